@@ -0,0 +1,83 @@
+package uuid
+
+import (
+	"errors"
+)
+
+// AliasScope registers alias as another name of the known scope canonical, e.g. after renaming a scope.
+// Both names can be used to generate UUIDs and match the same UUIDs with ScopeMatches, but Scope always
+// returns canonical. canonical can't be an alias itself.
+//
+// alias must follow the naming rules of SetScopes, otherwise a *ScopeNameError is returned, and must not
+// be any other scope or alias. Aliases are dropped by ReplaceScopes.
+func AliasScope(canonical, alias string) error {
+	var (
+		table *scopeTable
+		rule  string
+	)
+
+	scopesMu.Lock()
+	defer scopesMu.Unlock()
+
+	table = loadScopes()
+
+	if table.byName[canonical] == nil || table.isAlias(canonical) {
+		return ErrMissingScope
+	}
+
+	rule = checkScopeName(alias)
+	if alias == "" {
+		rule = RuleScopeNameEmpty
+	}
+
+	if rule != "" {
+		return &ScopeNameError{Index: scopeIndex(*table.byName[canonical]), Name: alias, Rule: rule}
+	}
+
+	if alias == canonical || table.byName[alias] != nil && table.byName[alias] != table.byName[canonical] {
+		return errors.New(ErrorBadAlias)
+	}
+
+	table = table.clone()
+	table.byName[alias] = table.byName[canonical]
+
+	currentScopes.Store(table)
+
+	return nil
+}
+
+// ScopeAliases returns all aliases set with AliasScope, mapping each alias to the scope it resolves to.
+// Scopes and ScopesSeq only list the scopes themselves.
+func ScopeAliases() map[string]string {
+	var (
+		table   *scopeTable
+		aliases map[string]string
+		scope   string
+	)
+
+	table = loadScopes()
+	aliases = make(map[string]string)
+
+	for scope = range table.byName {
+		if table.isAlias(scope) {
+			aliases[scope] = table.name(*table.byName[scope])
+		}
+	}
+
+	return aliases
+}
+
+// RemapScope returns a copy of the UUID with the scope changed from one scope to another, keeping all
+// other bits. It is meant for migrating stored UUIDs between scope tables in which the binary
+// representation of a scope differs. If the UUID is not of scope from, ErrorUnexpectedScope is returned.
+func RemapScope(uuid *UUID, from, to string) (*UUID, error) {
+	if uuid == nil || uuid.scope == "" {
+		return nil, errors.New(ErrorUninitializedUUID)
+	}
+
+	if !uuid.ScopeMatches([]string{from}) {
+		return nil, errors.New(ErrorUnexpectedScope)
+	}
+
+	return Rescope(uuid, to)
+}
@@ -0,0 +1,132 @@
+package uuid_test
+
+import (
+	"errors"
+	"github.com/4xoc/uuid"
+	"strings"
+	"testing"
+)
+
+func TestAliasScope(t *testing.T) {
+	var (
+		myUUID   *uuid.UUID
+		factory  *uuid.ScopeFactory
+		min, max *uuid.UUID
+		batch    []*uuid.UUID
+		nameErr  *uuid.ScopeNameError
+		err      error
+	)
+
+	setupScopes(t, "account", "post", "comment")
+
+	if err = uuid.AliasScope("ten", "acct"); err != uuid.ErrMissingScope {
+		t.Error("Expected ErrMissingScope for unknown target but got ", err)
+	}
+
+	if err = uuid.AliasScope("account", "post"); err == nil || err.Error() != uuid.ErrorBadAlias {
+		t.Error("Expected error for aliasing over an existing scope but got ", err)
+	}
+
+	if err = uuid.AliasScope("account", "acct"); err != nil {
+		t.Fatal("Expected alias to be set but failed with error ", err.Error())
+	}
+
+	//setting the same alias again is fine, but not an alias of an alias or to another scope
+	if err = uuid.AliasScope("account", "acct"); err != nil {
+		t.Error("Expected alias to be set again but got ", err)
+	}
+
+	if err = uuid.AliasScope("acct", "acc"); err != uuid.ErrMissingScope {
+		t.Error("Expected ErrMissingScope for alias as target but got ", err)
+	}
+
+	if err = uuid.AliasScope("post", "acct"); err == nil || err.Error() != uuid.ErrorBadAlias {
+		t.Error("Expected error for moving an alias but got ", err)
+	}
+
+	if err = uuid.AliasScope("account", "account"); err == nil || err.Error() != uuid.ErrorBadAlias {
+		t.Error("Expected error for aliasing a scope to itself but got ", err)
+	}
+
+	//aliases follow the naming rules of scopes
+	for _, alias := range []string{"", "Acct", "acct.old", strings.Repeat("a", 33)} {
+		if err = uuid.AliasScope("post", alias); !errors.As(err, &nameErr) || nameErr.Name != alias || nameErr.Index != 1 ||
+			!errors.Is(err, uuid.ErrBadScopeName) {
+			t.Error("Expected ScopeNameError for alias ", alias, " but got ", err)
+		}
+	}
+
+	//generating with the alias results in the canonical scope
+	myUUID = mustNew(t, "acct")
+	batch, _ = uuid.NewBatch("acct", 2)
+	factory, _ = uuid.ForScope("acct")
+	generated := []*uuid.UUID{myUUID, batch[1], mustRead(t, myUUID.Hex())}
+
+	//scopes don't have bounds in the trailing layout
+	if min, max, err = uuid.ScopeBounds("acct"); testLayout == uuid.ScopeLeading {
+		generated = append(generated, min, max)
+	} else if err == nil || err.Error() != uuid.ErrorLayoutUnsupported {
+		t.Error("Expected ErrorLayoutUnsupported for bounds but got ", err)
+	}
+
+	for index, generated := range generated {
+		if generated.Scope() != "account" || !generated.ScopeMatches([]string{"acct"}) ||
+			!generated.ScopeMatches([]string{"account"}) || generated.ScopeMatches([]string{"post"}) {
+			t.Error("test case ", index, ": unexpected scope ", generated.Scope())
+		}
+	}
+
+	if factory.Scope() != "account" || scopeByteOf(myUUID) != 0x00 {
+		t.Error("alias should resolve to the byte of its scope")
+	}
+
+	if !myUUID.IsValid() {
+		t.Error("UUID generated with an alias should be valid")
+	}
+
+	//aliases are not listed as scopes of their own
+	if counts := uuid.Counters(); len(counts) != 3 {
+		t.Error("unexpected scopes ", counts)
+	}
+
+	if uuid.NewSet(myUUID).FilterScope("acct").Len() != 1 {
+		t.Error("filtering by alias should match the scope")
+	}
+
+	if aliases := uuid.ScopeAliases(); len(aliases) != 1 || aliases["acct"] != "account" {
+		t.Error("unexpected aliases ", aliases)
+	}
+}
+
+func TestRemapScope(t *testing.T) {
+	var (
+		myUUID   *uuid.UUID
+		remapped *uuid.UUID
+		err      error
+	)
+
+	setupScopes(t, "user", "post")
+
+	myUUID = mustNew(t, "user")
+
+	remapped, err = uuid.RemapScope(myUUID, "user", "post")
+	if err != nil || remapped.Scope() != "post" {
+		t.Fatal("Expected remapped UUID but got ", err)
+	}
+
+	if withoutScope(remapped) != withoutScope(myUUID) || remapped.Variant() != myUUID.Variant() || myUUID.Scope() != "user" {
+		t.Error("remapping should only change the scope of a copy")
+	}
+
+	if _, err = uuid.RemapScope(myUUID, "post", "user"); err == nil || err.Error() != uuid.ErrorUnexpectedScope {
+		t.Error("Expected error for UUID of another scope but got ", err)
+	}
+
+	if _, err = uuid.RemapScope(myUUID, "user", "ten"); err != uuid.ErrMissingScope {
+		t.Error("Expected ErrMissingScope but got ", err)
+	}
+
+	if _, err = uuid.RemapScope(nil, "user", "post"); err == nil || err.Error() != uuid.ErrorUninitializedUUID {
+		t.Error("Expected error for nil UUID but got ", err)
+	}
+}
@@ -0,0 +1,196 @@
+package uuid
+
+import (
+	"math"
+	"math/bits"
+	"sort"
+)
+
+const (
+	// AnalyzeMinCount is the number of UUIDs an Analyzer needs before flagging byte positions of low
+	// entropy. Smaller samples don't allow telling bias from chance.
+	AnalyzeMinCount int = 1024
+	// AnalyzeEntropyMargin is the number of bits the estimated entropy of a byte position may fall short
+	// of its random bits before the position is flagged.
+	AnalyzeEntropyMargin float64 = 0.5
+)
+
+// Report is the result of analyzing a corpus of UUIDs with Analyze or an Analyzer, e.g. for detecting
+// UUIDs generated with a broken entropy source.
+type Report struct {
+	// Count is the number of analyzed UUIDs.
+	Count int
+	// Skipped is the number of nil and uninitialized UUIDs, which are not analyzed.
+	Skipped int
+	// Scopes holds the number of UUIDs per scope. UUIDs of an unknown scope (see ReadAny) are counted with
+	// the empty string as scope.
+	Scopes map[string]int
+	// LowBits holds the number of UUIDs per value of the low two bits of the byte holding the scope,
+	// which are random with 6 bit scopes.
+	LowBits [4]int
+	// RandomBits holds the number of random bits of each byte position: 8 for all bytes but the one
+	// holding the scope, which has 2 (see ScopeLayout and SetScopeWidth). The high four bits of byte 1
+	// don't count when a table version is set (see SetTableVersion).
+	RandomBits [16]int
+	// Entropy holds the estimated Shannon entropy in bits of the random bits of each byte position,
+	// using the Miller-Madow correction of the sample entropy. For random data, it is about RandomBits.
+	Entropy [16]float64
+	// LowEntropy lists the byte positions whose Entropy falls short of RandomBits by more than
+	// AnalyzeEntropyMargin bits. It is always empty for less than AnalyzeMinCount UUIDs.
+	LowEntropy []int
+	// Duplicates lists each UUID analyzed more than once.
+	Duplicates []*UUID
+}
+
+// Suspicious returns true if the report lists byte positions of low entropy or duplicates.
+//
+// UUIDs created with NewOrdered, NewCompat or NewFromReader hold fixed or derived bits and are flagged as
+// well; analyze them separately from UUIDs created with New.
+func (report *Report) Suspicious() bool {
+	return len(report.LowEntropy) > 0 || len(report.Duplicates) > 0
+}
+
+// Analyzer analyzes a stream of UUIDs one at a time, see Analyze. It keeps every UUID for finding
+// duplicates. The zero value is ready to use. An Analyzer must not be used concurrently.
+type Analyzer struct {
+	// report holds the counts gathered so far.
+	report Report
+	// values counts the values of the random bits per byte position.
+	values [16][256]int
+	// seen counts the occurrences of each UUID.
+	seen map[[16]byte]int
+}
+
+// Add analyzes the UUID.
+func (analyzer *Analyzer) Add(uuid *UUID) {
+	var (
+		tmpUUID UUID
+		index   int
+		mask    [16]byte
+	)
+
+	if uuid == nil || uuid.scope == "" {
+		analyzer.report.Skipped++
+		return
+	}
+
+	if analyzer.seen == nil {
+		analyzer.seen = make(map[[16]byte]int)
+		analyzer.report.Scopes = make(map[string]int)
+	}
+
+	analyzer.seen[uuid.bin]++
+	if analyzer.seen[uuid.bin] == 2 {
+		tmpUUID = *uuid
+		analyzer.report.Duplicates = append(analyzer.report.Duplicates, &tmpUUID)
+	}
+
+	analyzer.report.Count++
+	analyzer.report.Scopes[uuid.Scope()]++
+	analyzer.report.LowBits[uuid.bin[scopePosition()]&0x03]++
+
+	mask = randomMask()
+
+	for index = range uuid.bin {
+		analyzer.values[index][uuid.bin[index]&mask[index]]++
+	}
+}
+
+// Report returns the report of the UUIDs added so far.
+func (analyzer *Analyzer) Report() Report {
+	var (
+		report Report
+		mask   [16]byte
+		index  int
+	)
+
+	report = analyzer.report
+	report.Scopes = make(map[string]int, len(analyzer.report.Scopes))
+	report.Duplicates = append([]*UUID(nil), analyzer.report.Duplicates...)
+
+	for scope, count := range analyzer.report.Scopes {
+		report.Scopes[scope] = count
+	}
+
+	mask = randomMask()
+
+	for index = range report.Entropy {
+		report.RandomBits[index] = bits.OnesCount8(mask[index])
+		report.Entropy[index] = estimateEntropy(analyzer.values[index][:], report.Count, report.RandomBits[index])
+
+		if report.Count >= AnalyzeMinCount && report.Entropy[index] < float64(report.RandomBits[index])-AnalyzeEntropyMargin {
+			report.LowEntropy = append(report.LowEntropy, index)
+		}
+	}
+
+	sort.Slice(report.Duplicates, func(i, j int) bool {
+		return Compare(report.Duplicates[i], report.Duplicates[j]) < 0
+	})
+
+	return report
+}
+
+// Analyze returns a report on the given UUIDs: their scopes, the distribution of the low two bits of the
+// byte holding the scope, an entropy estimate of each byte position and duplicates. Use an Analyzer for
+// corpora that don't fit into memory as a slice.
+//
+// A byte position is flagged in LowEntropy if its estimated entropy is more than AnalyzeEntropyMargin
+// bits below its number of random bits, which is only checked for at least AnalyzeMinCount UUIDs.
+func Analyze(uuids []*UUID) Report {
+	var (
+		analyzer Analyzer
+	)
+
+	for _, uuid := range uuids {
+		analyzer.Add(uuid)
+	}
+
+	return analyzer.Report()
+}
+
+// randomMask returns the random bits of each byte position of UUIDs created with New.
+func randomMask() [16]byte {
+	var (
+		mask [16]byte
+	)
+
+	for index := range mask {
+		mask[index] = 0xff
+	}
+
+	mask[scopePosition()] &^= scopeMask()
+
+	if tableVersion.Load() != nil {
+		mask[1] &= 0x0f
+	}
+
+	return mask
+}
+
+// estimateEntropy returns the Miller-Madow corrected sample entropy in bits of the given counts of count
+// values, never more than the given number of random bits.
+func estimateEntropy(counts []int, count int, randomBits int) float64 {
+	var (
+		entropy float64
+		bins    int
+		p       float64
+	)
+
+	if count == 0 {
+		return 0
+	}
+
+	for _, n := range counts {
+		if n == 0 {
+			continue
+		}
+
+		bins++
+		p = float64(n) / float64(count)
+		entropy -= p * math.Log2(p)
+	}
+
+	entropy += float64(bins-1) / (2 * float64(count) * math.Ln2)
+
+	return math.Min(entropy, float64(randomBits))
+}
@@ -0,0 +1,121 @@
+package uuid_test
+
+import (
+	"bytes"
+	"github.com/4xoc/uuid"
+	"testing"
+)
+
+func TestAnalyze(t *testing.T) {
+	var (
+		uuids  []*uuid.UUID
+		report uuid.Report
+	)
+
+	setupScopes(t, "one", "two")
+
+	uuids, _ = uuid.NewBatch("one", 3000)
+	more, _ := uuid.NewBatch("two", 1000)
+	uuids = append(uuids, more...)
+
+	report = uuid.Analyze(uuids)
+
+	if report.Count != 4000 || report.Scopes["one"] != 3000 || report.Scopes["two"] != 1000 || report.Skipped != 0 {
+		t.Error("unexpected counts ", report.Count, report.Scopes, report.Skipped)
+	}
+
+	if report.Suspicious() || len(report.LowEntropy) != 0 || len(report.Duplicates) != 0 {
+		t.Error("healthy corpus is flagged: ", report.LowEntropy, report.Entropy)
+	}
+
+	for index, count := range report.LowBits {
+		if count < 800 || count > 1200 {
+			t.Error("unexpected count of low bits ", index, ": ", count)
+		}
+	}
+
+	for index := range report.Entropy {
+		if report.RandomBits[index] == 2 && report.Entropy[index] < 1.9 || report.RandomBits[index] == 8 && report.Entropy[index] < 7.9 {
+			t.Error("unexpected entropy of byte ", index, ": ", report.Entropy[index])
+		}
+	}
+}
+
+// TestAnalyzeBiased feeds UUIDs generated from a broken entropy source.
+func TestAnalyzeBiased(t *testing.T) {
+	var (
+		uuids    []*uuid.UUID
+		report   uuid.Report
+		position int
+	)
+
+	setupScopes(t, "one")
+
+	uuids, _ = uuid.NewBatch("one", 2000)
+
+	//all low bits zero like with a miswired random source
+	if testLayout == uuid.ScopeTrailing {
+		position = 15
+	}
+
+	for index := range uuids {
+		bin := uuids[index].Bin()
+		bin[position] &^= 0x03
+
+		uuids[index], _ = uuid.FromRFC(bin)
+	}
+
+	//a few duplicates
+	uuids = append(uuids, uuids[7], uuids[7], uuids[3])
+
+	report = uuid.Analyze(append(uuids, nil, &uuid.UUID{}))
+
+	if !report.Suspicious() || len(report.LowEntropy) != 1 || report.LowEntropy[0] != position ||
+		report.LowBits[0] != len(uuids) || report.Skipped != 2 {
+		t.Error("biased corpus is not flagged: ", report.LowEntropy, report.LowBits, report.Entropy)
+	}
+
+	if len(report.Duplicates) != 2 || !bytes.Equal(sorted(uuids[3], uuids[7]), sorted(report.Duplicates...)) {
+		t.Error("unexpected duplicates ", report.Duplicates)
+	}
+}
+
+// TestAnalyzeSmall checks that small samples aren't flagged even though their sample entropy is low.
+func TestAnalyzeSmall(t *testing.T) {
+	var (
+		analyzer uuid.Analyzer
+		report   uuid.Report
+	)
+
+	setupScopes(t, "one")
+
+	for range uuid.AnalyzeMinCount - 1 {
+		analyzer.Add(mustNew(t, "one"))
+
+		report = analyzer.Report()
+		if report.Suspicious() {
+			t.Fatal("small sample of ", report.Count, " UUIDs is flagged: ", report.Entropy)
+		}
+	}
+
+	if (&uuid.Analyzer{}).Report().Count != 0 {
+		t.Error("expected empty report")
+	}
+}
+
+// sorted returns the concatenated binary representation of the UUIDs in ascending order.
+func sorted(uuids ...*uuid.UUID) []byte {
+	var (
+		buf []byte
+	)
+
+	uuids = append([]*uuid.UUID(nil), uuids...)
+	uuid.Sort(uuids)
+
+	for _, myUUID := range uuids {
+		bin := myUUID.Bin()
+		buf = append(buf, bin[:]...)
+	}
+
+	return buf
+}
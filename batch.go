@@ -0,0 +1,73 @@
+package uuid
+
+import (
+	"errors"
+)
+
+// maxBatchSize limits the number of UUIDs that can be created by a single call of NewBatch so that
+// the size of the random data buffer can't overflow.
+const maxBatchSize int = int(^uint(0)>>1) / 16
+
+// NewBatch generates n new UUIDs, all of them using the provided scope. Instead of reading random
+// data for every single UUID, all random data is read at once which reduces the overhead when
+// creating a large number of UUIDs. Batches larger than the internal entropy buffer are always read
+// directly from crypto/rand.
+//
+// The function either returns all n UUIDs or none of them along with an error. Like New, it fails
+// when the scope doesn't exist yet.
+func NewBatch(scope string, n int) ([]*UUID, error) {
+	var (
+		name      string
+		scopeByte byte
+		err       error
+	)
+
+	if n <= 0 || n > maxBatchSize {
+		return nil, errors.New(ErrorBadBatchSize)
+	}
+
+	name, scopeByte, err = loadScopes().resolveNew(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	return newBatch(name, scopeByte, n)
+}
+
+// newBatch generates n UUIDs of the given scope and its binary representation.
+func newBatch(scope string, scopeByte byte, n int) ([]*UUID, error) {
+	var (
+		buf   []byte
+		uuids []UUID
+		batch []*UUID
+		index int
+		err   error
+	)
+
+	buf = make([]byte, n*16)
+
+	err = readEntropy(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	//all UUIDs share one backing array to keep the number of allocations low
+	uuids = make([]UUID, n)
+	batch = make([]*UUID, n)
+
+	for index = range uuids {
+		copy(uuids[index].bin[:], buf[index*16:(index+1)*16])
+
+		//set scope, keeping the random low two bits
+		uuids[index].setScopeBits(scopeByte)
+		uuids[index].scope = scope
+
+		stampTableVersion(uuids[index].bin[:])
+
+		batch[index] = &uuids[index]
+	}
+
+	reportGenerate(scope, scopeByte, n)
+
+	return batch, nil
+}
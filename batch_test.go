@@ -0,0 +1,93 @@
+package uuid_test
+
+import (
+	"github.com/4xoc/uuid"
+	"testing"
+)
+
+func TestNewBatch(t *testing.T) {
+	var (
+		batch  []*uuid.UUID
+		myCopy *uuid.UUID
+		seen   map[string]bool
+		index  int
+		err    error
+	)
+
+	//trying uninitialized package (no scopes set)
+	_, err = uuid.NewBatch("one", 10)
+	if err == nil || err.Error() != uuid.ErrorMissingScope {
+		t.Error("There are no scopes defined thus there should be no batch")
+	}
+
+	setupScopes(t, "one", "two", "three")
+
+	//bad batch sizes
+	for _, n := range []int{0, -1, uuid.MaxBatchSize + 1} {
+		_, err = uuid.NewBatch("one", n)
+		if err == nil || err.Error() != uuid.ErrorBadBatchSize {
+			t.Error("Expected error for batch size ", n)
+		}
+	}
+
+	//unknown scope
+	_, err = uuid.NewBatch("ten", 10)
+	if err == nil || err.Error() != uuid.ErrorMissingScope {
+		t.Error("Expected error for unknown scope")
+	}
+
+	batch, err = uuid.NewBatch("two", 1000)
+	if err != nil {
+		t.Fatal("Expected batch to be generated but failed with error ", err.Error())
+	}
+
+	if len(batch) != 1000 {
+		t.Fatal("Expected 1000 UUIDs but got ", len(batch))
+	}
+
+	seen = make(map[string]bool)
+
+	for index = range batch {
+		if batch[index].Scope() != "two" {
+			t.Error("UUID does not match the scope defined on creation time.")
+		}
+
+		if seen[batch[index].Hex()] {
+			t.Error("UUID ", batch[index].Hex(), " has been generated twice")
+		}
+		seen[batch[index].Hex()] = true
+
+		//every UUID must be readable again
+		myCopy, err = uuid.Read(batch[index].Hex())
+		if err != nil {
+			t.Error("Expected UUID to be readable but failed with error ", err.Error())
+			continue
+		}
+
+		if myCopy.Bin() != batch[index].Bin() || myCopy.Scope() != "two" {
+			t.Error("UUIDs should be identical but aren't")
+		}
+	}
+}
+
+func BenchmarkNewBatch(b *testing.B) {
+	setupScopes(b, "one")
+
+	for i := 0; i < b.N; i++ {
+		if _, err := uuid.NewBatch("one", 10000); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkNewLoop(b *testing.B) {
+	setupScopes(b, "one")
+
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < 10000; j++ {
+			if _, err := uuid.New("one"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package uuid
+
+import (
+	"errors"
+)
+
+const (
+	// cborTagUUID is the head of CBOR tag 37 (major type 6, 1 byte argument), marking a UUID.
+	cborTagUUID string = "\xd8\x25"
+	// cborBytes16 is the head of a CBOR byte string of 16 bytes.
+	cborBytes16 byte = 0x50
+	// cborText36 is the head of a CBOR text string of 36 bytes.
+	cborText36 string = "\x78\x24"
+	// cborNull is the CBOR simple value null.
+	cborNull byte = 0xf6
+)
+
+// MarshalCBOR returns the UUID as CBOR byte string of 16 bytes, tagged with tag 37. Uninitialized UUIDs
+// return ErrorUninitializedUUID.
+func (uuid UUID) MarshalCBOR() ([]byte, error) {
+	var (
+		buf []byte
+	)
+
+	if uuid.scope == "" {
+		return nil, errors.New(ErrorUninitializedUUID)
+	}
+
+	buf = make([]byte, 0, 19)
+	buf = append(buf, cborTagUUID...)
+	buf = append(buf, cborBytes16)
+
+	return append(buf, uuid.bin[:]...), nil
+}
+
+// UnmarshalCBOR parses a CBOR encoded UUID. Accepted are byte strings of 16 bytes, with or without tag
+// 37, and untagged text strings holding the canonical hex-string. Null results in the zero value. Like
+// Read, the scope of the UUID must be known. On failure, the UUID is set to the zero value.
+func (uuid *UUID) UnmarshalCBOR(data []byte) error {
+	var (
+		tmpUUID *UUID
+		tagged  bool
+		err     error
+	)
+
+	*uuid = UUID{}
+
+	if len(data) == 1 && data[0] == cborNull {
+		return nil
+	}
+
+	if len(data) >= 2 && string(data[:2]) == cborTagUUID {
+		data = data[2:]
+		tagged = true
+	} else if len(data) > 0 && data[0]>>5 == 6 {
+		//any other tag
+		return errors.New(ErrorBadCBOR)
+	}
+
+	switch {
+	case len(data) == 17 && data[0] == cborBytes16:
+		tmpUUID = &UUID{}
+		copy(tmpUUID.bin[:], data[1:])
+
+		err = tmpUUID.resolveScope()
+	case len(data) > 0 && data[0]>>5 == 2:
+		//byte string of any other length
+		return errors.New(ErrorBadLength)
+	case !tagged && len(data) == 38 && string(data[:2]) == cborText36:
+		tmpUUID, err = Read(string(data[2:]))
+	default:
+		return errors.New(ErrorBadCBOR)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	*uuid = *tmpUUID
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package uuid_test
+
+import (
+	"github.com/4xoc/uuid"
+	"testing"
+)
+
+func TestCBOR(t *testing.T) {
+	var (
+		myUUID  *uuid.UUID
+		decoded uuid.UUID
+		data    []byte
+		bin     [16]byte
+		err     error
+	)
+
+	setupScopes(t, "one", "two")
+
+	myUUID = mustNew(t, "two")
+	bin = myUUID.Bin()
+
+	data, err = myUUID.MarshalCBOR()
+	if err != nil || string(data) != "\xd8\x25\x50"+string(bin[:]) {
+		t.Error("unexpected CBOR encoding ", data, err)
+	}
+
+	if _, err = (uuid.UUID{}).MarshalCBOR(); err == nil || err.Error() != uuid.ErrorUninitializedUUID {
+		t.Error("Expected error for uninitialized UUID but got ", err)
+	}
+
+	//all accepted shapes
+	for index, input := range []string{
+		string(data),
+		"\x50" + string(bin[:]),
+		"\x78\x24" + myUUID.Hex(),
+	} {
+		decoded = uuid.UUID{}
+
+		if err = decoded.UnmarshalCBOR([]byte(input)); err != nil {
+			t.Error("test case ", index, ": failed to unmarshal: ", err)
+		}
+
+		if decoded.Bin() != bin || decoded.Scope() != "two" {
+			t.Error("test case ", index, ": UUID doesn't round-trip")
+		}
+	}
+
+	testCases := []struct {
+		input string
+		err   string
+	}{
+		{"", uuid.ErrorBadCBOR},
+		{"\xd8\x25", uuid.ErrorBadCBOR},
+		{"\xd8\x20\x50" + string(bin[:]), uuid.ErrorBadCBOR},
+		{"\xc0\x50" + string(bin[:]), uuid.ErrorBadCBOR},
+		{"\x4f" + string(bin[:15]), uuid.ErrorBadLength},
+		{"\xd8\x25\x51" + string(bin[:]) + "\x00", uuid.ErrorBadLength},
+		{"\x50" + string(bin[:]) + "\x00", uuid.ErrorBadLength},
+		{"\xd8\x25\x78\x24" + myUUID.Hex(), uuid.ErrorBadCBOR},
+		{"\x78\x24" + myUUID.HexUpper(), uuid.ErrorBadString},
+		{"\x50\xfc" + string(bin[1:15]) + "\xfc", uuid.ErrorBadScope},
+		{"\x02", uuid.ErrorBadCBOR},
+	}
+
+	for index := range testCases {
+		decoded = *myUUID
+
+		err = decoded.UnmarshalCBOR([]byte(testCases[index].input))
+		if err == nil || err.Error() != testCases[index].err {
+			t.Error("test case ", index, ": expected error ", testCases[index].err, " but got ", err)
+		}
+
+		if decoded != (uuid.UUID{}) {
+			t.Error("test case ", index, ": failed unmarshal didn't reset the UUID")
+		}
+	}
+
+	if err = decoded.UnmarshalCBOR([]byte{0xf6}); err != nil || decoded != (uuid.UUID{}) {
+		t.Error("null should result in the zero value but got ", err)
+	}
+}
@@ -0,0 +1,94 @@
+package uuid
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+var (
+	// ErrChecksumMismatch is returned by ReadChecked when the UUID is well-formed but the checksum
+	// doesn't match. Its message is ErrorChecksumMismatch.
+	ErrChecksumMismatch = errors.New(ErrorChecksumMismatch)
+)
+
+// checksum calculates the CRC-16/CCITT-FALSE checksum (polynomial 0x1021, initial value 0xffff) of the
+// given bytes. It catches all errors that change at most 16 consecutive bits, which includes any
+// substituted hex character and any two swapped adjacent hex characters.
+func checksum(data []byte) uint16 {
+	var (
+		crc   uint16 = 0xffff
+		index int
+		bit   int
+	)
+
+	for index = range data {
+		crc ^= uint16(data[index]) << 8
+
+		for bit = 0; bit < 8; bit++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+
+	return crc
+}
+
+// HexChecked returns the canonical hex-string of the UUID followed by a dot and four hex characters of
+// a checksum over the binary representation, e.g. "9c4fb1d0-84f3-4d8d-b6cc-682d1ca34dae.1a2b". It is
+// meant for IDs that get typed in by hand; see ReadChecked.
+//
+// If the UUID is not initialized, an empty string is returned.
+func (uuid *UUID) HexChecked() string {
+	var (
+		buf [41]byte
+		crc uint16
+	)
+
+	if uuid == nil || uuid.scope == "" {
+		return ""
+	}
+
+	crc = checksum(uuid.bin[:])
+
+	appendHex(buf[:0], uuid.bin[:])
+	buf[36] = '.'
+	hex.Encode(buf[37:], []byte{byte(crc >> 8), byte(crc)})
+
+	return string(buf[:])
+}
+
+// ReadChecked parses a string as returned by HexChecked. The checksum is verified before the scope gets
+// resolved; if only the checksum doesn't match, ErrChecksumMismatch is returned.
+func ReadChecked(input string) (*UUID, error) {
+	var (
+		index    int
+		tmpBytes []byte
+		tmpCRC   []byte
+		err      error
+	)
+
+	index = strings.LastIndexByte(input, '.')
+	if index != 36 || len(input) != 41 || !canonicalPattern.MatchString(input[:index]) {
+		return nil, ErrBadString
+	}
+
+	tmpCRC, err = hex.DecodeString(input[index+1:])
+	if err != nil || strings.ToLower(input[index+1:]) != input[index+1:] {
+		return nil, ErrBadString
+	}
+
+	tmpBytes, err = hex.DecodeString(strings.Replace(input[:index], "-", "", -1))
+	if err != nil {
+		return nil, ErrBadString
+	}
+
+	if checksum(tmpBytes) != uint16(tmpCRC[0])<<8|uint16(tmpCRC[1]) {
+		return nil, ErrChecksumMismatch
+	}
+
+	return Read(input[:index])
+}
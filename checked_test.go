@@ -0,0 +1,92 @@
+package uuid_test
+
+import (
+	"github.com/4xoc/uuid"
+	"testing"
+)
+
+func TestChecked(t *testing.T) {
+	var (
+		myUUID  *uuid.UUID
+		nilPtr  *uuid.UUID
+		myCopy  *uuid.UUID
+		checked string
+		typo    []byte
+		index   int
+		char    byte
+		err     error
+	)
+
+	setupScopes(t, "one", "two")
+
+	if nilPtr.HexChecked() != "" {
+		t.Error("nil UUID should return an empty string")
+	}
+
+	myUUID, _ = uuid.New("two")
+	checked = myUUID.HexChecked()
+
+	if len(checked) != 41 || checked[:36] != myUUID.Hex() || checked[36] != '.' {
+		t.Fatal("unexpected checked hex-string ", checked)
+	}
+
+	myCopy, err = uuid.ReadChecked(checked)
+	if err != nil || myCopy.Hex() != myUUID.Hex() || myCopy.Scope() != "two" {
+		t.Fatal("checked hex-string should round-trip")
+	}
+
+	//every single substitution of a hex character must be caught
+	for index = 0; index < 36; index++ {
+		if checked[index] == '-' {
+			continue
+		}
+
+		for _, char = range []byte("0123456789abcdef") {
+			if char == checked[index] {
+				continue
+			}
+
+			typo = []byte(checked)
+			typo[index] = char
+
+			_, err = uuid.ReadChecked(string(typo))
+			if err != uuid.ErrChecksumMismatch {
+				t.Fatal("substitution not detected in ", string(typo))
+			}
+		}
+	}
+
+	//every transposition of adjacent hex characters must be caught
+	for index = 0; index < 35; index++ {
+		typo = []byte(checked)
+
+		if typo[index] == '-' || typo[index+1] == '-' || typo[index] == typo[index+1] {
+			continue
+		}
+
+		typo[index], typo[index+1] = typo[index+1], typo[index]
+
+		_, err = uuid.ReadChecked(string(typo))
+		if err != uuid.ErrChecksumMismatch {
+			t.Fatal("transposition not detected in ", string(typo))
+		}
+	}
+
+	//malformatted strings
+	for _, input := range []string{"", myUUID.Hex(), checked[:40], checked + "0", checked[:37] + "ABCD", checked[:36] + "-" + checked[37:]} {
+		_, err = uuid.ReadChecked(input)
+		if err == nil || err.Error() != uuid.ErrorBadString {
+			t.Error("Expected error for malformatted string ", input)
+		}
+	}
+
+	//correct checksum but unknown scope
+	myUUID = mustRead(t, "00000000-0000-0000-0000-000000000000")
+	uuid.ResetScopes()
+	uuid.SetScopes([64]string{1: "two"})
+
+	_, err = uuid.ReadChecked(myUUID.HexChecked())
+	if err == nil || err.Error() != uuid.ErrorBadScope {
+		t.Error("Expected error for unknown scope")
+	}
+}
@@ -0,0 +1,218 @@
+// Command uuid generates and inspects scoped UUIDs.
+//
+// The scope table is read from a JSON file holding an array of up to 64 scope names; the position of
+// each name defines its binary representation, exactly like the array passed to uuid.SetScopes.
+//
+//	uuid [-scopes file] new <scope> [-n count]
+//	uuid [-scopes file] inspect <id>
+//	uuid [-scopes file] validate < ids.txt
+//
+// The scope file defaults to the value of the UUID_SCOPES environment variable.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/4xoc/uuid"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run executes the command given by args and returns the exit status.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	var (
+		flags      *flag.FlagSet
+		scopesFile *string
+		err        error
+	)
+
+	flags = flag.NewFlagSet("uuid", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	scopesFile = flags.String("scopes", os.Getenv("UUID_SCOPES"), "JSON file holding the array of scope names")
+
+	if err = flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if flags.NArg() == 0 {
+		fmt.Fprintln(stderr, "usage: uuid [-scopes file] new|inspect|validate ...")
+		return 2
+	}
+
+	if err = loadScopes(*scopesFile); err != nil {
+		fmt.Fprintln(stderr, "failed to load scopes:", err)
+		return 1
+	}
+
+	switch flags.Arg(0) {
+	case "new":
+		return runNew(flags.Args()[1:], stdout, stderr)
+	case "inspect":
+		return runInspect(flags.Args()[1:], stdout, stderr)
+	case "validate":
+		return runValidate(stdin, stdout, stderr)
+	}
+
+	fmt.Fprintln(stderr, "unknown command", flags.Arg(0))
+	return 2
+}
+
+// loadScopes reads the scope table from the given JSON file and sets it.
+func loadScopes(path string) error {
+	var (
+		data   []byte
+		names  []string
+		scopes [64]string
+		err    error
+	)
+
+	if path == "" {
+		return errors.New("no scope file given")
+	}
+
+	data, err = os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	if err = json.Unmarshal(data, &names); err != nil {
+		return err
+	}
+
+	if len(names) > len(scopes) {
+		return errors.New(uuid.ErrorOutOfScopes)
+	}
+
+	copy(scopes[:], names)
+
+	return uuid.SetScopes(scopes)
+}
+
+// runNew generates and prints new UUIDs.
+func runNew(args []string, stdout, stderr io.Writer) int {
+	var (
+		flags  *flag.FlagSet
+		count  *int
+		scope  string
+		uuids  []*uuid.UUID
+		writer *bufio.Writer
+		err    error
+	)
+
+	flags = flag.NewFlagSet("new", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	count = flags.Int("n", 1, "number of UUIDs to generate")
+
+	//the count may be given before or after the scope
+	if err = flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if flags.NArg() == 0 {
+		fmt.Fprintln(stderr, "usage: uuid new <scope> [-n count]")
+		return 2
+	}
+
+	scope = flags.Arg(0)
+
+	if err = flags.Parse(flags.Args()[1:]); err != nil || flags.NArg() != 0 {
+		fmt.Fprintln(stderr, "usage: uuid new <scope> [-n count]")
+		return 2
+	}
+
+	uuids, err = uuid.NewBatch(scope, *count)
+	if err != nil {
+		fmt.Fprintln(stderr, "failed to generate UUIDs:", err)
+		return 1
+	}
+
+	writer = bufio.NewWriter(stdout)
+
+	for _, myUUID := range uuids {
+		writer.Write(myUUID.AppendHex(nil))
+		writer.WriteByte('\n')
+	}
+
+	if err = writer.Flush(); err != nil {
+		fmt.Fprintln(stderr, "failed to write UUIDs:", err)
+		return 1
+	}
+
+	return 0
+}
+
+// runInspect prints the details of a UUID.
+func runInspect(args []string, stdout, stderr io.Writer) int {
+	var (
+		myUUID *uuid.UUID
+		bin    [16]byte
+		err    error
+	)
+
+	if len(args) != 1 {
+		fmt.Fprintln(stderr, "usage: uuid inspect <id>")
+		return 2
+	}
+
+	myUUID, err = uuid.Read(args[0])
+	if err != nil {
+		fmt.Fprintln(stderr, "invalid UUID:", err)
+		return 1
+	}
+
+	bin = myUUID.Bin()
+
+	fmt.Fprintf(stdout, "hex:     %s\n", myUUID.Hex())
+	fmt.Fprintf(stdout, "scope:   %s\n", myUUID.Scope())
+	fmt.Fprintf(stdout, "variant: %02b\n", myUUID.Variant())
+	fmt.Fprintf(stdout, "binary:  %08b\n", bin[:])
+
+	return 0
+}
+
+// runValidate reads UUIDs line by line and reports the ones that fail to parse.
+func runValidate(stdin io.Reader, stdout, stderr io.Writer) int {
+	var (
+		scanner *bufio.Scanner
+		line    int
+		failed  int
+		input   string
+		err     error
+	)
+
+	scanner = bufio.NewScanner(stdin)
+
+	for scanner.Scan() {
+		line++
+
+		input = strings.TrimSpace(scanner.Text())
+		if input == "" {
+			continue
+		}
+
+		if _, err = uuid.Read(input); err != nil {
+			fmt.Fprintf(stdout, "line %d: %s: %s\n", line, input, err)
+			failed++
+		}
+	}
+
+	if err = scanner.Err(); err != nil {
+		fmt.Fprintln(stderr, "failed to read input:", err)
+		return 1
+	}
+
+	if failed > 0 {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/4xoc/uuid"
+)
+
+// TestMain runs the command instead of the tests when the test binary has been started by runTest.
+// Since the scope table can only be set once per process, every run needs its own process.
+func TestMain(m *testing.M) {
+	if os.Getenv("UUID_CLI_TEST") == "1" {
+		main()
+	}
+
+	os.Exit(m.Run())
+}
+
+// runTest runs the command in a new process and returns its exit status and output.
+func runTest(t *testing.T, stdin string, args ...string) (int, string, string) {
+	var (
+		path    string
+		cmd     *exec.Cmd
+		stdout  bytes.Buffer
+		stderr  bytes.Buffer
+		exitErr *exec.ExitError
+		err     error
+	)
+
+	t.Helper()
+
+	path = filepath.Join(t.TempDir(), "scopes.json")
+	if err = os.WriteFile(path, []byte(`["user", "order"]`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd = exec.Command(os.Args[0], append([]string{"-scopes", path}, args...)...)
+	cmd.Env = append(os.Environ(), "UUID_CLI_TEST=1")
+	cmd.Stdin = strings.NewReader(stdin)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err = cmd.Run()
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode(), stdout.String(), stderr.String()
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return 0, stdout.String(), stderr.String()
+}
+
+func TestNew(t *testing.T) {
+	var (
+		status int
+		stdout string
+		lines  []string
+	)
+
+	status, stdout, _ = runTest(t, "", "new", "order", "-n", "100000")
+	lines = strings.Fields(stdout)
+
+	if status != 0 || len(lines) != 100000 {
+		t.Fatal("Expected 100000 UUIDs but got ", len(lines))
+	}
+
+	status, stdout, _ = runTest(t, "", "new", "user")
+	if status != 0 || len(strings.Fields(stdout)) != 1 || stdout[:2] != "00" && stdout[:2] != "01" && stdout[:2] != "02" && stdout[:2] != "03" {
+		t.Error("unexpected output ", stdout)
+	}
+
+	if status, _, _ = runTest(t, "", "new", "ten"); status != 1 {
+		t.Error("Expected failure for unknown scope")
+	}
+
+	if status, _, _ = runTest(t, "", "new"); status != 2 {
+		t.Error("Expected usage error")
+	}
+}
+
+func TestInspect(t *testing.T) {
+	var (
+		status int
+		stdout string
+	)
+
+	status, stdout, _ = runTest(t, "", "inspect", "0529a1d0-84f3-4d8d-b6cc-682d1ca34dae")
+	if status != 0 || !strings.Contains(stdout, "scope:   order\n") || !strings.Contains(stdout, "variant: 01\n") ||
+		!strings.Contains(stdout, "binary:  [00000101 00101001") {
+		t.Error("unexpected output ", stdout)
+	}
+
+	if status, _, _ = runTest(t, "", "inspect", "fc29a1d0-84f3-4d8d-b6cc-682d1ca34dae"); status != 1 {
+		t.Error("Expected failure for unknown scope")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	var (
+		status int
+		stdout string
+	)
+
+	status, _, _ = runTest(t, "0529a1d0-84f3-4d8d-b6cc-682d1ca34dae\n\n0029a1d0-84f3-4d8d-b6cc-682d1ca34dae\n", "validate")
+	if status != 0 {
+		t.Error("Expected all UUIDs to be valid")
+	}
+
+	status, stdout, _ = runTest(t, "0529a1d0-84f3-4d8d-b6cc-682d1ca34dae\nfoo\n", "validate")
+	if status != 1 || stdout != "line 2: foo: "+uuid.ErrorBadString+"\n" {
+		t.Error("unexpected output ", stdout)
+	}
+}
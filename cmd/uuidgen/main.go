@@ -0,0 +1,363 @@
+// Command uuidgen generates Go constants for the scopes of a scope table, so that typos in scope names
+// fail to compile instead of failing at runtime.
+//
+// The scope table is read from a JSON file in the format written by uuid.DumpScopes. The generated file
+// holds a constant for every scope and alias, named after the scope in camel case with the prefix
+// "Scope" (e.g. ScopeInvoiceV1 for "invoice_v1"), and an init function setting the scopes, aliases and
+// deprecations of the table. It is meant to be run by go generate:
+//
+//	//go:generate go run github.com/4xoc/uuid/cmd/uuidgen -o scopes_gen.go scopes.json
+//
+// The flags are:
+//
+//	-pkg name   package of the generated file, defaults to $GOPACKAGE as set by go generate
+//	-o file     file to write the Go code to instead of stdout
+//	-init=false don't generate the init function, e.g. if the scopes are set elsewhere
+//	-ts file    additionally write the scopes as TypeScript constants to file
+//
+// The output only depends on the scope table, so it can be committed and diffed like any other code.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/4xoc/uuid"
+)
+
+// options holds the settings of a single run.
+type options struct {
+	// pkg is the package of the generated Go file.
+	pkg string
+	// source is the name of the scope table file, written into the header of the generated files.
+	source string
+	// init defines if the init function is generated.
+	init bool
+}
+
+// scopeConst describes the constant generated for a scope or an alias.
+type scopeConst struct {
+	// ident is the name of the constant.
+	ident string
+	// name is the name of the scope or alias.
+	name string
+	// entry is the scope itself or the scope the alias resolves to.
+	entry *uuid.ScopeTableEntry
+	// alias is true if name is an alias of entry.
+	alias bool
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes the command given by args and returns the exit status.
+func run(args []string, stdout, stderr io.Writer) int {
+	var (
+		flags  *flag.FlagSet
+		opts   options
+		output *string
+		tsFile *string
+		table  uuid.ScopeTable
+		data   []byte
+		err    error
+	)
+
+	flags = flag.NewFlagSet("uuidgen", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.StringVar(&opts.pkg, "pkg", os.Getenv("GOPACKAGE"), "package of the generated file")
+	flags.BoolVar(&opts.init, "init", true, "generate an init function setting the scopes")
+	output = flags.String("o", "", "file to write the Go code to instead of stdout")
+	tsFile = flags.String("ts", "", "file to write TypeScript constants to")
+
+	if err = flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if flags.NArg() != 1 || opts.pkg == "" {
+		fmt.Fprintln(stderr, "usage: uuidgen [-pkg name] [-o file] [-init=false] [-ts file] <scopes.json>")
+		return 2
+	}
+
+	opts.source = filepath.Base(flags.Arg(0))
+
+	data, err = os.ReadFile(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintln(stderr, "failed to read scope table:", err)
+		return 1
+	}
+
+	if err = json.Unmarshal(data, &table); err != nil {
+		fmt.Fprintln(stderr, "failed to decode scope table:", err)
+		return 1
+	}
+
+	data, err = generateGo(table, opts)
+	if err != nil {
+		fmt.Fprintln(stderr, "failed to generate Go code:", err)
+		return 1
+	}
+
+	if err = writeOutput(*output, data, stdout); err != nil {
+		fmt.Fprintln(stderr, "failed to write Go code:", err)
+		return 1
+	}
+
+	if *tsFile != "" {
+		data, err = generateTS(table, opts)
+		if err == nil {
+			err = os.WriteFile(*tsFile, data, 0o644)
+		}
+
+		if err != nil {
+			fmt.Fprintln(stderr, "failed to write TypeScript code:", err)
+			return 1
+		}
+	}
+
+	return 0
+}
+
+// writeOutput writes data to the file at path or to stdout if path is empty.
+func writeOutput(path string, data []byte, stdout io.Writer) error {
+	var (
+		err error
+	)
+
+	if path == "" {
+		_, err = stdout.Write(data)
+		return err
+	}
+
+	return os.WriteFile(path, data, 0o644)
+}
+
+// constants returns the constants of all scopes and aliases of the table, ordered by the binary
+// representation of their scope and aliases after their scope. It also reports whether the table uses 8
+// bit scopes (see uuid.SetScopeWidth).
+func constants(table uuid.ScopeTable) ([]scopeConst, bool, error) {
+	var (
+		entries []uuid.ScopeTableEntry
+		consts  []scopeConst
+		aliases []string
+		idents  map[string]string
+		wide    bool
+		index   int
+		name    string
+	)
+
+	entries = append([]uuid.ScopeTableEntry{}, table.Scopes...)
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Index < entries[j].Index })
+
+	idents = make(map[string]string)
+
+	for index = range entries {
+		if entries[index].Index < 0 || entries[index].Index > 255 || entries[index].Name == "" {
+			return nil, false, fmt.Errorf("scope %q has the invalid index %d", entries[index].Name, entries[index].Index)
+		}
+
+		if index > 0 && entries[index].Index == entries[index-1].Index {
+			return nil, false, fmt.Errorf("scopes %q and %q share the index %d", entries[index-1].Name,
+				entries[index].Name, entries[index].Index)
+		}
+
+		//with 6 bit scopes the low two bits of the byte are not part of the scope
+		if entries[index].Index > 63 || int(entries[index].Byte) != entries[index].Index<<2 {
+			wide = true
+		}
+
+		consts = append(consts, scopeConst{name: entries[index].Name, entry: &entries[index]})
+
+		aliases = append([]string{}, entries[index].Aliases...)
+		sort.Strings(aliases)
+
+		for _, name = range aliases {
+			consts = append(consts, scopeConst{name: name, entry: &entries[index], alias: true})
+		}
+	}
+
+	for index = range consts {
+		consts[index].ident = constName(consts[index].name)
+
+		if name, ok := idents[consts[index].ident]; ok {
+			return nil, false, fmt.Errorf("scopes %q and %q both result in the constant %s", name,
+				consts[index].name, consts[index].ident)
+		}
+
+		idents[consts[index].ident] = consts[index].name
+	}
+
+	if len(consts) == 0 {
+		return nil, false, errors.New("the scope table holds no scopes")
+	}
+
+	return consts, wide, nil
+}
+
+// constName returns the name of the Go constant of the given scope, e.g. ScopeInvoiceV1 for "invoice_v1".
+// Characters other than letters and digits separate words.
+func constName(scope string) string {
+	var (
+		ident strings.Builder
+		upper = true
+	)
+
+	ident.WriteString("Scope")
+
+	for _, char := range scope {
+		switch {
+		case char >= 'a' && char <= 'z' && upper:
+			ident.WriteRune(char - 'a' + 'A')
+			upper = false
+		case char >= 'a' && char <= 'z' || char >= 'A' && char <= 'Z' || char >= '0' && char <= '9':
+			ident.WriteRune(char)
+			upper = false
+		default:
+			upper = true
+		}
+	}
+
+	return ident.String()
+}
+
+// generateGo returns the formatted Go code of the constants and the init function.
+func generateGo(table uuid.ScopeTable, opts options) ([]byte, error) {
+	var (
+		consts []scopeConst
+		wide   bool
+		buf    bytes.Buffer
+		index  int
+		err    error
+	)
+
+	consts, wide, err = constants(table)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Fprintf(&buf, "// Code generated by uuidgen from %s; DO NOT EDIT.\n\n", opts.source)
+	fmt.Fprintf(&buf, "package %s\n\n", opts.pkg)
+
+	if opts.init {
+		fmt.Fprintf(&buf, "import \"github.com/4xoc/uuid\"\n\n")
+	}
+
+	buf.WriteString("const (\n")
+
+	for index = range consts {
+		if index > 0 {
+			buf.WriteString("\n")
+		}
+
+		writeGoDoc(&buf, consts[index])
+		fmt.Fprintf(&buf, "%s = %s\n", consts[index].ident, strconv.Quote(consts[index].name))
+	}
+
+	buf.WriteString(")\n")
+
+	if opts.init {
+		writeGoInit(&buf, consts, wide)
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+// writeGoDoc writes the doc comment of a constant.
+func writeGoDoc(buf *bytes.Buffer, c scopeConst) {
+	if c.alias {
+		fmt.Fprintf(buf, "// %s is the alias %q of the scope %q.\n", c.ident, c.name, c.entry.Name)
+	} else {
+		fmt.Fprintf(buf, "// %s is the scope %q.\n", c.ident, c.name)
+	}
+
+	//the description of the scope is not repeated for its aliases
+	if !c.alias && c.entry.Info != nil && c.entry.Info.Description != "" {
+		fmt.Fprintf(buf, "//\n// %s\n", strings.Join(strings.Fields(c.entry.Info.Description), " "))
+	}
+
+	if !c.alias && c.entry.Info != nil && c.entry.Info.Owner != "" {
+		fmt.Fprintf(buf, "//\n// Owner: %s\n", strings.Join(strings.Fields(c.entry.Info.Owner), " "))
+	}
+
+	if c.entry.Deprecated {
+		buf.WriteString("//\n// Deprecated: new UUIDs of the scope can't be generated anymore.\n")
+	}
+}
+
+// writeGoInit writes the init function setting the scopes, aliases and deprecations.
+func writeGoInit(buf *bytes.Buffer, consts []scopeConst, wide bool) {
+	var (
+		index int
+	)
+
+	buf.WriteString("\nfunc init() {\n")
+
+	if wide {
+		buf.WriteString("if err := uuid.SetScopeWidth(8); err != nil {\npanic(err)\n}\n\n")
+		buf.WriteString("if err := uuid.SetWideScopes([256]string{\n")
+	} else {
+		buf.WriteString("if err := uuid.SetScopes([64]string{\n")
+	}
+
+	for index = range consts {
+		if !consts[index].alias {
+			fmt.Fprintf(buf, "%d: %s,\n", consts[index].entry.Index, consts[index].ident)
+		}
+	}
+
+	buf.WriteString("}); err != nil {\npanic(err)\n}\n")
+
+	for index = range consts {
+		if consts[index].alias {
+			fmt.Fprintf(buf, "\nif err := uuid.AliasScope(%s, %s); err != nil {\npanic(err)\n}\n",
+				constName(consts[index].entry.Name), consts[index].ident)
+		}
+	}
+
+	for index = range consts {
+		if !consts[index].alias && consts[index].entry.Deprecated {
+			fmt.Fprintf(buf, "\nif err := uuid.DeprecateScope(%s); err != nil {\npanic(err)\n}\n", consts[index].ident)
+		}
+	}
+
+	buf.WriteString("}\n")
+}
+
+// generateTS returns TypeScript code holding the binary representation of every scope and alias and a
+// union type of their names.
+func generateTS(table uuid.ScopeTable, opts options) ([]byte, error) {
+	var (
+		consts []scopeConst
+		buf    bytes.Buffer
+		index  int
+		err    error
+	)
+
+	consts, _, err = constants(table)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Fprintf(&buf, "// Code generated by uuidgen from %s; DO NOT EDIT.\n\n", opts.source)
+	buf.WriteString("// Scopes maps the name of each scope and alias to the binary representation of its scope.\n")
+	buf.WriteString("export const Scopes = {\n")
+
+	for index = range consts {
+		fmt.Fprintf(&buf, "  %s: 0x%02x,\n", strconv.Quote(consts[index].name), consts[index].entry.Byte)
+	}
+
+	buf.WriteString("} as const;\n\n")
+	buf.WriteString("export type Scope = keyof typeof Scopes;\n")
+
+	return buf.Bytes(), nil
+}
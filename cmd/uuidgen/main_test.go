@@ -0,0 +1,207 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/4xoc/uuid"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+// checkGolden compares data with the golden file at path, updating it if -update is given.
+func checkGolden(t *testing.T, path string, data []byte) {
+	var (
+		golden []byte
+		err    error
+	)
+
+	t.Helper()
+
+	if *update {
+		if err = os.WriteFile(path, data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	golden, err = os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(data, golden) {
+		t.Errorf("output doesn't match %s:\n%s", path, data)
+	}
+}
+
+func TestRun(t *testing.T) {
+	var (
+		dir    string
+		stdout bytes.Buffer
+		stderr bytes.Buffer
+		data   []byte
+		status int
+		err    error
+	)
+
+	dir = t.TempDir()
+
+	status = run([]string{"-pkg", "ids", "-ts", filepath.Join(dir, "scopes.ts"), "testdata/scopes.json"}, &stdout, &stderr)
+	if status != 0 {
+		t.Fatal("unexpected status ", status, stderr.String())
+	}
+
+	checkGolden(t, "testdata/scopes.go.golden", stdout.Bytes())
+
+	data, err = os.ReadFile(filepath.Join(dir, "scopes.ts"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkGolden(t, "testdata/scopes.ts.golden", data)
+
+	//generating again results in the same output
+	status = run([]string{"-pkg", "ids", "-o", filepath.Join(dir, "scopes.go"), "testdata/scopes.json"}, &stdout, &stderr)
+	if data, err = os.ReadFile(filepath.Join(dir, "scopes.go")); status != 0 || err != nil {
+		t.Fatal("unexpected status ", status, err)
+	}
+
+	checkGolden(t, "testdata/scopes.go.golden", data)
+
+	t.Setenv("GOPACKAGE", "")
+
+	for _, args := range [][]string{
+		{"testdata/scopes.json"},
+		{"-pkg", "ids"},
+		{"-pkg", "ids", "-unknown", "testdata/scopes.json"},
+	} {
+		if status = run(args, &stdout, &stderr); status != 2 {
+			t.Error("expected usage error for ", args, " but got ", status)
+		}
+	}
+
+	if status = run([]string{"-pkg", "ids", filepath.Join(dir, "missing.json")}, &stdout, &stderr); status != 1 {
+		t.Error("expected failure for missing file but got ", status)
+	}
+}
+
+func TestGenerateGo(t *testing.T) {
+	var (
+		data []byte
+		err  error
+	)
+
+	data, err = generateGo(uuid.ScopeTable{Scopes: []uuid.ScopeTableEntry{
+		{Index: 0, Name: "user", Byte: 0x00},
+		{Index: 1, Name: "post", Byte: 0x01},
+		{Index: 200, Name: "comment", Byte: 0xc8},
+	}}, options{pkg: "ids", source: "wide.json", init: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Contains(data, []byte("uuid.SetScopeWidth(8)")) || !bytes.Contains(data, []byte("uuid.SetWideScopes([256]string{")) ||
+		!bytes.Contains(data, []byte("200: ScopeComment,")) {
+		t.Error("expected 8 bit scopes but got\n", string(data))
+	}
+
+	data, err = generateGo(uuid.ScopeTable{Scopes: []uuid.ScopeTableEntry{{Index: 0, Name: "user"}}},
+		options{pkg: "ids", source: "scopes.json"})
+	if err != nil || bytes.Contains(data, []byte("import")) || bytes.Contains(data, []byte("func init")) {
+		t.Error("expected no init function but got\n", string(data))
+	}
+
+	for index, table := range []uuid.ScopeTable{
+		{},
+		{Scopes: []uuid.ScopeTableEntry{{Index: 0, Name: "a_b"}, {Index: 1, Name: "a-b", Byte: 0x04}}},
+		{Scopes: []uuid.ScopeTableEntry{{Index: 0, Name: "user", Aliases: []string{"post"}}, {Index: 1, Name: "post", Byte: 0x04}}},
+		{Scopes: []uuid.ScopeTableEntry{{Index: 0, Name: "user"}, {Index: 0, Name: "post"}}},
+		{Scopes: []uuid.ScopeTableEntry{{Index: 256, Name: "user"}}},
+		{Scopes: []uuid.ScopeTableEntry{{Index: 0, Name: ""}}},
+	} {
+		if _, err = generateGo(table, options{pkg: "ids"}); err == nil {
+			t.Error("test case ", index, ": expected error")
+		}
+	}
+}
+
+func TestConstName(t *testing.T) {
+	for scope, ident := range map[string]string{
+		"user":       "ScopeUser",
+		"invoice_v1": "ScopeInvoiceV1",
+		"invoice-v2": "ScopeInvoiceV2",
+		"a__b":       "ScopeAB",
+		"2fa":        "Scope2fa",
+	} {
+		if constName(scope) != ident {
+			t.Errorf("expected %s for %q but got %s", ident, scope, constName(scope))
+		}
+	}
+}
+
+// TestGeneratedCode compiles the generated code in a module of its own and runs it.
+func TestGeneratedCode(t *testing.T) {
+	var (
+		dir    string
+		root   string
+		data   []byte
+		output []byte
+		cmd    *exec.Cmd
+		err    error
+	)
+
+	if testing.Short() {
+		t.Skip("compiling the generated code takes a while")
+	}
+
+	dir = t.TempDir()
+
+	root, err = filepath.Abs("../..")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err = os.ReadFile("testdata/scopes.go.golden")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data = bytes.Replace(data, []byte("package ids"), []byte("package main"), 1)
+
+	for name, content := range map[string]string{
+		"go.mod":    "module ids\n\ngo 1.24\n\nrequire github.com/4xoc/uuid v0.0.0\n\nreplace github.com/4xoc/uuid => " + root + "\n",
+		"scopes.go": string(data),
+		"main.go": `package main
+
+import (
+	"fmt"
+
+	"github.com/4xoc/uuid"
+)
+
+func main() {
+	myUUID, err := uuid.New(ScopeMember)
+	_, deprecated := uuid.New(ScopeInvoiceV1)
+	fmt.Println(myUUID.Scope(), err, deprecated == uuid.ErrScopeDeprecated)
+}
+`,
+	} {
+		if err = os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cmd = exec.Command("go", "run", ".")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod")
+
+	output, err = cmd.CombinedOutput()
+	if err != nil || strings.TrimSpace(string(output)) != "user <nil> true" {
+		t.Fatal("unexpected output of the generated code ", string(output), err)
+	}
+}
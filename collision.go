@@ -0,0 +1,51 @@
+package uuid
+
+import (
+	"errors"
+)
+
+// collisionRetries limits the number of times NewUnique regenerates a UUID that already exists.
+const collisionRetries int = 3
+
+// ErrCollisionRetryExhausted is returned by NewUnique when every generated candidate already exists. Its
+// message is ErrorCollision.
+var ErrCollisionRetryExhausted = errors.New(ErrorCollision)
+
+// NewUnique generates a new UUID like New and checks it with the given exists function, e.g. a lookup in
+// the database the UUID is imported into. A UUID reported to exist is regenerated up to 3 times before
+// ErrCollisionRetryExhausted is returned. Errors returned by exists are passed through unchanged.
+//
+// If exists is nil, NewUnique is the same as New.
+func NewUnique(scope string, exists func(*UUID) (bool, error)) (*UUID, error) {
+	var (
+		uuid    *UUID
+		attempt int
+		taken   bool
+		err     error
+	)
+
+	uuid, err = New(scope)
+	if err != nil || exists == nil {
+		return uuid, err
+	}
+
+	for attempt = 0; ; attempt++ {
+		taken, err = exists(uuid)
+		if err != nil {
+			return nil, err
+		}
+
+		if !taken {
+			return uuid, nil
+		}
+
+		if attempt == collisionRetries {
+			return nil, ErrCollisionRetryExhausted
+		}
+
+		err = uuid.Regenerate()
+		if err != nil {
+			return nil, err
+		}
+	}
+}
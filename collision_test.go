@@ -0,0 +1,64 @@
+package uuid_test
+
+import (
+	"errors"
+	"github.com/4xoc/uuid"
+	"testing"
+)
+
+// takenFirst returns an exists function reporting the first k candidates as taken and counting calls.
+func takenFirst(k int, calls *int) func(*uuid.UUID) (bool, error) {
+	return func(*uuid.UUID) (bool, error) {
+		*calls++
+		return *calls <= k, nil
+	}
+}
+
+func TestNewUnique(t *testing.T) {
+	var (
+		myUUID *uuid.UUID
+		calls  int
+		err    error
+	)
+
+	setupScopes(t, "one", "two")
+
+	for k := 0; k <= 3; k++ {
+		calls = 0
+
+		myUUID, err = uuid.NewUnique("two", takenFirst(k, &calls))
+		if err != nil || myUUID.Scope() != "two" {
+			t.Error("k=", k, ": Expected UUID but got ", err)
+		}
+
+		if calls != k+1 {
+			t.Error("k=", k, ": Expected ", k+1, " checks but got ", calls)
+		}
+	}
+
+	calls = 0
+
+	myUUID, err = uuid.NewUnique("two", takenFirst(4, &calls))
+	if err != uuid.ErrCollisionRetryExhausted || myUUID != nil || calls != 4 {
+		t.Error("Expected ErrCollisionRetryExhausted after 4 checks but got ", err, calls)
+	}
+
+	//errors of the check are passed through
+	errLookup := errors.New("lookup failed")
+
+	_, err = uuid.NewUnique("two", func(*uuid.UUID) (bool, error) {
+		return false, errLookup
+	})
+	if err != errLookup {
+		t.Error("Expected error of the check but got ", err)
+	}
+
+	if _, err = uuid.NewUnique("ten", takenFirst(0, &calls)); err != uuid.ErrMissingScope {
+		t.Error("Expected ErrMissingScope but got ", err)
+	}
+
+	myUUID, err = uuid.NewUnique("one", nil)
+	if err != nil || myUUID.Scope() != "one" {
+		t.Error("Expected UUID without check but got ", err)
+	}
+}
@@ -0,0 +1,148 @@
+package uuid
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"errors"
+	"sort"
+)
+
+// Compare compares the binary representation of two UUIDs byte by byte. The result is 0 if a == b, -1
+// if a < b and +1 if a > b. A nil UUID sorts before everything else.
+//
+// Since the scope is stored in the first byte, sorting UUIDs groups them by scope. Compare can be used
+// with slices.SortFunc.
+func Compare(a, b *UUID) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+
+	return bytes.Compare(a.bin[:], b.bin[:])
+}
+
+// Equal returns true if both UUIDs have the same binary representation or both are nil.
+func Equal(a, b *UUID) bool {
+	return Compare(a, b) == 0
+}
+
+// EqualConstantTime returns the same result as Equal but takes the same time regardless of how many
+// bytes of both UUIDs match. Use it when UUIDs are used as secrets (e.g. tokens) to avoid leaking
+// information via timing; Equal is faster and fine everywhere else. Nil UUIDs are compared like a UUID
+// with all bytes 0 and don't return early.
+func EqualConstantTime(a, b *UUID) bool {
+	var (
+		aBin [16]byte
+		bBin [16]byte
+		aNil byte
+		bNil byte
+	)
+
+	if a != nil {
+		aBin = a.bin
+	} else {
+		aNil = 1
+	}
+
+	if b != nil {
+		bBin = b.bin
+	} else {
+		bNil = 1
+	}
+
+	return subtle.ConstantTimeCompare(aBin[:], bBin[:])&subtle.ConstantTimeByteEq(aNil, bNil) == 1
+}
+
+// Less returns true if the UUID sorts before the other. See Compare function.
+func (uuid *UUID) Less(other *UUID) bool {
+	return Compare(uuid, other) < 0
+}
+
+// Sort sorts a slice of UUIDs in ascending order as defined by Compare.
+func Sort(uuids []*UUID) {
+	sort.Slice(uuids, func(i, j int) bool {
+		return Compare(uuids[i], uuids[j]) < 0
+	})
+}
+
+// ScopeBounds returns the smallest and the largest possible UUID of the given scope. Since the scope is
+// stored in the most significant bits, all UUIDs of a scope lie in this range when sorted in binary or
+// hex-string order, which can be used for range queries (e.g. "id >= min AND id <= max").
+//
+// With ScopeTrailing, UUIDs of a scope don't share a range and ErrorLayoutUnsupported is returned.
+func ScopeBounds(scope string) (min, max *UUID, err error) {
+	var (
+		name      string
+		scopeByte byte
+		ok        bool
+	)
+
+	name, scopeByte, ok = loadScopes().resolve(scope)
+	if !ok {
+		return nil, nil, ErrMissingScope
+	}
+
+	if scopeLayout != ScopeLeading {
+		return nil, nil, errors.New(ErrorLayoutUnsupported)
+	}
+
+	min = &UUID{scope: name}
+	min.bin[0] = scopeByte
+
+	max = &UUID{scope: min.scope}
+	max.bin = [16]byte{
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+	max.bin[0] = scopeByte | ^scopeMask()
+
+	return min, max, nil
+}
+
+// Next returns the smallest UUID that is greater than this one, treating the 16 bytes as a big endian
+// 128bit integer. If that UUID would be of a different scope (see ScopeBounds), ErrorScopeOverflow is
+// returned. With ScopeTrailing, ErrorLayoutUnsupported is returned.
+func (uuid *UUID) Next() (*UUID, error) {
+	return uuid.step(1)
+}
+
+// Prev returns the largest UUID that is smaller than this one. See Next function.
+func (uuid *UUID) Prev() (*UUID, error) {
+	return uuid.step(-1)
+}
+
+// step adds delta (1 or -1) to the UUID, carrying over from the least significant byte.
+func (uuid *UUID) step(delta int) (*UUID, error) {
+	var (
+		tmpUUID UUID
+		index   int
+	)
+
+	if uuid == nil || uuid.scope == "" {
+		return nil, errors.New(ErrorUninitializedUUID)
+	}
+
+	if scopeLayout != ScopeLeading {
+		return nil, errors.New(ErrorLayoutUnsupported)
+	}
+
+	tmpUUID = *uuid
+
+	for index = 15; index >= 0; index-- {
+		tmpUUID.bin[index] += byte(delta)
+
+		//stop unless the byte wrapped around
+		if (delta > 0 && tmpUUID.bin[index] != 0x00) || (delta < 0 && tmpUUID.bin[index] != 0xff) {
+			break
+		}
+	}
+
+	if index < 0 || tmpUUID.scopeBits() != uuid.scopeBits() {
+		return nil, errors.New(ErrorScopeOverflow)
+	}
+
+	return &tmpUUID, nil
+}
@@ -0,0 +1,249 @@
+package uuid_test
+
+import (
+	"github.com/4xoc/uuid"
+	"slices"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	var (
+		myUUID *uuid.UUID
+		low    *uuid.UUID
+		high   *uuid.UUID
+		uuids  []*uuid.UUID
+		scopes []string
+		index  int
+		err    error
+	)
+
+	setupScopes(t, "one", "two", "three")
+
+	low = mustRead(t, "00000000-0000-0000-0000-000000000001")
+	high = mustRead(t, "04000000-0000-0000-0000-000000000000")
+
+	if testLayout == uuid.ScopeTrailing {
+		high = mustRead(t, "00000000-0000-0000-0000-000000000004")
+	}
+
+	testCases := []struct {
+		a, b   *uuid.UUID
+		result int
+	}{
+		{nil, nil, 0},
+		{nil, low, -1},
+		{low, nil, 1},
+		{low, low, 0},
+		{low, high, -1},
+		{high, low, 1},
+	}
+
+	for index = range testCases {
+		if uuid.Compare(testCases[index].a, testCases[index].b) != testCases[index].result {
+			t.Error("test case ", index, ": unexpected result of Compare")
+		}
+
+		if uuid.Equal(testCases[index].a, testCases[index].b) != (testCases[index].result == 0) {
+			t.Error("test case ", index, ": Equal isn't consistent with Compare")
+		}
+
+		if testCases[index].a.Less(testCases[index].b) != (testCases[index].result < 0) {
+			t.Error("test case ", index, ": Less isn't consistent with Compare")
+		}
+	}
+
+	//a copy compares equal
+	if !uuid.Equal(high, mustRead(t, high.Hex())) {
+		t.Error("copy of UUID should be equal")
+	}
+
+	//sorting groups UUIDs by scope
+	for index = 0; index < 300; index++ {
+		myUUID, err = uuid.New([]string{"three", "one", "two"}[index%3])
+		if err != nil {
+			t.Fatal("Expected UUID to be generated but failed with error ", err.Error())
+		}
+
+		uuids = append(uuids, myUUID)
+	}
+
+	uuids = append(uuids, nil)
+
+	uuid.Sort(uuids)
+
+	if uuids[0] != nil {
+		t.Error("nil should sort first")
+	}
+
+	for index = 1; index < len(uuids); index++ {
+		if len(scopes) == 0 || scopes[len(scopes)-1] != uuids[index].Scope() {
+			scopes = append(scopes, uuids[index].Scope())
+		}
+	}
+
+	//the trailing layout spreads the scopes over the whole range
+	if testLayout == uuid.ScopeLeading && !slices.Equal(scopes, []string{"one", "two", "three"}) {
+		t.Error("sorted UUIDs should be grouped by scope but got ", scopes)
+	}
+
+	//the comparator works with slices.SortFunc
+	slices.Reverse(uuids)
+	slices.SortFunc(uuids, uuid.Compare)
+
+	if !slices.IsSortedFunc(uuids, uuid.Compare) || uuids[0] != nil {
+		t.Error("UUIDs should be sorted")
+	}
+}
+
+// mustRead reads the given string or fails the test.
+func mustRead(t *testing.T, input string) *uuid.UUID {
+	var (
+		myUUID *uuid.UUID
+		err    error
+	)
+
+	t.Helper()
+
+	myUUID, err = uuid.Read(input)
+	if err != nil {
+		t.Fatal("Expected UUID ", input, " to be read but failed with error ", err.Error())
+	}
+
+	return myUUID
+}
+
+func TestEqualConstantTime(t *testing.T) {
+	var (
+		uuids []*uuid.UUID
+		zero  *uuid.UUID
+		i, j  int
+		err   error
+	)
+
+	setupScopes(t, "one", "two")
+
+	uuids, err = uuid.NewBatch("one", 200)
+	if err != nil {
+		t.Fatal("Expected batch to be generated but failed with error ", err.Error())
+	}
+
+	//copies, nil and a UUID with all bytes 0 which must not be equal to nil
+	zero = mustRead(t, "00000000-0000-0000-0000-000000000000")
+	uuids = append(uuids, mustRead(t, uuids[0].Hex()), nil, zero)
+
+	for i = range uuids {
+		for j = range uuids {
+			if uuid.EqualConstantTime(uuids[i], uuids[j]) != uuid.Equal(uuids[i], uuids[j]) {
+				t.Fatal("EqualConstantTime doesn't agree with Equal for ", i, " and ", j)
+			}
+		}
+	}
+}
+
+func TestScopeBounds(t *testing.T) {
+	var (
+		min, max *uuid.UUID
+		myUUID   *uuid.UUID
+		index    int
+		err      error
+	)
+
+	setupScopes(t, "one", "two", "three")
+
+	if _, _, err = uuid.ScopeBounds("ten"); err == nil || err.Error() != uuid.ErrorMissingScope {
+		t.Error("Expected error for unknown scope")
+	}
+
+	//scopes don't share a range in the trailing layout
+	if testLayout == uuid.ScopeTrailing {
+		if _, _, err = uuid.ScopeBounds("two"); err == nil || err.Error() != uuid.ErrorLayoutUnsupported {
+			t.Error("Expected ErrorLayoutUnsupported but got ", err)
+		}
+
+		return
+	}
+
+	min, max, err = uuid.ScopeBounds("two")
+	if err != nil {
+		t.Fatal("Expected bounds but failed with error ", err.Error())
+	}
+
+	if min.Hex() != "04000000-0000-0000-0000-000000000000" || max.Hex() != "07ffffff-ffff-ffff-ffff-ffffffffffff" {
+		t.Error("unexpected bounds ", min.Hex(), max.Hex())
+	}
+
+	if min.Scope() != "two" || mustRead(t, max.Hex()).Scope() != "two" {
+		t.Error("bounds should be of scope two")
+	}
+
+	for index = 0; index < 1000; index++ {
+		myUUID = mustNew(t, []string{"one", "two", "three"}[index%3])
+
+		if (uuid.Compare(myUUID, min) >= 0 && uuid.Compare(myUUID, max) <= 0) != (myUUID.Scope() == "two") {
+			t.Fatal("UUID ", myUUID.Hex(), " of scope ", myUUID.Scope(), " is on the wrong side of the bounds")
+		}
+	}
+}
+
+func TestNextPrev(t *testing.T) {
+	var (
+		min, max *uuid.UUID
+		myUUID   *uuid.UUID
+		nilPtr   *uuid.UUID
+		err      error
+	)
+
+	setupScopes(t, "one", "two")
+
+	if _, err = nilPtr.Next(); err == nil || err.Error() != uuid.ErrorUninitializedUUID {
+		t.Error("Expected error for nil UUID")
+	}
+
+	//stepping would change the scope in the trailing layout
+	if testLayout == uuid.ScopeTrailing {
+		if _, err = mustNew(t, "two").Next(); err == nil || err.Error() != uuid.ErrorLayoutUnsupported {
+			t.Error("Expected ErrorLayoutUnsupported but got ", err)
+		}
+
+		return
+	}
+
+	testCases := []struct {
+		input, next string
+	}{
+		{"04000000-0000-0000-0000-000000000000", "04000000-0000-0000-0000-000000000001"},
+		{"04000000-0000-0000-0000-0000000000ff", "04000000-0000-0000-0000-000000000100"},
+		//carry over many bytes including the low bits of the first byte
+		{"04ffffff-ffff-ffff-ffff-ffffffffffff", "05000000-0000-0000-0000-000000000000"},
+		{"06ffffff-ffff-ffff-ffff-ffffffffffff", "07000000-0000-0000-0000-000000000000"},
+	}
+
+	for index := range testCases {
+		myUUID, err = mustRead(t, testCases[index].input).Next()
+		if err != nil || myUUID.Hex() != testCases[index].next || myUUID.Scope() != "two" {
+			t.Error("test case ", index, ": unexpected next UUID ", myUUID.Hex())
+		}
+
+		myUUID, err = mustRead(t, testCases[index].next).Prev()
+		if err != nil || myUUID.Hex() != testCases[index].input || myUUID.Scope() != "two" {
+			t.Error("test case ", index, ": unexpected previous UUID ", myUUID.Hex())
+		}
+	}
+
+	//the bounds of a scope can't be left
+	min, max, _ = uuid.ScopeBounds("two")
+
+	if _, err = max.Next(); err == nil || err.Error() != uuid.ErrorScopeOverflow {
+		t.Error("Expected error for next of max")
+	}
+
+	if _, err = min.Prev(); err == nil || err.Error() != uuid.ErrorScopeOverflow {
+		t.Error("Expected error for previous of min")
+	}
+
+	//the very first and last UUID
+	min, _, _ = uuid.ScopeBounds("one")
+	if _, err = min.Prev(); err == nil || err.Error() != uuid.ErrorScopeOverflow {
+		t.Error("Expected error for previous of the smallest UUID")
+	}
+}
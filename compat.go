@@ -0,0 +1,38 @@
+package uuid
+
+// NewCompat generates a new UUID like New but additionally sets the version and variant bits as
+// defined by RFC 4122 for version 4 UUIDs. That way UUIDs of this package pass validators which expect
+// RFC 4122 compliant random UUIDs.
+//
+// Setting those bits reduces the random part of the UUID by another 6 bits, leaving 116 random bits
+// for each scope instead of 122.
+func NewCompat(scope string) (*UUID, error) {
+	var (
+		uuid *UUID
+		err  error
+	)
+
+	uuid, err = New(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	//version 4 in the high nibble of byte 6
+	uuid.bin[6] = uuid.bin[6]&0x0f | 0x40
+	//variant 10 in the top bits of byte 8
+	uuid.bin[8] = uuid.bin[8]&0x3f | 0x80
+
+	return uuid, nil
+}
+
+// IsRFC4122 returns true if the UUID carries the version and variant bits of a RFC 4122 version 4
+// UUID, like UUIDs generated by NewCompat do. UUIDs generated by New may carry them by chance.
+//
+// If the UUID is not initialized, false is returned.
+func (uuid *UUID) IsRFC4122() bool {
+	if uuid == nil {
+		return false
+	}
+
+	return uuid.bin[6]&0xf0 == 0x40 && uuid.bin[8]&0xc0 == 0x80
+}
@@ -0,0 +1,59 @@
+package uuid_test
+
+import (
+	"github.com/4xoc/uuid"
+	"testing"
+)
+
+func TestNewCompat(t *testing.T) {
+	var (
+		myUUID *uuid.UUID
+		myCopy *uuid.UUID
+		nilPtr *uuid.UUID
+		hex    string
+		index  int
+		err    error
+	)
+
+	setupScopes(t, "one", "two")
+
+	_, err = uuid.NewCompat("ten")
+	if err == nil || err.Error() != uuid.ErrorMissingScope {
+		t.Error("Expected error for unknown scope")
+	}
+
+	if nilPtr.IsRFC4122() {
+		t.Error("nil UUID must not be RFC 4122 compliant")
+	}
+
+	for index = 0; index < 1000; index++ {
+		myUUID, err = uuid.NewCompat("two")
+		if err != nil {
+			t.Fatal("Expected UUID to be generated but failed with error ", err.Error())
+		}
+
+		hex = myUUID.Hex()
+		if hex[14] != '4' || (hex[19] != '8' && hex[19] != '9' && hex[19] != 'a' && hex[19] != 'b') {
+			t.Error("UUID ", hex, " doesn't carry RFC 4122 version and variant")
+		}
+
+		if !myUUID.IsRFC4122() {
+			t.Error("UUID ", hex, " should be RFC 4122 compliant")
+		}
+
+		myCopy, err = uuid.Read(hex)
+		if err != nil || myCopy.Scope() != "two" || !myCopy.IsRFC4122() {
+			t.Error("Expected UUID ", hex, " to be read with scope two")
+		}
+	}
+
+	//non-compat UUIDs are still read fine
+	myCopy, err = uuid.Read(fixture("04000000-0000-0000-0000-000000000000"))
+	if err != nil || myCopy.Scope() != "two" {
+		t.Error("Expected non-compat UUID to be read with scope two")
+	}
+
+	if myCopy.IsRFC4122() {
+		t.Error("UUID should not be RFC 4122 compliant")
+	}
+}
@@ -0,0 +1,49 @@
+package uuid
+
+import (
+	"crypto/sha256"
+	"hash"
+	"io"
+)
+
+// NewFromReader derives a UUID of the given scope from the content of r, e.g. to deduplicate blobs by
+// their ID. The content is streamed through SHA-256 without buffering it; the UUID holds the first 16
+// bytes of the digest with the scope set in the upper six bits of the first byte while the low two bits
+// are kept from the digest. The same content always results in the same UUID for a scope, and an empty
+// reader results in the UUID of the empty content. Like New, the scope table version is written into the
+// UUID if one is set.
+//
+// Errors of r are returned as is. Unlike New, the UUID is not random at all and as easy to guess as the
+// content is.
+func NewFromReader(scope string, r io.Reader) (*UUID, error) {
+	var (
+		uuid      UUID
+		digest    hash.Hash
+		name      string
+		scopeByte byte
+		err       error
+	)
+
+	name, scopeByte, err = loadScopes().resolveNew(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	digest = sha256.New()
+
+	_, err = io.Copy(digest, r)
+	if err != nil {
+		return nil, err
+	}
+
+	copy(uuid.bin[:], digest.Sum(nil))
+
+	uuid.setScopeBits(scopeByte)
+	uuid.scope = name
+
+	stampTableVersion(uuid.bin[:])
+
+	reportGenerate(uuid.scope, scopeByte, 1)
+
+	return &uuid, nil
+}
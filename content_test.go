@@ -0,0 +1,64 @@
+package uuid_test
+
+import (
+	"bytes"
+	"errors"
+	"github.com/4xoc/uuid"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestNewFromReader(t *testing.T) {
+	var (
+		myUUID    *uuid.UUID
+		other     *uuid.UUID
+		errReader = errors.New("broken reader")
+		err       error
+	)
+
+	setupScopes(t, "one", "two")
+
+	myUUID, err = uuid.NewFromReader("two", strings.NewReader("hello world"))
+	if err != nil || myUUID.Scope() != "two" {
+		t.Fatal("unexpected result ", myUUID.Hex(), " ", err)
+	}
+
+	//sha256("hello world") = b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9 with the
+	//scope bits of "two" applied
+	expected := map[uuid.ScopeLayout][2]string{
+		uuid.ScopeLeading:  {"054d27b9-934d-3e08-a52e-52d7da7dabfa", "03b0c442-98fc-1c14-9afb-f4c8996fb924"},
+		uuid.ScopeTrailing: {"b94d27b9-934d-3e08-a52e-52d7da7dab06", "e3b0c442-98fc-1c14-9afb-f4c8996fb900"},
+	}[testLayout]
+
+	if myUUID.Hex() != expected[0] {
+		t.Error("unexpected UUID ", myUUID.Hex())
+	}
+
+	//reading in small chunks must not change the result
+	other, err = uuid.NewFromReader("two", iotest.OneByteReader(strings.NewReader("hello world")))
+	if err != nil || !uuid.Equal(myUUID, other) {
+		t.Error("same content should result in the same UUID")
+	}
+
+	other, _ = uuid.NewFromReader("one", strings.NewReader("hello world"))
+	if other.Scope() != "one" || withoutScope(other) != withoutScope(myUUID) || uuid.Equal(myUUID, other) {
+		t.Error("unexpected UUID of other scope ", other.Hex())
+	}
+
+	//sha256 of the empty content starts with e3b0c442
+	other, err = uuid.NewFromReader("one", bytes.NewReader(nil))
+	if err != nil || other.Hex() != expected[1] {
+		t.Error("unexpected UUID of empty content ", other.Hex(), " ", err)
+	}
+
+	_, err = uuid.NewFromReader("one", io.MultiReader(strings.NewReader("hello"), iotest.ErrReader(errReader)))
+	if err != errReader {
+		t.Error("Expected reader error but got ", err)
+	}
+
+	if _, err = uuid.NewFromReader("three", strings.NewReader("hello world")); err != uuid.ErrMissingScope {
+		t.Error("Expected ErrMissingScope but got ", err)
+	}
+}
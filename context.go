@@ -0,0 +1,65 @@
+package uuid
+
+import (
+	"context"
+)
+
+// contextKey is the key UUIDs are stored with in contexts. Being unexported, it can't collide with the
+// keys of other packages.
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying a copy of the UUID, which can be read back with FromContext.
+// A nil or uninitialized UUID hides the UUID of a parent context, so that FromContext reports ctx as
+// carrying no UUID.
+func NewContext(ctx context.Context, uuid *UUID) context.Context {
+	var (
+		value UUID
+	)
+
+	if uuid != nil {
+		value = *uuid
+	}
+
+	return context.WithValue(ctx, contextKey{}, value)
+}
+
+// FromContext returns a copy of the UUID ctx carries (see NewContext) and true, or nil and false if ctx
+// carries no UUID. Modifying the returned UUID doesn't affect the context.
+func FromContext(ctx context.Context) (*UUID, bool) {
+	var (
+		value UUID
+		ok    bool
+	)
+
+	value, ok = ctx.Value(contextKey{}).(UUID)
+	if !ok || value.scope == "" {
+		return nil, false
+	}
+
+	return &value, true
+}
+
+// EnsureContext returns ctx along with the UUID it carries if there is one, regardless of its scope.
+// Otherwise it generates a new UUID of the given scope like New and returns it along with a copy of ctx
+// carrying it. If generating fails, ctx is returned along with the error.
+//
+// It is meant for request IDs that are either passed in by the caller or created by the first layer
+// handling the request.
+func EnsureContext(ctx context.Context, scope string) (context.Context, *UUID, error) {
+	var (
+		uuid *UUID
+		ok   bool
+		err  error
+	)
+
+	if uuid, ok = FromContext(ctx); ok {
+		return ctx, uuid, nil
+	}
+
+	uuid, err = New(scope)
+	if err != nil {
+		return ctx, nil, err
+	}
+
+	return NewContext(ctx, uuid), uuid, nil
+}
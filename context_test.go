@@ -0,0 +1,129 @@
+package uuid_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/4xoc/uuid"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestContext(t *testing.T) {
+	var (
+		ctx    context.Context
+		child  context.Context
+		myUUID *uuid.UUID
+		read   *uuid.UUID
+		ok     bool
+	)
+
+	setupScopes(t, "request")
+
+	ctx = context.Background()
+
+	if read, ok = uuid.FromContext(ctx); ok || read != nil {
+		t.Error("expected no UUID in empty context but got ", read.DebugString())
+	}
+
+	myUUID = mustNew(t, "request")
+	ctx = uuid.NewContext(ctx, myUUID)
+
+	if read, ok = uuid.FromContext(ctx); !ok || read.Hex() != myUUID.Hex() || read.Scope() != "request" {
+		t.Error("unexpected UUID ", read.DebugString())
+	}
+
+	//the context holds a copy
+	read.Regenerate()
+	myUUID.Regenerate()
+
+	if read, _ = uuid.FromContext(ctx); read.Hex() == myUUID.Hex() {
+		t.Error("modifying the UUID changed the context")
+	}
+
+	//nil and uninitialized UUIDs hide the UUID of the parent
+	for _, hidden := range []*uuid.UUID{nil, {}} {
+		child = uuid.NewContext(ctx, hidden)
+
+		if read, ok = uuid.FromContext(child); ok || read != nil {
+			t.Error("expected no UUID but got ", read.DebugString())
+		}
+	}
+
+	if _, ok = uuid.FromContext(ctx); !ok {
+		t.Error("child context changed the parent")
+	}
+}
+
+func TestEnsureContext(t *testing.T) {
+	var (
+		ctx     context.Context
+		ensured context.Context
+		myUUID  *uuid.UUID
+		read    *uuid.UUID
+		err     error
+	)
+
+	setupScopes(t, "request", "user")
+
+	ctx, myUUID, err = uuid.EnsureContext(context.Background(), "request")
+	if err != nil || myUUID.Scope() != "request" {
+		t.Fatal("unexpected UUID ", myUUID.DebugString(), err)
+	}
+
+	if read, _ = uuid.FromContext(ctx); read.Hex() != myUUID.Hex() {
+		t.Error("context doesn't carry the generated UUID")
+	}
+
+	//an existing UUID is reused regardless of its scope
+	ensured, read, err = uuid.EnsureContext(ctx, "user")
+	if err != nil || ensured != ctx || read.Hex() != myUUID.Hex() {
+		t.Error("expected existing UUID to be reused but got ", read.DebugString(), err)
+	}
+
+	ensured, read, err = uuid.EnsureContext(context.Background(), "unknown")
+	if err != uuid.ErrMissingScope || read != nil || ensured != context.Background() {
+		t.Error("expected ErrMissingScope but got ", read.DebugString(), err)
+	}
+}
+
+func ExampleEnsureContext() {
+	uuid.ResetForTesting()
+	defer uuid.ResetForTesting()
+
+	uuid.SetScopes([64]string{"request"})
+
+	//the middleware reuses the request ID of the caller or generates one
+	middleware := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			if id, err := uuid.Read(r.Header.Get("X-Request-ID")); err == nil {
+				ctx = uuid.NewContext(ctx, id)
+			}
+
+			ctx, id, err := uuid.EnsureContext(ctx, "request")
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+
+			w.Header().Set("X-Request-ID", id.Hex())
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+
+	handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, ok := uuid.FromContext(r.Context())
+		fmt.Fprint(w, ok, " ", id.Scope())
+	}))
+
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request.Header.Set("X-Request-ID", "0129a1d0-84f3-4d8d-b6cc-682d1ca34dae")
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+
+	fmt.Println(recorder.Body.String(), recorder.Header().Get("X-Request-ID"))
+	// Output: true request 0129a1d0-84f3-4d8d-b6cc-682d1ca34dae
+}
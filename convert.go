@@ -0,0 +1,209 @@
+package uuid
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strings"
+)
+
+// FromRFC builds a UUID from the 16 bytes of a RFC 4122 UUID, e.g. the [16]byte based UUID type of
+// github.com/google/uuid. The scope is derived from the first byte; if it isn't a known scope,
+// ErrorBadScope is returned.
+//
+// The reverse direction is covered by the Bin function whose result can be converted directly:
+//
+//	googleUUID := googleuuid.UUID(myUUID.Bin())
+func FromRFC(b [16]byte) (*UUID, error) {
+	return FromBin(b)
+}
+
+// FromBin builds a UUID from its binary representation as returned by Bin. The scope is derived from the
+// scope bits; if it isn't a known scope, ErrorBadScope is returned. If no scopes are set, ErrMissingScope
+// is returned, and with strict table versions UUIDs of another table version return ErrTableVersion like
+// they do with Read. The other From functions building a UUID from binary data return the same errors.
+func FromBin(b [16]byte) (*UUID, error) {
+	var (
+		uuid UUID
+		err  error
+	)
+
+	uuid.bin = b
+
+	err = uuid.resolveScope()
+	if err != nil {
+		return nil, err
+	}
+
+	return &uuid, nil
+}
+
+// FromBytes works like FromBin for a slice, e.g. a BINARY(16) column or a value read from a cache. Slices
+// of another length than 16 bytes return ErrorBadLength. The UUID doesn't keep a reference to b.
+func FromBytes(b []byte) (*UUID, error) {
+	if len(b) != 16 {
+		return nil, errors.New(ErrorBadLength)
+	}
+
+	return FromBin([16]byte(b))
+}
+
+// ImportForeign adopts a UUID that has been generated elsewhere (e.g. a RFC 4122 version 4 UUID) into
+// the given scope. The string must be a UUID in canonical form, upper case letters are accepted.
+//
+// The 6 scope bits of the first byte are overwritten with the binary representation of the scope
+// while all other bits are kept. The original scope bits are lost: the returned UUID has a different
+// hex-string than the input and the input can't be recovered from it. Store the original ID alongside
+// if it is still needed, e.g. for lookups in the foreign system.
+func ImportForeign(s string, scope string) (*UUID, error) {
+	var (
+		uuid      UUID
+		tmpBytes  []byte
+		name      string
+		scopeByte byte
+		err       error
+	)
+
+	name, scopeByte, err = loadScopes().resolveNew(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	s = strings.ToLower(s)
+
+	if !canonicalPattern.MatchString(s) {
+		return nil, ErrBadString
+	}
+
+	tmpBytes, err = hex.DecodeString(strings.Replace(s, "-", "", -1))
+	if err != nil {
+		return nil, ErrBadString
+	}
+
+	copy(uuid.bin[:], tmpBytes)
+
+	//replacing scope bits, keeping their low two bits
+	uuid.setScopeBits(scopeByte)
+	uuid.scope = name
+
+	return &uuid, nil
+}
+
+// ToProtoBytes returns the binary representation of the UUID as a new 16 bytes long slice, e.g. to be
+// used in protobuf bytes fields. Changing the slice doesn't affect the UUID.
+//
+// If the UUID is not initialized, nil is returned.
+func (uuid *UUID) ToProtoBytes() []byte {
+	var (
+		tmpBytes []byte
+	)
+
+	if uuid == nil {
+		return nil
+	}
+
+	tmpBytes = make([]byte, 16)
+	copy(tmpBytes, uuid.bin[:])
+
+	return tmpBytes
+}
+
+// FromProtoBytes builds a UUID from a 16 bytes long slice as returned by ToProtoBytes. Since proto3
+// omits empty bytes fields, an empty slice results in an uninitialized UUID and no error. Any other
+// length returns ErrorBadLength.
+func FromProtoBytes(b []byte) (*UUID, error) {
+	var (
+		tmpBin [16]byte
+	)
+
+	if len(b) == 0 {
+		return &UUID{}, nil
+	}
+
+	if len(b) != 16 {
+		return nil, errors.New(ErrorBadLength)
+	}
+
+	copy(tmpBin[:], b)
+
+	return FromRFC(tmpBin)
+}
+
+// Uint64Pair returns the binary representation of the UUID as two big endian unsigned integers, hi
+// holding bytes 0-7 and lo holding bytes 8-15.
+//
+// If the UUID is not initialized, 0 is returned for both.
+func (uuid *UUID) Uint64Pair() (hi, lo uint64) {
+	if uuid == nil {
+		return 0, 0
+	}
+
+	return binary.BigEndian.Uint64(uuid.bin[0:8]), binary.BigEndian.Uint64(uuid.bin[8:16])
+}
+
+// FromUint64Pair builds a UUID from two integers as returned by Uint64Pair.
+func FromUint64Pair(hi, lo uint64) (*UUID, error) {
+	var (
+		tmpBin [16]byte
+	)
+
+	binary.BigEndian.PutUint64(tmpBin[0:8], hi)
+	binary.BigEndian.PutUint64(tmpBin[8:16], lo)
+
+	return FromRFC(tmpBin)
+}
+
+// BigInt returns the binary representation of the UUID as an unsigned 128bit big endian integer.
+//
+// If the UUID is not initialized, nil is returned.
+func (uuid *UUID) BigInt() *big.Int {
+	if uuid == nil {
+		return nil
+	}
+
+	return new(big.Int).SetBytes(uuid.bin[:])
+}
+
+// FromBigInt builds a UUID from an integer as returned by BigInt. Negative integers and integers that
+// need more than 128 bits return ErrorBadInteger.
+func FromBigInt(i *big.Int) (*UUID, error) {
+	var (
+		tmpBin [16]byte
+	)
+
+	if i == nil || i.Sign() < 0 || i.BitLen() > 128 {
+		return nil, errors.New(ErrorBadInteger)
+	}
+
+	i.FillBytes(tmpBin[:])
+
+	return FromRFC(tmpBin)
+}
+
+// Rescope returns a copy of the UUID with the scope replaced by newScope. Only the scope bits of the
+// first byte change, all other bits including the low two bits of the first byte are kept. The given
+// UUID is not modified.
+func Rescope(uuid *UUID, newScope string) (*UUID, error) {
+	var (
+		tmpUUID   UUID
+		name      string
+		scopeByte byte
+		err       error
+	)
+
+	if uuid == nil || uuid.scope == "" {
+		return nil, errors.New(ErrorUninitializedUUID)
+	}
+
+	name, scopeByte, err = loadScopes().resolveNew(newScope)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpUUID.bin = uuid.bin
+	tmpUUID.setScopeBits(scopeByte)
+	tmpUUID.scope = name
+
+	return &tmpUUID, nil
+}
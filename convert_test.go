@@ -0,0 +1,345 @@
+package uuid_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/4xoc/uuid"
+	"math/big"
+	"testing"
+)
+
+func TestFromRFC(t *testing.T) {
+	var (
+		myUUID *uuid.UUID
+		myCopy *uuid.UUID
+		err    error
+	)
+
+	//no scopes set
+	uuid.ResetScopes()
+
+	_, err = uuid.FromRFC([16]byte{})
+	if err != uuid.ErrMissingScope {
+		t.Error("Expected ErrMissingScope when no scopes are set but got ", err)
+	}
+
+	setupScopes(t, "one", "two")
+
+	myUUID, err = uuid.New("two")
+	if err != nil {
+		t.Fatal("Expected UUID to be generated but failed with error ", err.Error())
+	}
+
+	myCopy, err = uuid.FromRFC(myUUID.Bin())
+	if err != nil {
+		t.Fatal("Expected UUID to be converted but failed with error ", err.Error())
+	}
+
+	if myCopy.Hex() != myUUID.Hex() || myCopy.Scope() != "two" || myCopy.Bin() != myUUID.Bin() {
+		t.Error("UUIDs should be identical but aren't")
+	}
+
+	//first byte with unknown scope
+	_, err = uuid.FromRFC(fixtureBin([16]byte{0xfc, 0x01}))
+	if err == nil || err.Error() != uuid.ErrorBadScope {
+		t.Error("Expected error for unknown scope")
+	}
+}
+
+func TestFromBytes(t *testing.T) {
+	var (
+		myUUID *uuid.UUID
+		myCopy *uuid.UUID
+		bin    [16]byte
+		data   []byte
+		err    error
+	)
+
+	setupScopes(t, "one", "two")
+
+	myUUID = mustNew(t, "two")
+	bin = myUUID.Bin()
+
+	if myCopy, err = uuid.FromBin(bin); err != nil || *myCopy != *myUUID || myCopy.Scope() != "two" {
+		t.Error("FromBin results in ", myCopy.DebugString(), err)
+	}
+
+	data = bin[:]
+
+	myCopy, err = uuid.FromBytes(data)
+	if err != nil || *myCopy != *myUUID || myCopy.Scope() != "two" {
+		t.Error("FromBytes results in ", myCopy.DebugString(), err)
+	}
+
+	//the UUID doesn't share memory with the slice
+	data[5]++
+	if myCopy.Bin() != myUUID.Bin() {
+		t.Error("UUID changed along with the slice")
+	}
+
+	for _, data = range [][]byte{nil, {}, bin[:15], append(bin[:], 0), []byte(myUUID.Hex())} {
+		if myCopy, err = uuid.FromBytes(data); err == nil || err.Error() != uuid.ErrorBadLength || myCopy != nil {
+			t.Error("expected ErrorBadLength for ", data, " but got ", myCopy.DebugString(), err)
+		}
+	}
+
+	//neither layout knows the scope byte 0xfc
+	if myCopy, err = uuid.FromBytes([]byte{0xfc, 15: 0xfc}); err != uuid.ErrBadScope || myCopy != nil {
+		t.Error("expected ErrBadScope but got ", myCopy.DebugString(), err)
+	}
+
+	if myCopy, err = uuid.FromBin([16]byte{0xfc, 15: 0xfc}); err != uuid.ErrBadScope || myCopy != nil {
+		t.Error("expected ErrBadScope but got ", myCopy.DebugString(), err)
+	}
+
+	uuid.ResetScopes()
+
+	for index, from := range []func() (*uuid.UUID, error){
+		func() (*uuid.UUID, error) { return uuid.FromBin(bin) },
+		func() (*uuid.UUID, error) { return uuid.FromBytes(bin[:]) },
+		func() (*uuid.UUID, error) { return uuid.FromRFC(bin) },
+		func() (*uuid.UUID, error) { return uuid.FromUint64Pair(myUUID.Uint64Pair()) },
+		func() (*uuid.UUID, error) { return uuid.FromULIDString(myUUID.ToULIDString()) },
+	} {
+		if myCopy, err = from(); !errors.Is(err, uuid.ErrMissingScope) || myCopy != nil {
+			t.Error("test case ", index, ": expected ErrMissingScope without scopes but got ", err)
+		}
+	}
+}
+
+func TestImportForeign(t *testing.T) {
+	var (
+		myUUID *uuid.UUID
+		myCopy *uuid.UUID
+		err    error
+	)
+
+	setupScopes(t, "one", "two")
+
+	_, err = uuid.ImportForeign("9c4fb1d0-84f3-4d8d-b6cc-682d1ca34dae", "ten")
+	if err == nil || err.Error() != uuid.ErrorMissingScope {
+		t.Error("Expected error for unknown scope")
+	}
+
+	for _, input := range []string{"", "9c4fb1d0-84f3-4d8d-b6cc-682d1ca34da", "9c4fb1d084f34d8db6cc682d1ca34dae", "9c4fb1d0-84f3-4d8d-b6cc-682d1ca34dag"} {
+		_, err = uuid.ImportForeign(input, "two")
+		if err == nil || err.Error() != uuid.ErrorBadString {
+			t.Error("Expected error for malformatted string ", input)
+		}
+	}
+
+	//0x9c -> 0x04 (scope two) | 0x00 (low bits of 0x9c)
+	myUUID, err = uuid.ImportForeign(fixture("9C4FB1D0-84F3-4D8D-B6CC-682D1CA34DAE"), "two")
+	if err != nil {
+		t.Fatal("Expected UUID to be imported but failed with error ", err.Error())
+	}
+
+	if myUUID.Hex() != fixture("044fb1d0-84f3-4d8d-b6cc-682d1ca34dae") || myUUID.Scope() != "two" {
+		t.Error("unexpected imported UUID ", myUUID.Hex())
+	}
+
+	//low bits are preserved
+	myUUID, _ = uuid.ImportForeign(fixture("9f4fb1d0-84f3-4d8d-b6cc-682d1ca34dae"), "two")
+	if myUUID.Hex() != fixture("074fb1d0-84f3-4d8d-b6cc-682d1ca34dae") {
+		t.Error("unexpected imported UUID ", myUUID.Hex())
+	}
+
+	myCopy, err = uuid.Read(myUUID.Hex())
+	if err != nil || myCopy.Scope() != "two" || myCopy.Bin() != myUUID.Bin() {
+		t.Error("imported UUID should round-trip through Read")
+	}
+}
+
+func TestProtoBytes(t *testing.T) {
+	var (
+		myUUID *uuid.UUID
+		nilPtr *uuid.UUID
+		myCopy *uuid.UUID
+		proto  []byte
+		err    error
+	)
+
+	setupScopes(t, "one", "two")
+
+	if nilPtr.ToProtoBytes() != nil {
+		t.Error("nil UUID should return nil")
+	}
+
+	myUUID, _ = uuid.New("one")
+	proto = myUUID.ToProtoBytes()
+
+	if len(proto) != 16 {
+		t.Fatal("Expected 16 bytes but got ", len(proto))
+	}
+
+	//changing the returned slice must not affect the UUID
+	proto[15]++
+	if myUUID.Bin()[15] == proto[15] {
+		t.Error("returned slice refers to internal state")
+	}
+	proto[15]--
+
+	myCopy, err = uuid.FromProtoBytes(proto)
+	if err != nil || myCopy.Hex() != myUUID.Hex() || myCopy.Scope() != "one" {
+		t.Error("UUID should round-trip through proto bytes")
+	}
+
+	myCopy, err = uuid.FromProtoBytes(nil)
+	if err != nil || myCopy.Hex() != "" || myCopy.Bin() != [16]byte{} {
+		t.Error("empty bytes should return the zero UUID")
+	}
+
+	_, err = uuid.FromProtoBytes(proto[:15])
+	if err == nil || err.Error() != uuid.ErrorBadLength {
+		t.Error("Expected error for short slice")
+	}
+
+	_, err = uuid.FromProtoBytes(append(proto, 0))
+	if err == nil || err.Error() != uuid.ErrorBadLength {
+		t.Error("Expected error for long slice")
+	}
+}
+
+func ExampleFromProtoBytes() {
+	var (
+		message struct {
+			ID []byte
+		}
+		myUUID *uuid.UUID
+		myCopy *uuid.UUID
+	)
+
+	uuid.ResetScopes()
+	defer uuid.ResetScopes()
+
+	uuid.SetScopes([64]string{"user"})
+
+	myUUID, _ = uuid.Read("0129a1d0-84f3-4d8d-b6cc-682d1ca34dae")
+
+	//filling the bytes field of a generated proto message and reading it back
+	message.ID = myUUID.ToProtoBytes()
+	myCopy, _ = uuid.FromProtoBytes(message.ID)
+
+	fmt.Println(myCopy.Scope(), myCopy.Hex())
+	// Output: user 0129a1d0-84f3-4d8d-b6cc-682d1ca34dae
+}
+
+func TestIntegers(t *testing.T) {
+	var (
+		myUUID *uuid.UUID
+		nilPtr *uuid.UUID
+		myCopy *uuid.UUID
+		hi, lo uint64
+		i      *big.Int
+		err    error
+	)
+
+	setupScopes(t, "one", "two")
+
+	hi, lo = nilPtr.Uint64Pair()
+	if hi != 0 || lo != 0 || nilPtr.BigInt() != nil {
+		t.Error("nil UUID should return zero values")
+	}
+
+	//scope one is 0x00, so the leading bytes are zero; in the trailing layout, 1 is in its low bits
+	myUUID = mustRead(t, "00000000-0000-0000-0000-000000000001")
+
+	hi, lo = myUUID.Uint64Pair()
+	if hi != 0 || lo != 1 {
+		t.Error("unexpected integers ", hi, lo)
+	}
+
+	myCopy, err = uuid.FromUint64Pair(hi, lo)
+	if err != nil || myCopy.Hex() != myUUID.Hex() || myCopy.Scope() != "one" {
+		t.Error("UUID should round-trip through uint64 pair")
+	}
+
+	if myUUID.BigInt().Cmp(big.NewInt(1)) != 0 {
+		t.Error("unexpected big integer ", myUUID.BigInt())
+	}
+
+	myCopy, err = uuid.FromBigInt(myUUID.BigInt())
+	if err != nil || myCopy.Hex() != myUUID.Hex() || myCopy.Scope() != "one" {
+		t.Error("UUID should round-trip through big integer")
+	}
+
+	myUUID, _ = uuid.New("two")
+	hi, lo = myUUID.Uint64Pair()
+
+	myCopy, err = uuid.FromUint64Pair(hi, lo)
+	if err != nil || myCopy.Hex() != myUUID.Hex() || myCopy.Scope() != "two" {
+		t.Error("UUID should round-trip through uint64 pair")
+	}
+
+	myCopy, err = uuid.FromBigInt(myUUID.BigInt())
+	if err != nil || myCopy.Hex() != myUUID.Hex() || myCopy.Scope() != "two" {
+		t.Error("UUID should round-trip through big integer")
+	}
+
+	//unknown scope
+	_, err = uuid.FromUint64Pair(0xfc00000000000000, 0xfc)
+	if err == nil || err.Error() != uuid.ErrorBadScope {
+		t.Error("Expected error for unknown scope")
+	}
+
+	//out of range
+	i = new(big.Int).Lsh(big.NewInt(1), 128)
+
+	for _, bad := range []*big.Int{nil, big.NewInt(-1), i} {
+		_, err = uuid.FromBigInt(bad)
+		if err == nil || err.Error() != uuid.ErrorBadInteger {
+			t.Error("Expected error for integer ", bad)
+		}
+	}
+}
+
+func TestRescope(t *testing.T) {
+	var (
+		myUUID *uuid.UUID
+		nilPtr *uuid.UUID
+		moved  *uuid.UUID
+		oldBin [16]byte
+		newBin [16]byte
+		oldHex string
+		err    error
+	)
+
+	setupScopes(t, "legacy_order", "order")
+
+	_, err = uuid.Rescope(nilPtr, "order")
+	if err == nil || err.Error() != uuid.ErrorUninitializedUUID {
+		t.Error("Expected error for nil UUID")
+	}
+
+	myUUID, _ = uuid.New("legacy_order")
+	oldHex = myUUID.Hex()
+
+	_, err = uuid.Rescope(myUUID, "ten")
+	if err == nil || err.Error() != uuid.ErrorMissingScope {
+		t.Error("Expected error for unknown scope")
+	}
+
+	moved, err = uuid.Rescope(myUUID, "order")
+	if err != nil {
+		t.Fatal("Expected UUID to be rescoped but failed with error ", err.Error())
+	}
+
+	if moved.Scope() != "order" || mustRead(t, moved.Hex()).Scope() != "order" {
+		t.Error("rescoped UUID should have scope order")
+	}
+
+	if myUUID.Scope() != "legacy_order" || myUUID.Hex() != oldHex {
+		t.Error("original UUID must not change")
+	}
+
+	oldBin = myUUID.Bin()
+	newBin = moved.Bin()
+
+	if oldBin[scopeIndex()]&0x03 != newBin[scopeIndex()]&0x03 || oldBin[scopeIndex()]&^0x03 == newBin[scopeIndex()]&^0x03 {
+		t.Error("only the scope bits of the byte holding the scope should differ")
+	}
+
+	if withoutScope(myUUID) != withoutScope(moved) {
+		t.Error("only the byte holding the scope should differ")
+	}
+}
@@ -0,0 +1,72 @@
+package uuid
+
+import (
+	"expvar"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	// countersEnabled defines if generated UUIDs are counted.
+	countersEnabled atomic.Bool
+
+	// counters holds the number of generated UUIDs of each scope at the same index as `scopes`.
+	counters [maxScopes]atomic.Uint64
+
+	// publishMu guards the publication of counters via expvar.
+	publishMu sync.Mutex
+)
+
+// EnableCounters enables counting of generated UUIDs per scope. The counters of all currently known
+// scopes are published via expvar as "uuid.generated.<scope>". Counting can't be disabled again; as
+// long as it isn't enabled, generating UUIDs doesn't pay for it.
+func EnableCounters() {
+	var (
+		table *scopeTable
+		scope string
+	)
+
+	publishMu.Lock()
+	defer publishMu.Unlock()
+
+	countersEnabled.Store(true)
+
+	table = loadScopes()
+
+	for scope = range table.byName {
+		if table.isAlias(scope) || expvar.Get("uuid.generated."+scope) != nil {
+			continue
+		}
+
+		publishCounter(scope)
+	}
+}
+
+// publishCounter publishes the counter of the given scope via expvar.
+func publishCounter(scope string) {
+	expvar.Publish("uuid.generated."+scope, expvar.Func(func() interface{} {
+		return Counters()[scope]
+	}))
+}
+
+// Counters returns the number of UUIDs generated of each known scope since counting has been enabled.
+// Each counter is read atomically.
+func Counters() map[string]uint64 {
+	var (
+		table  *scopeTable
+		scope  string
+		result map[string]uint64
+	)
+
+	table = loadScopes()
+	result = make(map[string]uint64, len(table.byName))
+
+	for scope = range table.byName {
+		//aliases are counted with the scope they resolve to
+		if !table.isAlias(scope) {
+			result[scope] = counters[scopeIndex(*table.byName[scope])].Load()
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,56 @@
+package uuid_test
+
+import (
+	"expvar"
+	"github.com/4xoc/uuid"
+	"sync"
+	"testing"
+)
+
+func TestCounters(t *testing.T) {
+	var (
+		wg       sync.WaitGroup
+		snapshot map[string]uint64
+		index    int
+	)
+
+	setupScopes(t, "one", "two")
+
+	//not counting before enabled
+	mustNew(t, "one")
+
+	uuid.EnableCounters()
+
+	for index = 0; index < 8; index++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := 0; i < 100; i++ {
+				uuid.New("one")
+			}
+
+			uuid.NewBatch("two", 50)
+		}()
+	}
+
+	//reading while generating must be safe
+	for index = 0; index < 10; index++ {
+		snapshot = uuid.Counters()
+		if snapshot["one"] > 800 || snapshot["two"] > 400 {
+			t.Error("unexpected snapshot ", snapshot)
+		}
+	}
+
+	wg.Wait()
+
+	snapshot = uuid.Counters()
+	if snapshot["one"] != 800 || snapshot["two"] != 400 || len(snapshot) != 2 {
+		t.Error("unexpected counters ", snapshot)
+	}
+
+	if expvar.Get("uuid.generated.one") == nil || expvar.Get("uuid.generated.one").String() != "800" {
+		t.Error("counter should be published via expvar")
+	}
+}
@@ -0,0 +1,109 @@
+package uuid
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/bits"
+)
+
+// decimalChunk is the largest power of 10 that fits into an uint64, used for converting 19 digits at once.
+const decimalChunk uint64 = 10000000000000000000
+
+// DecimalString returns the binary representation of the UUID as an unsigned big endian integer in
+// decimal notation without leading zeros, like str(uuid.UUID(...).int) in Python. See ReadDecimal.
+//
+// If the UUID is not initialized, an empty string is returned.
+func (uuid *UUID) DecimalString() string {
+	var (
+		buf   [39]byte
+		pos   int
+		hi    uint64
+		lo    uint64
+		chunk uint64
+		digit int
+	)
+
+	if uuid == nil || uuid.scope == "" {
+		return ""
+	}
+
+	hi, lo = uuid.Uint64Pair()
+	pos = len(buf)
+
+	for {
+		//dividing the 128 bit integer by 10^19; hi becomes the high part of the quotient
+		chunk = hi % decimalChunk
+		hi /= decimalChunk
+		lo, chunk = bits.Div64(chunk, lo, decimalChunk)
+
+		for digit = 0; digit < 19 && (hi != 0 || lo != 0 || chunk != 0); digit++ {
+			pos--
+			buf[pos] = byte('0' + chunk%10)
+			chunk /= 10
+		}
+
+		if hi == 0 && lo == 0 {
+			break
+		}
+	}
+
+	if pos == len(buf) {
+		return "0"
+	}
+
+	return string(buf[pos:])
+}
+
+// ReadDecimal parses the binary representation of a UUID from an unsigned integer in decimal notation as
+// returned by DecimalString. Leading zeros are allowed. Strings holding anything but digits return
+// ErrBadString, integers needing more than 128 bits ErrorBadInteger. Like Read, the scope of the UUID
+// must be known.
+func ReadDecimal(s string) (*UUID, error) {
+	var (
+		uuid     *UUID
+		tmpBin   [16]byte
+		hi       uint64
+		lo       uint64
+		carry    uint64
+		overflow uint64
+		err      error
+	)
+
+	if s == "" {
+		err = ErrBadString
+	}
+
+	for index := 0; index < len(s) && err == nil; index++ {
+		if s[index] < '0' || s[index] > '9' {
+			err = ErrBadString
+			break
+		}
+
+		//multiplying the 128 bit integer by 10 and adding the digit
+		overflow, hi = bits.Mul64(hi, 10)
+		carry, lo = bits.Mul64(lo, 10)
+		hi, carry = bits.Add64(hi, carry, 0)
+		overflow |= carry
+		lo, carry = bits.Add64(lo, uint64(s[index]-'0'), 0)
+		hi, carry = bits.Add64(hi, carry, 0)
+		overflow |= carry
+
+		if overflow != 0 {
+			err = errors.New(ErrorBadInteger)
+		}
+	}
+
+	if err == nil {
+		binary.BigEndian.PutUint64(tmpBin[0:8], hi)
+		binary.BigEndian.PutUint64(tmpBin[8:16], lo)
+
+		uuid, err = FromRFC(tmpBin)
+	}
+
+	if err != nil {
+		reportParseError(s, err)
+		return nil, err
+	}
+
+	return uuid, nil
+}
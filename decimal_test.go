@@ -0,0 +1,96 @@
+package uuid_test
+
+import (
+	"github.com/4xoc/uuid"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+// TestDecimal checks fixtures generated with str(uuid.UUID(...).int) in Python.
+func TestDecimal(t *testing.T) {
+	var (
+		myUUID *uuid.UUID
+		err    error
+	)
+
+	setupScopes(t, "one", "user")
+	uuid.ResetScopes()
+	uuid.SetScopeLayout(testLayout)
+	uuid.SetScopes([64]string{"one", "user", 63: "last"})
+
+	//valid in both layouts
+	fixtures := map[string]string{
+		"04000000-0000-0000-0000-000000000000": "5316911983139663491615228241121378304",
+		"00000000-0000-0000-0000-000000000000": "0",
+		"ffffffff-ffff-ffff-ffff-ffffffffffff": "340282366920938463463374607431768211455",
+		"00000000-0000-0000-8ac7-230489e80000": "10000000000000000000",
+		"00000000-0000-0000-8ac7-230489e7ffff": "9999999999999999999",
+	}
+
+	if testLayout == uuid.ScopeTrailing {
+		fixtures["ae29a1d0-84f3-4d8d-b6cc-682d1ca34d05"] = "231501837426325437210918670672844770565"
+		fixtures["00000000-0000-0000-0000-000000000004"] = "4"
+	} else {
+		fixtures["0529a1d0-84f3-4d8d-b6cc-682d1ca34dae"] = "6862306138674654690175277485466537390"
+		fixtures["00000000-0000-0000-0000-00000000002a"] = "42"
+	}
+
+	for hex, decimal := range fixtures {
+		myUUID = mustRead(t, hex)
+
+		if myUUID.DecimalString() != decimal {
+			t.Error("expected ", decimal, " for ", hex, " but got ", myUUID.DecimalString())
+		}
+
+		for _, input := range []string{decimal, "000" + decimal} {
+			myUUID, err = uuid.ReadDecimal(input)
+			if err != nil || myUUID.Hex() != hex {
+				t.Error("expected ", hex, " for ", input, " but got ", myUUID.Hex(), err)
+			}
+		}
+	}
+
+	if (*uuid.UUID)(nil).DecimalString() != "" || (&uuid.UUID{}).DecimalString() != "" {
+		t.Error("expected empty string for nil and uninitialized UUIDs")
+	}
+}
+
+func TestReadDecimal(t *testing.T) {
+	var (
+		myUUID *uuid.UUID
+		err    error
+	)
+
+	setupScopes(t, "one", "two")
+
+	//random UUIDs round-trip and match math/big
+	for range 1000 {
+		myUUID = mustNew(t, "two")
+
+		if myUUID.DecimalString() != myUUID.BigInt().String() {
+			t.Fatal("expected ", myUUID.BigInt(), " but got ", myUUID.DecimalString())
+		}
+
+		if parsed, err := uuid.ReadDecimal(myUUID.DecimalString()); err != nil || parsed.Bin() != myUUID.Bin() {
+			t.Fatal("UUID doesn't round-trip: ", parsed.DebugString(), err)
+		}
+	}
+
+	for input, expected := range map[string]string{
+		"":     uuid.ErrorBadString,
+		"-1":   uuid.ErrorBadString,
+		"+1":   uuid.ErrorBadString,
+		"12a4": uuid.ErrorBadString,
+		" 42":  uuid.ErrorBadString,
+		"340282366920938463463374607431768211456":     uuid.ErrorBadInteger,
+		"999999999999999999999999999999999999999":     uuid.ErrorBadInteger,
+		new(big.Int).Lsh(big.NewInt(1), 200).String(): uuid.ErrorBadInteger,
+		"0" + strings.Repeat("9", 40):                 uuid.ErrorBadInteger,
+		"340282366920938463463374607431768211452":     uuid.ErrorBadScope,
+	} {
+		if myUUID, err = uuid.ReadDecimal(input); err == nil || err.Error() != expected || myUUID != nil {
+			t.Errorf("expected %s for %q but got %v", expected, input, err)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package uuid
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+var (
+	// defaultScope holds the scope used by NewDefault and by New when called with an empty scope.
+	defaultScope atomic.Pointer[string]
+)
+
+// SetDefaultScope sets the scope used by NewDefault and by New when called with an empty scope. The
+// scope must be known and the default scope can only be set once. Parsing UUIDs is not affected by the
+// default scope.
+func SetDefaultScope(scope string) error {
+	if loadScopes().byName[scope] == nil {
+		return ErrMissingScope
+	}
+
+	if !defaultScope.CompareAndSwap(nil, &scope) {
+		return errors.New(ErrorDefaultScopeSet)
+	}
+
+	return nil
+}
+
+// NewDefault generates a new UUID of the default scope. If no default scope is set,
+// ErrorNoDefaultScope is returned.
+func NewDefault() (*UUID, error) {
+	if defaultScope.Load() == nil {
+		return nil, errors.New(ErrorNoDefaultScope)
+	}
+
+	return New(*defaultScope.Load())
+}
@@ -0,0 +1,46 @@
+package uuid_test
+
+import (
+	"github.com/4xoc/uuid"
+	"testing"
+)
+
+func TestDefaultScope(t *testing.T) {
+	var (
+		myUUID *uuid.UUID
+		err    error
+	)
+
+	setupScopes(t, "one", "two")
+
+	if _, err = uuid.NewDefault(); err == nil || err.Error() != uuid.ErrorNoDefaultScope {
+		t.Error("Expected error when no default scope is set")
+	}
+
+	if err = uuid.SetDefaultScope("ten"); err == nil || err.Error() != uuid.ErrorMissingScope {
+		t.Error("Expected error for unknown scope")
+	}
+
+	if err = uuid.SetDefaultScope("two"); err != nil {
+		t.Fatal("Expected default scope to be set but failed with error ", err.Error())
+	}
+
+	if err = uuid.SetDefaultScope("one"); err == nil || err.Error() != uuid.ErrorDefaultScopeSet {
+		t.Error("Expected error when setting the default scope twice")
+	}
+
+	myUUID, err = uuid.NewDefault()
+	if err != nil || myUUID.Scope() != "two" {
+		t.Error("Expected UUID of the default scope")
+	}
+
+	myUUID, err = uuid.New("")
+	if err != nil || myUUID.Scope() != "two" {
+		t.Error("Expected UUID of the default scope")
+	}
+
+	//explicit scopes and parsing are not affected
+	if mustNew(t, "one").Scope() != "one" || mustRead(t, "00000000-0000-0000-0000-000000000000").Scope() != "one" {
+		t.Error("default scope must not affect other scopes")
+	}
+}
@@ -0,0 +1,95 @@
+package uuid
+
+import (
+	"errors"
+	"sort"
+)
+
+// ErrScopeDeprecated is returned when generating a UUID of a scope deprecated with DeprecateScope. Its
+// message is ErrorScopeDeprecated.
+var ErrScopeDeprecated = errors.New(ErrorScopeDeprecated)
+
+// DeprecateScope retires the known scope, e.g. after it has been replaced by a new version. Existing
+// UUIDs of the scope keep working: Read, Scan and all other functions parsing UUIDs resolve the scope as
+// before and ScopeMatches still matches it. Generating new UUIDs of the scope with New, NewBatch,
+// ForScope or any other function returns ErrScopeDeprecated, including a ScopeFactory or Pool created
+// before. Deprecating an alias deprecates the scope it resolves to.
+//
+// A scope can't be un-deprecated other than by ReplaceScopes. Unknown scopes return ErrMissingScope.
+func DeprecateScope(name string) error {
+	var (
+		table     *scopeTable
+		scopeByte byte
+		ok        bool
+	)
+
+	scopesMu.Lock()
+	defer scopesMu.Unlock()
+
+	table = loadScopes()
+
+	_, scopeByte, ok = table.resolve(name)
+	if !ok {
+		return ErrMissingScope
+	}
+
+	table = table.clone()
+	table.deprecated[scopeIndex(scopeByte)] = true
+
+	currentScopes.Store(table)
+
+	return nil
+}
+
+// DeprecatedScopes returns the names of all scopes deprecated with DeprecateScope, sorted.
+func DeprecatedScopes() []string {
+	var (
+		table *scopeTable
+		names []string
+		index int
+	)
+
+	table = loadScopes()
+
+	for index = range table.deprecated {
+		if table.deprecated[index] {
+			names = append(names, table.names[index])
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// resolveNew works like resolve for generating new UUIDs of the scope, returning ErrMissingScope if the
+// scope is not known and ErrScopeDeprecated if it is deprecated.
+func (table *scopeTable) resolveNew(scope string) (name string, scopeByte byte, err error) {
+	var (
+		ok bool
+	)
+
+	name, scopeByte, ok = table.resolve(scope)
+	if !ok {
+		return "", 0, ErrMissingScope
+	}
+
+	if table.deprecated[scopeIndex(scopeByte)] {
+		return "", 0, ErrScopeDeprecated
+	}
+
+	return name, scopeByte, nil
+}
+
+// isDeprecated returns true if the scope with the given name is deprecated. Unknown scopes are not
+// deprecated.
+func (table *scopeTable) isDeprecated(scope string) bool {
+	var (
+		scopeByte byte
+		ok        bool
+	)
+
+	_, scopeByte, ok = table.resolve(scope)
+
+	return ok && table.deprecated[scopeIndex(scopeByte)]
+}
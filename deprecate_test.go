@@ -0,0 +1,143 @@
+package uuid_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/4xoc/uuid"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestDeprecateScope(t *testing.T) {
+	var (
+		myUUID  *uuid.UUID
+		factory *uuid.ScopeFactory
+		pool    *uuid.Pool
+		table   uuid.ScopeTable
+		data    []byte
+		err     error
+	)
+
+	setupScopes(t, "invoice", "invoice_v1")
+
+	myUUID = mustNew(t, "invoice_v1")
+
+	factory, err = uuid.ForScope("invoice_v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pool, err = uuid.NewPool("invoice_v1", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pool.Close()
+
+	if err = uuid.DeprecateScope("invoice_v2"); err != uuid.ErrMissingScope {
+		t.Error("expected ErrMissingScope for unknown scope but got ", err)
+	}
+
+	if err = uuid.AliasScope("invoice_v1", "old_invoice"); err != nil {
+		t.Fatal(err)
+	}
+
+	//deprecating the alias deprecates the scope
+	if err = uuid.DeprecateScope("old_invoice"); err != nil {
+		t.Fatal("expected scope to be deprecated but failed with error ", err.Error())
+	}
+
+	if err = uuid.DeprecateScope("invoice_v1"); err != nil {
+		t.Error("expected deprecating again to succeed but got ", err)
+	}
+
+	for index, generate := range []func() error{
+		func() error { _, err := uuid.New("invoice_v1"); return err },
+		func() error { _, err := uuid.New("old_invoice"); return err },
+		func() error { _, err := uuid.NewBatch("invoice_v1", 10); return err },
+		func() error { _, err := uuid.ForScope("invoice_v1"); return err },
+		func() error { _, err := uuid.NewOrdered("invoice_v1"); return err },
+		func() error { _, err := uuid.NewCompat("invoice_v1"); return err },
+		func() error { _, err := uuid.NewFromReader("invoice_v1", strings.NewReader("")); return err },
+		func() error { _, err := uuid.NewPool("invoice_v1", 1); return err },
+		func() error { _, err := uuid.Rescope(mustNew(t, "invoice"), "invoice_v1"); return err },
+		func() error { _, err := factory.New(); return err },
+		func() error { _, err := factory.NewBatch(10); return err },
+		func() error { _, err := pool.Get(); return err },
+	} {
+		if err = generate(); err != uuid.ErrScopeDeprecated {
+			t.Error("test case ", index, ": expected ErrScopeDeprecated but got ", err)
+		}
+	}
+
+	mustNew(t, "invoice")
+
+	//existing UUIDs keep working
+	if myCopy := mustRead(t, myUUID.Hex()); myCopy.Scope() != "invoice_v1" || !myCopy.IsValid() ||
+		!myCopy.ScopeMatches([]string{"invoice_v1"}) || !myCopy.ScopeMatches([]string{"old_invoice"}) {
+		t.Error("expected deprecated scope to be resolved")
+	}
+
+	if scopes := uuid.DeprecatedScopes(); len(scopes) != 1 || scopes[0] != "invoice_v1" {
+		t.Error("unexpected deprecated scopes ", scopes)
+	}
+
+	data, err = uuid.DumpScopes(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = json.Unmarshal(data, &table); err != nil || table.Scopes[0].Deprecated || !table.Scopes[1].Deprecated ||
+		!bytes.Contains(data, []byte(`"deprecated": true`)) {
+		t.Error("unexpected scope table ", string(data))
+	}
+
+	//replacing the scopes drops all deprecations
+	if _, err = uuid.ReplaceScopes(map[string]byte{"invoice_v1": 0x04}); err != nil {
+		t.Fatal(err)
+	}
+
+	if scopes := uuid.DeprecatedScopes(); scopes != nil {
+		t.Error("unexpected deprecated scopes ", scopes)
+	}
+
+	mustNew(t, "invoice_v1")
+}
+
+func TestDeprecateScopeConcurrent(t *testing.T) {
+	var (
+		wg sync.WaitGroup
+	)
+
+	setupScopes(t, "one", "two")
+
+	for range 4 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for range 1000 {
+				if _, err := uuid.New("one"); err != nil && err != uuid.ErrScopeDeprecated {
+					t.Error("unexpected error ", err)
+					return
+				}
+
+				if _, err := uuid.New("two"); err != nil {
+					t.Error("unexpected error ", err)
+					return
+				}
+			}
+		}()
+	}
+
+	if err := uuid.DeprecateScope("one"); err != nil {
+		t.Error(err)
+	}
+
+	wg.Wait()
+
+	if _, err := uuid.New("one"); err != uuid.ErrScopeDeprecated {
+		t.Error("expected ErrScopeDeprecated but got ", err)
+	}
+}
@@ -0,0 +1,54 @@
+package uuid
+
+import (
+	"bytes"
+)
+
+// XORDistance is the bytewise XOR of two UUIDs as used by Kademlia-style consistent hashing. Distances
+// compare as 128 bit big endian integers.
+type XORDistance [16]byte
+
+// Distance returns the bytewise XOR of both UUIDs. A nil UUID is treated like a UUID with all bytes 0.
+// The distance of a UUID to itself is 0 and Distance(a, b) == Distance(b, a).
+func Distance(a, b *UUID) XORDistance {
+	var (
+		distance XORDistance
+		index    int
+	)
+
+	if a != nil {
+		distance = XORDistance(a.bin)
+	}
+
+	if b != nil {
+		for index = range distance {
+			distance[index] ^= b.bin[index]
+		}
+	}
+
+	return distance
+}
+
+// Cmp compares two distances. The result is 0 if distance == other, -1 if distance is smaller and +1 if
+// it is larger.
+func (distance XORDistance) Cmp(other XORDistance) int {
+	return bytes.Compare(distance[:], other[:])
+}
+
+// CloserTo compares which of a and b is closer to target by XOR distance. The result is -1 if a is closer,
+// +1 if b is closer and 0 if both are equally close. A nil candidate is farther away than any other
+// candidate; a nil target is treated like a UUID with all bytes 0.
+//
+// CloserTo can be used with slices.SortFunc to sort candidates by their distance to a target.
+func CloserTo(target, a, b *UUID) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	}
+
+	return Distance(target, a).Cmp(Distance(target, b))
+}
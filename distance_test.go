@@ -0,0 +1,82 @@
+package uuid_test
+
+import (
+	"github.com/4xoc/uuid"
+	"slices"
+	"testing"
+)
+
+func TestDistance(t *testing.T) {
+	var (
+		a, b, c *uuid.UUID
+		ab      uuid.XORDistance
+		zero    [16]byte
+		nodes   []*uuid.UUID
+	)
+
+	setupScopes(t, "one", "two")
+
+	//a and b differ in less significant bits than a and c
+	if testLayout == uuid.ScopeTrailing {
+		a = mustRead(t, "00000000-0000-0000-0000-00000000f004")
+		b = mustRead(t, "00000000-0000-0000-0000-000000000f04")
+		c = mustRead(t, "01000000-0000-0000-0000-000000000004")
+		ab = uuid.XORDistance{14: 0xff}
+	} else {
+		a = mustRead(t, "04000000-0000-0000-0000-0000000000f0")
+		b = mustRead(t, "04000000-0000-0000-0000-00000000000f")
+		c = mustRead(t, "05000000-0000-0000-0000-000000000000")
+		ab = uuid.XORDistance{15: 0xff}
+	}
+
+	if uuid.Distance(a, a) != zero {
+		t.Error("distance to itself should be 0")
+	}
+
+	if uuid.Distance(a, b) != uuid.Distance(b, a) {
+		t.Error("distance should be symmetric")
+	}
+
+	if uuid.Distance(a, b) != ab {
+		t.Error("unexpected distance ", uuid.Distance(a, b))
+	}
+
+	if uuid.Distance(a, nil) != uuid.XORDistance(a.Bin()) || uuid.Distance(nil, nil) != zero {
+		t.Error("nil UUIDs should be treated as 0")
+	}
+
+	if uuid.Distance(a, b).Cmp(uuid.Distance(a, c)) != -1 || uuid.Distance(a, c).Cmp(uuid.Distance(a, b)) != 1 ||
+		uuid.Distance(a, b).Cmp(uuid.Distance(b, a)) != 0 {
+		t.Error("unexpected result of Cmp")
+	}
+
+	testCases := []struct {
+		target, a, b *uuid.UUID
+		result       int
+	}{
+		{a, a, b, -1},
+		{a, c, b, 1},
+		{a, b, b, 0},
+		{a, nil, b, 1},
+		{a, b, nil, -1},
+		{a, nil, nil, 0},
+		{nil, a, c, -1},
+	}
+
+	for index := range testCases {
+		if uuid.CloserTo(testCases[index].target, testCases[index].a, testCases[index].b) != testCases[index].result {
+			t.Error("test case ", index, ": unexpected result")
+		}
+	}
+
+	//sorting candidates by distance
+	nodes = []*uuid.UUID{c, nil, b, a}
+
+	slices.SortFunc(nodes, func(x, y *uuid.UUID) int {
+		return uuid.CloserTo(b, x, y)
+	})
+
+	if nodes[0] != b || nodes[1] != a || nodes[2] != c || nodes[3] != nil {
+		t.Error("unexpected order ", nodes)
+	}
+}
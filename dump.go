@@ -0,0 +1,84 @@
+package uuid
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// ScopeTable is the format written by DumpScopes so that all services can agree on the same scopes.
+type ScopeTable struct {
+	// Scopes holds all scopes ordered by their binary representation.
+	Scopes []ScopeTableEntry `json:"scopes"`
+	// WellKnown maps the names of well-known UUIDs to their canonical hex-string.
+	WellKnown map[string]string `json:"well_known,omitempty"`
+}
+
+// ScopeTableEntry describes a single scope of a ScopeTable.
+type ScopeTableEntry struct {
+	// Index is the position of the scope in the array passed to SetScopes.
+	Index int `json:"index"`
+	// Name is the name of the scope.
+	Name string `json:"name"`
+	// Byte is the binary representation of the scope in the first byte of a UUID.
+	Byte byte `json:"byte"`
+	// Aliases holds the aliases of the scope (see AliasScope).
+	Aliases []string `json:"aliases,omitempty"`
+	// Deprecated is true if the scope is deprecated (see DeprecateScope).
+	Deprecated bool `json:"deprecated,omitempty"`
+	// Info holds the ScopeInfo of the scope if one is set (see SetScopeInfo).
+	Info *ScopeInfo `json:"info,omitempty"`
+}
+
+// DumpScopes returns the currently set scopes as JSON encoded ScopeTable. Well-known UUIDs are only
+// included if withWellKnown is true.
+func DumpScopes(withWellKnown bool) ([]byte, error) {
+	var (
+		table   ScopeTable
+		known   *scopeTable
+		entries [maxScopes]*ScopeTableEntry
+		index   int
+		scope   string
+	)
+
+	table.Scopes = []ScopeTableEntry{}
+	known = loadScopes()
+
+	for index = range known.names {
+		if known.names[index] != "" {
+			entries[index] = &ScopeTableEntry{
+				Index:      index,
+				Name:       known.names[index],
+				Byte:       scopeByteAt(index),
+				Deprecated: known.deprecated[index],
+			}
+		}
+	}
+
+	for scope = range known.byName {
+		index = scopeIndex(*known.byName[scope])
+
+		if scope != entries[index].Name {
+			entries[index].Aliases = append(entries[index].Aliases, scope)
+		}
+	}
+
+	scopeInfos.mu.RLock()
+	defer scopeInfos.mu.RUnlock()
+
+	for index = range entries {
+		if entries[index] != nil {
+			if info, ok := scopeInfos.byName[entries[index].Name]; ok {
+				entries[index].Info = &info
+			}
+
+			sort.Strings(entries[index].Aliases)
+			table.Scopes = append(table.Scopes, *entries[index])
+		}
+	}
+
+	if withWellKnown {
+		table.WellKnown = wellKnownHex()
+	}
+
+	return json.MarshalIndent(table, "", "  ")
+}
@@ -0,0 +1,48 @@
+package uuid_test
+
+import (
+	"encoding/json"
+	"github.com/4xoc/uuid"
+	"testing"
+)
+
+func TestDumpScopes(t *testing.T) {
+	var (
+		data  []byte
+		table uuid.ScopeTable
+		err   error
+	)
+
+	setupScopes(t, "user", "post", "", "account")
+	uuid.AliasScope("account", "acct")
+	uuid.AliasScope("account", "acc")
+	uuid.RegisterWellKnown("system", mustRead(t, "00000000-0000-0000-0000-000000000001"))
+
+	data, err = uuid.DumpScopes(false)
+	if err != nil {
+		t.Fatal("failed to dump scopes: ", err)
+	}
+
+	if err = json.Unmarshal(data, &table); err != nil {
+		t.Fatal("failed to decode dump: ", err)
+	}
+
+	if len(table.Scopes) != 3 || table.WellKnown != nil {
+		t.Fatal("unexpected dump ", string(data))
+	}
+
+	if table.Scopes[0].Index != 0 || table.Scopes[0].Name != "user" || table.Scopes[0].Aliases != nil ||
+		table.Scopes[1].Name != "post" || table.Scopes[1].Byte != 0x04 ||
+		table.Scopes[2].Index != 3 || table.Scopes[2].Byte != 0x0c ||
+		len(table.Scopes[2].Aliases) != 2 || table.Scopes[2].Aliases[0] != "acc" || table.Scopes[2].Aliases[1] != "acct" {
+		t.Error("unexpected dump ", string(data))
+	}
+
+	data, _ = uuid.DumpScopes(true)
+	table = uuid.ScopeTable{}
+	json.Unmarshal(data, &table)
+
+	if len(table.WellKnown) != 1 || table.WellKnown["system"] != "00000000-0000-0000-0000-000000000001" {
+		t.Error("unexpected well-known UUIDs ", string(data))
+	}
+}
@@ -0,0 +1,147 @@
+package uuid
+
+import (
+	crand "crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// entropyBufferSize defines the number of random bytes that are read at once when the entropy buffer
+// needs to be refilled.
+const entropyBufferSize int = 4096
+
+// entropyAttempts limits the number of reads from the entropy source before giving up.
+const entropyAttempts int = 3
+
+// ErrEntropy is matched by errors.Is for every error caused by failing to read random data. The error
+// of the entropy source is wrapped as well.
+var ErrEntropy = errors.New(ErrorEntropy)
+
+// entropyPool buffers random data so that not every new UUID requires a separate read from
+// crypto/rand.
+type entropyPool struct {
+	mu sync.Mutex
+	// buf holds the random data read from the entropy source.
+	buf [entropyBufferSize]byte
+	// off is the offset of the first byte in buf that hasn't been handed out yet.
+	off int
+}
+
+var (
+	// entropySource is the reader all random data is taken from.
+	entropySource io.Reader = crand.Reader
+
+	// directEntropy defines if random data is read directly from entropySource for every UUID
+	// instead of using the buffer.
+	directEntropy atomic.Bool
+
+	// pool is the package wide entropy buffer. It starts out empty.
+	pool = entropyPool{off: entropyBufferSize}
+
+	// entropyBackoff is the time waited after the first failed read from the entropy source. It grows
+	// with every further attempt.
+	entropyBackoff = time.Millisecond
+)
+
+// SetDirectEntropy defines if random data for new UUIDs is read from crypto/rand for every
+// single UUID (true) or taken from an internal buffer that is refilled in chunks of 4 KiB (false).
+//
+// By default the buffer is used. Either way, the same random data is never used twice.
+func SetDirectEntropy(direct bool) {
+	directEntropy.Store(direct)
+}
+
+// readEntropy fills dst with random data, either from the buffer or directly from entropySource. Errors
+// wrap ErrEntropy as well as the error of the entropy source.
+func readEntropy(dst []byte) error {
+	var (
+		err error
+	)
+
+	if directEntropy.Load() || len(dst) > entropyBufferSize {
+		err = readSource(dst)
+	} else {
+		err = pool.read(dst)
+	}
+
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrEntropy, err)
+	}
+
+	return nil
+}
+
+// readEntropyInto works like readEntropy for the 16 bytes of a UUID. Slices handed to entropySource
+// escape to the heap, so the direct mode reads into a buffer of its own and only copies the result to
+// dst. This keeps UUIDs returned by value off the heap in the buffered mode (see NewValue).
+func readEntropyInto(dst *[16]byte) error {
+	var (
+		buf []byte
+		err error
+	)
+
+	if directEntropy.Load() {
+		buf = make([]byte, len(dst))
+
+		err = readSource(buf)
+		copy(dst[:], buf)
+	} else {
+		err = pool.read(dst[:])
+	}
+
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrEntropy, err)
+	}
+
+	return nil
+}
+
+// readSource fills dst from entropySource. Failed reads are retried up to entropyAttempts times in
+// total as failures of the entropy source are mostly transient.
+func readSource(dst []byte) error {
+	var (
+		attempt int
+		err     error
+	)
+
+	for attempt = 1; ; attempt++ {
+		_, err = io.ReadFull(entropySource, dst)
+		if err == nil || attempt == entropyAttempts {
+			return err
+		}
+
+		time.Sleep(time.Duration(attempt) * entropyBackoff)
+	}
+}
+
+// read copies len(dst) bytes from the buffer into dst and refills the buffer when it doesn't hold
+// enough data anymore. Handed out bytes are cleared from the buffer.
+func (pool *entropyPool) read(dst []byte) error {
+	var (
+		err error
+	)
+
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	if entropyBufferSize-pool.off < len(dst) {
+		err = readSource(pool.buf[:])
+		if err != nil {
+			//whatever has been read is discarded, the buffer stays empty
+			pool.off = entropyBufferSize
+			return err
+		}
+
+		pool.off = 0
+	}
+
+	copy(dst, pool.buf[pool.off:pool.off+len(dst)])
+	clear(pool.buf[pool.off : pool.off+len(dst)])
+	pool.off += len(dst)
+
+	return nil
+}
@@ -0,0 +1,237 @@
+package uuid_test
+
+import (
+	"encoding/binary"
+	"errors"
+	"github.com/4xoc/uuid"
+	"sync"
+	"testing"
+)
+
+// counterReader returns a stream of increasing big endian 64bit counters.
+type counterReader struct {
+	mu      sync.Mutex
+	counter uint64
+	bytes   []byte
+}
+
+func (r *counterReader) Read(p []byte) (int, error) {
+	var (
+		n int
+	)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for n < len(p) {
+		if len(r.bytes) == 0 {
+			r.bytes = binary.BigEndian.AppendUint64(nil, r.counter)
+			r.counter++
+		}
+
+		copy(p[n:], r.bytes[:1])
+		r.bytes = r.bytes[1:]
+		n++
+	}
+
+	return n, nil
+}
+
+// failingReader fails every read.
+type failingReader struct{}
+
+func (failingReader) Read(p []byte) (int, error) {
+	return 0, errEntropySource
+}
+
+// flakyReader fails the given number of reads before reading from counterReader.
+type flakyReader struct {
+	counterReader
+	failures int
+	reads    int
+}
+
+func (r *flakyReader) Read(p []byte) (int, error) {
+	r.reads++
+	if r.reads <= r.failures {
+		return 0, errEntropySource
+	}
+
+	return r.counterReader.Read(p)
+}
+
+// errEntropySource is returned by failing entropy sources.
+var errEntropySource = errors.New("no entropy")
+
+func TestEntropyBuffer(t *testing.T) {
+	var (
+		myUUIDs [64][]*uuid.UUID
+		seen    map[[16]byte]bool
+		wg      sync.WaitGroup
+		index   int
+		offset  int
+		err     error
+	)
+
+	setupScopes(t, "one")
+	t.Cleanup(uuid.SetEntropySource(&counterReader{}))
+
+	//generating UUIDs concurrently so that the buffer gets refilled several times
+	for index = range myUUIDs {
+		wg.Add(1)
+
+		go func(index int) {
+			defer wg.Done()
+
+			for i := 0; i < 100; i++ {
+				myUUID, err := uuid.New("one")
+				if err != nil {
+					t.Error("Expected UUID to be generated but failed with error ", err.Error())
+					return
+				}
+
+				myUUIDs[index] = append(myUUIDs[index], myUUID)
+			}
+		}(index)
+	}
+
+	wg.Wait()
+
+	//the counter source yields unique 16 byte chunks; any duplicate means bytes have been reused
+	seen = make(map[[16]byte]bool)
+
+	for index = range myUUIDs {
+		for _, myUUID := range myUUIDs[index] {
+			if seen[myUUID.Bin()] {
+				t.Error("random data of UUID ", myUUID.Hex(), " has been used twice")
+			}
+
+			seen[myUUID.Bin()] = true
+		}
+	}
+
+	//direct reads must not touch the buffer
+	offset = uuid.EntropyBufferOffset()
+
+	uuid.SetDirectEntropy(true)
+	defer uuid.SetDirectEntropy(false)
+
+	_, err = uuid.New("one")
+	if err != nil {
+		t.Error("Expected UUID to be generated but failed with error ", err.Error())
+	}
+
+	if uuid.EntropyBufferOffset() != offset {
+		t.Error("direct read has taken data from the entropy buffer")
+	}
+}
+
+func TestEntropyBufferRefillError(t *testing.T) {
+	var (
+		err error
+	)
+
+	setupScopes(t, "one")
+	t.Cleanup(uuid.SetEntropySource(failingReader{}))
+
+	_, err = uuid.New("one")
+	if err == nil {
+		t.Error("Expected an error when the entropy buffer can't be refilled")
+	}
+
+	_, err = uuid.NewBatch("one", 10)
+	if err == nil {
+		t.Error("Expected an error when the entropy buffer can't be refilled")
+	}
+
+	uuid.SetDirectEntropy(true)
+	defer uuid.SetDirectEntropy(false)
+
+	_, err = uuid.New("one")
+	if err == nil {
+		t.Error("Expected an error when no entropy can be read")
+	}
+}
+
+func TestEntropyRetry(t *testing.T) {
+	var (
+		reader *flakyReader
+		err    error
+	)
+
+	setupScopes(t, "one")
+	defer uuid.SetDirectEntropy(false)
+
+	for _, direct := range []bool{true, false} {
+		uuid.SetDirectEntropy(direct)
+
+		//transient failures are retried
+		reader = &flakyReader{failures: 2}
+		restore := uuid.SetEntropySource(reader)
+
+		if _, err = uuid.New("one"); err != nil {
+			t.Error("direct ", direct, ": expected UUID after retries but got ", err)
+		}
+
+		if reader.reads != 3 {
+			t.Error("direct ", direct, ": expected 3 reads but got ", reader.reads)
+		}
+
+		//giving up after three attempts
+		reader = &flakyReader{failures: 3}
+		uuid.SetEntropySource(reader)
+
+		_, err = uuid.New("one")
+		if !errors.Is(err, uuid.ErrEntropy) || !errors.Is(err, errEntropySource) {
+			t.Error("direct ", direct, ": expected wrapped entropy error but got ", err)
+		}
+
+		if err != nil && err.Error() != uuid.ErrorEntropy+": no entropy" {
+			t.Error("direct ", direct, ": unexpected error message ", err.Error())
+		}
+
+		if reader.reads != 3 {
+			t.Error("direct ", direct, ": expected 3 reads but got ", reader.reads)
+		}
+
+		restore()
+	}
+
+	restore := uuid.SetEntropySource(failingReader{})
+	defer restore()
+
+	if _, err = uuid.NewBatch("one", 300); !errors.Is(err, uuid.ErrEntropy) {
+		t.Error("expected wrapped entropy error for batch but got ", err)
+	}
+
+	if _, err = uuid.NewOrdered("one"); !errors.Is(err, uuid.ErrEntropy) {
+		t.Error("expected wrapped entropy error for ordered UUID but got ", err)
+	}
+}
+
+func BenchmarkNewBuffered(b *testing.B) {
+	setupScopes(b, "one")
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := uuid.New("one"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkNewDirect(b *testing.B) {
+	setupScopes(b, "one")
+
+	uuid.SetDirectEntropy(true)
+	defer uuid.SetDirectEntropy(false)
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := uuid.New("one"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,162 @@
+// Package entuuid integrates uuid.UUID with ent.
+//
+// ID and BinaryID are the Go types of ent UUID fields holding scoped UUIDs. ID is stored as canonical
+// hex-string in text columns (or the uuid type of Postgres), BinaryID as 16 bytes in binary columns. Both
+// scan either representation.
+//
+// Scoped UUIDs can't be generated by the database, so Field and BinaryField define an ID field that is
+// generated on create:
+//
+//	func (User) Fields() []ent.Field {
+//		return []ent.Field{
+//			entuuid.Field("user"),
+//			field.String("name"),
+//		}
+//	}
+//
+// ent doesn't allow default functions to fail. If generating an ID fails, e.g. because the scopes have
+// not been set before the first insert, the failure is kept in the ID and Save returns it as a
+// validation error, so zero-valued keys are never written.
+//
+// The package lives in its own module so that the uuid package doesn't depend on ent.
+package entuuid
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/schema/field"
+	"github.com/4xoc/uuid"
+)
+
+var (
+	// ErrUninitialized is returned by Validate if the ID has neither been generated nor set.
+	ErrUninitialized = errors.New("entuuid: the ID is not initialized")
+)
+
+// ID is a uuid.UUID stored as canonical hex-string. Use it as the type of ent UUID fields.
+type ID struct {
+	uuid.UUID
+
+	// err holds the error of generating the ID with the default function of Field.
+	err error
+}
+
+// Validate implements the validator interface of ent, which calls it before saving. It returns the error
+// of generating the ID, or ErrUninitialized for the zero value.
+func (id ID) Validate() error {
+	return validate(id.UUID, id.err)
+}
+
+// BinaryID is a uuid.UUID stored as 16 bytes. Use it as the type of ent UUID fields in binary columns.
+type BinaryID struct {
+	uuid.UUID
+
+	// err holds the error of generating the ID with the default function of BinaryField.
+	err error
+}
+
+// Value implements driver.Valuer and returns the binary representation of the UUID. The zero value
+// results in SQL NULL.
+func (id BinaryID) Value() (driver.Value, error) {
+	var (
+		value driver.Value
+		bin   [16]byte
+		err   error
+	)
+
+	value, err = id.UUID.Value()
+	if value == nil || err != nil {
+		return value, err
+	}
+
+	bin = id.UUID.Bin()
+
+	return bin[:], nil
+}
+
+// Validate implements the validator interface of ent, which calls it before saving. It returns the error
+// of generating the ID, or ErrUninitialized for the zero value.
+func (id BinaryID) Validate() error {
+	return validate(id.UUID, id.err)
+}
+
+// validate implements Validate of ID and BinaryID.
+func validate(id uuid.UUID, err error) error {
+	if err != nil {
+		return err
+	}
+
+	//UUIDs of unknown scopes read with uuid.ReadAny have no scope name but are initialized
+	if id == (uuid.UUID{}) {
+		return ErrUninitialized
+	}
+
+	return nil
+}
+
+// generate returns a new UUID of the scope or the error of generating it.
+func generate(scope string) (uuid.UUID, error) {
+	var (
+		tmpUUID *uuid.UUID
+		err     error
+	)
+
+	tmpUUID, err = uuid.New(scope)
+	if err != nil {
+		return uuid.UUID{}, fmt.Errorf("entuuid: generating ID of scope %q: %w", scope, err)
+	}
+
+	return *tmpUUID, nil
+}
+
+// Default returns a default function for ent fields of type ID generating a UUID of the given scope. A
+// failure is returned by Validate of the generated ID.
+func Default(scope string) func() ID {
+	return func() ID {
+		var (
+			id ID
+		)
+
+		id.UUID, id.err = generate(scope)
+
+		return id
+	}
+}
+
+// DefaultBinary is like Default for ent fields of type BinaryID.
+func DefaultBinary(scope string) func() BinaryID {
+	return func() BinaryID {
+		var (
+			id BinaryID
+		)
+
+		id.UUID, id.err = generate(scope)
+
+		return id
+	}
+}
+
+// Field returns an immutable ID field named "id" that is generated with a UUID of the given scope on
+// create unless set explicitly.
+func Field(scope string) ent.Field {
+	return field.UUID("id", ID{}).
+		Default(Default(scope)).
+		Immutable()
+}
+
+// BinaryField is like Field for an ID stored as 16 bytes, using binary(16) in MySQL, bytea in Postgres
+// and blob in SQLite.
+func BinaryField(scope string) ent.Field {
+	return field.UUID("id", BinaryID{}).
+		Default(DefaultBinary(scope)).
+		SchemaType(map[string]string{
+			dialect.MySQL:    "binary(16)",
+			dialect.Postgres: "bytea",
+			dialect.SQLite:   "blob",
+		}).
+		Immutable()
+}
@@ -0,0 +1,194 @@
+package entuuid_test
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect"
+	"github.com/4xoc/uuid"
+	"github.com/4xoc/uuid/entuuid"
+	"github.com/4xoc/uuid/uuidtest"
+)
+
+func TestDefault(t *testing.T) {
+	var (
+		id     entuuid.ID
+		binary entuuid.BinaryID
+	)
+
+	uuid.ResetForTesting()
+	t.Cleanup(uuid.ResetForTesting)
+
+	//generating fails before the scopes are set
+	id = entuuid.Default("user")()
+	if err := id.Validate(); !errors.Is(err, uuid.ErrMissingScope) || !strings.Contains(err.Error(), `"user"`) {
+		t.Error("expected error for unset scopes but got ", err)
+	}
+
+	binary = entuuid.DefaultBinary("user")()
+	if err := binary.Validate(); !errors.Is(err, uuid.ErrMissingScope) {
+		t.Error("expected error for unset scopes but got ", err)
+	}
+
+	uuidtest.Scopes(t, "user")
+
+	id = entuuid.Default("user")()
+	if err := id.Validate(); err != nil || id.Scope() != "user" {
+		t.Error("unexpected ID ", id.DebugString(), err)
+	}
+
+	binary = entuuid.DefaultBinary("user")()
+	if err := binary.Validate(); err != nil || binary.Scope() != "user" {
+		t.Error("unexpected ID ", binary.DebugString(), err)
+	}
+
+	if err := (entuuid.ID{}).Validate(); err != entuuid.ErrUninitialized {
+		t.Error("expected ErrUninitialized but got ", err)
+	}
+
+	if err := (entuuid.BinaryID{}).Validate(); err != entuuid.ErrUninitialized {
+		t.Error("expected ErrUninitialized but got ", err)
+	}
+
+	foreign, err := uuid.ReadAny("fc000000-0000-0000-0000-0000000000fc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = (entuuid.ID{UUID: *foreign}).Validate(); err != nil {
+		t.Error("expected ID of an unknown scope to be valid but got ", err)
+	}
+}
+
+func TestValueScan(t *testing.T) {
+	var (
+		myUUID  *uuid.UUID
+		bin     [16]byte
+		id      entuuid.ID
+		binary  entuuid.BinaryID
+		scanned entuuid.BinaryID
+		value   driver.Value
+		err     error
+	)
+
+	uuidtest.Scopes(t, "user")
+
+	myUUID = uuidtest.Static(t, "user", "entuuid")
+	bin = myUUID.Bin()
+
+	id = entuuid.ID{UUID: *myUUID}
+	if value, err = id.Value(); err != nil || value != myUUID.Hex() {
+		t.Error("unexpected value ", value, err)
+	}
+
+	binary = entuuid.BinaryID{UUID: *myUUID}
+	if value, err = binary.Value(); err != nil || !bytes.Equal(value.([]byte), bin[:]) {
+		t.Error("unexpected value ", value, err)
+	}
+
+	if value, err = (entuuid.BinaryID{}).Value(); err != nil || value != nil {
+		t.Error("expected NULL for zero value but got ", value, err)
+	}
+
+	//both types scan text and binary columns
+	for _, src := range []interface{}{myUUID.Hex(), []byte(myUUID.Hex()), bin[:]} {
+		id, scanned = entuuid.ID{}, entuuid.BinaryID{}
+
+		if err = id.Scan(src); err != nil || id.Hex() != myUUID.Hex() || id.Validate() != nil {
+			t.Errorf("unexpected ID %s scanning %v: %v", id.DebugString(), src, err)
+		}
+
+		if err = scanned.Scan(src); err != nil || scanned.Hex() != myUUID.Hex() || scanned.Validate() != nil {
+			t.Errorf("unexpected ID %s scanning %v: %v", scanned.DebugString(), src, err)
+		}
+	}
+}
+
+func TestField(t *testing.T) {
+	for name, field := range map[string]ent.Field{
+		"Field":       entuuid.Field("user"),
+		"BinaryField": entuuid.BinaryField("user"),
+	} {
+		if descriptor := field.Descriptor(); descriptor.Err != nil || descriptor.Name != "id" || !descriptor.Immutable {
+			t.Error(name, ": unexpected descriptor ", descriptor, descriptor.Err)
+		}
+	}
+
+	if entuuid.BinaryField("user").Descriptor().SchemaType[dialect.SQLite] != "blob" {
+		t.Error("expected blob column in SQLite")
+	}
+}
+
+// TestExample generates the ent code of the example schema in testdata/example and runs the example
+// against the sqlite dialect of ent.
+func TestExample(t *testing.T) {
+	var (
+		dir    string
+		root   string
+		output []byte
+		err    error
+	)
+
+	if testing.Short() {
+		t.Skip("generating and compiling the ent code takes a while")
+	}
+
+	dir = t.TempDir()
+
+	root, err = filepath.Abs("..")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.CopyFS(dir, os.DirFS("testdata/example"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//the ent command loads the schema with golang.org/x/tools, which must support the toolchain
+	err = os.WriteFile(filepath.Join(dir, "go.mod"), []byte(`module example
+
+go 1.24
+
+require (
+	entgo.io/ent v0.14.6
+	github.com/4xoc/uuid v0.0.0
+	github.com/4xoc/uuid/entuuid v0.0.0
+	github.com/mattn/go-sqlite3 v1.14.28
+	golang.org/x/tools v0.50.0
+)
+
+replace (
+	github.com/4xoc/uuid => `+root+`
+	github.com/4xoc/uuid/entuuid => `+filepath.Join(root, "entuuid")+`
+)
+`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, args := range [][]string{
+		{"generate", "./ent"},
+		{"run", "."},
+	} {
+		cmd := exec.Command("go", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod")
+
+		output, err = cmd.CombinedOutput()
+		if err != nil {
+			t.Fatal("go ", strings.Join(args, " "), " failed: ", string(output), err)
+		}
+	}
+
+	if strings.TrimSpace(string(output)) != "ok" {
+		t.Fatal("unexpected output of the example ", string(output))
+	}
+}
@@ -0,0 +1,12 @@
+module github.com/4xoc/uuid/entuuid
+
+go 1.24
+
+require (
+	entgo.io/ent v0.14.6
+	github.com/4xoc/uuid v0.0.0
+)
+
+require github.com/google/uuid v1.3.0 // indirect
+
+replace github.com/4xoc/uuid => ../
@@ -0,0 +1,111 @@
+// Command example inserts and queries entities with scoped UUIDs as primary keys using the sqlite dialect
+// of ent. It is run by the tests of entuuid after generating the ent code.
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/4xoc/uuid"
+	"github.com/4xoc/uuid/entuuid"
+	_ "github.com/mattn/go-sqlite3"
+
+	"example/ent"
+	"example/ent/document"
+)
+
+func main() {
+	if err := run(context.Background()); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	fmt.Println("ok")
+}
+
+func run(ctx context.Context) error {
+	var (
+		db       *sql.DB
+		client   *ent.Client
+		user     *ent.User
+		doc      *ent.Document
+		loaded   *ent.Document
+		explicit *uuid.UUID
+		count    int
+		err      error
+	)
+
+	db, err = sql.Open("sqlite3", "file:example?mode=memory&cache=shared&_fk=1")
+	if err != nil {
+		return err
+	}
+
+	client = ent.NewClient(ent.Driver(entsql.OpenDB(dialect.SQLite, db)))
+	defer client.Close()
+
+	if err = client.Schema.Create(ctx); err != nil {
+		return err
+	}
+
+	//the scopes are not set before the first insert
+	_, err = client.User.Create().SetName("alice").Save(ctx)
+	if !ent.IsValidationError(err) || !errors.Is(err, uuid.ErrMissingScope) {
+		return fmt.Errorf("expected validation error for unset scopes but got %v", err)
+	}
+
+	if count, err = client.User.Query().Count(ctx); err != nil || count != 0 {
+		return fmt.Errorf("expected no users but got %d (%v)", count, err)
+	}
+
+	if err = uuid.SetScopes([64]string{"user", "document"}); err != nil {
+		return err
+	}
+
+	user, err = client.User.Create().SetName("alice").Save(ctx)
+	if err != nil {
+		return err
+	}
+
+	if user.ID.Scope() != "user" {
+		return fmt.Errorf("expected ID of scope user but got %s", user.ID.DebugString())
+	}
+
+	doc, err = client.Document.Create().SetTitle("notes").SetOwner(user).Save(ctx)
+	if err != nil {
+		return err
+	}
+
+	//binary IDs are stored as 16 bytes
+	if err = db.QueryRowContext(ctx, "SELECT length(id) FROM documents").Scan(&count); err != nil || count != 16 {
+		return fmt.Errorf("expected 16 byte IDs but got %d (%v)", count, err)
+	}
+
+	loaded, err = client.Document.Query().Where(document.ID(doc.ID)).WithOwner().Only(ctx)
+	if err != nil {
+		return err
+	}
+
+	if loaded.ID.Hex() != doc.ID.Hex() || loaded.ID.Scope() != "document" || loaded.Edges.Owner.ID.Hex() != user.ID.Hex() {
+		return fmt.Errorf("unexpected document %s owned by %v", loaded.ID.DebugString(), loaded.Edges.Owner)
+	}
+
+	//explicitly set IDs are kept
+	explicit, _ = uuid.New("user")
+
+	user, err = client.User.Create().SetID(entuuid.ID{UUID: *explicit}).SetName("bob").Save(ctx)
+	if err != nil || user.ID.Hex() != explicit.Hex() {
+		return fmt.Errorf("expected explicit ID %s but got %v (%v)", explicit.Hex(), user, err)
+	}
+
+	_, err = client.User.Create().SetID(entuuid.ID{}).SetName("carol").Save(ctx)
+	if !errors.Is(err, entuuid.ErrUninitialized) {
+		return fmt.Errorf("expected error for zero ID but got %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package uuid
+
+import (
+	"errors"
+)
+
+// envelopeVersion is the version of the envelope format written by MarshalEnvelope.
+const envelopeVersion byte = 1
+
+var (
+	// ErrBadEnvelope is returned by UnmarshalEnvelope for data that is not an envelope. Its message is
+	// ErrorBadEnvelope.
+	ErrBadEnvelope = errors.New(ErrorBadEnvelope)
+	// ErrEnvelopeVersion is returned by UnmarshalEnvelope for envelopes of an unknown version, e.g. written
+	// by a future version of this package. Its message is ErrorEnvelopeVersion.
+	ErrEnvelopeVersion = errors.New(ErrorEnvelopeVersion)
+	// ErrEnvelopeScope is returned by UnmarshalEnvelope if the scope name of the envelope doesn't match
+	// the scope byte of the UUID, which means the scope tables of both sides diverged. Its message is
+	// ErrorEnvelopeScope.
+	ErrEnvelopeScope = errors.New(ErrorEnvelopeScope)
+)
+
+// MarshalEnvelope returns a self-describing binary envelope of the UUID for systems that don't share the
+// scope table. The envelope consists of
+//
+//   - the format version (1 byte, currently 1),
+//   - the length of the scope name (1 byte),
+//   - the scope name,
+//   - the 16 bytes of the binary representation.
+//
+// If the UUID is nil or not initialized, ErrorUninitializedUUID is returned, for UUIDs of an unknown scope
+// (see ReadAny) ErrBadScope. See UnmarshalEnvelope.
+func (uuid *UUID) MarshalEnvelope() ([]byte, error) {
+	var (
+		buf []byte
+	)
+
+	if uuid == nil || uuid.scope == "" {
+		return nil, errors.New(ErrorUninitializedUUID)
+	}
+
+	if uuid.scope == unknownScope {
+		return nil, ErrBadScope
+	}
+
+	if len(uuid.scope) > 255 {
+		return nil, errors.New(ErrorBadScopeName)
+	}
+
+	buf = make([]byte, 0, 2+len(uuid.scope)+16)
+	buf = append(buf, envelopeVersion, byte(len(uuid.scope)))
+	buf = append(buf, uuid.scope...)
+
+	return append(buf, uuid.bin[:]...), nil
+}
+
+// UnmarshalEnvelope parses an envelope as returned by MarshalEnvelope. The scope byte of the UUID must be
+// known like for Read, and the scope name of the envelope must be known as well and refer to the same
+// scope, aliases included (see AliasScope). Otherwise the scope tables of both sides diverged and
+// ErrEnvelopeScope is returned.
+//
+// Envelopes of an unknown version return ErrEnvelopeVersion, malformed envelopes ErrBadEnvelope.
+func UnmarshalEnvelope(data []byte) (*UUID, error) {
+	var (
+		uuid      UUID
+		table     *scopeTable
+		name      string
+		scopeByte byte
+		ok        bool
+		err       error
+	)
+
+	if len(data) == 0 {
+		return nil, ErrBadEnvelope
+	}
+
+	if data[0] != envelopeVersion {
+		return nil, ErrEnvelopeVersion
+	}
+
+	if len(data) < 2 || len(data) != 2+int(data[1])+16 {
+		return nil, ErrBadEnvelope
+	}
+
+	name = string(data[2 : 2+data[1]])
+	copy(uuid.bin[:], data[2+data[1]:])
+
+	table = loadScopes()
+
+	err = uuid.resolveScopeIn(table)
+	if err != nil {
+		return nil, err
+	}
+
+	_, scopeByte, ok = table.resolve(name)
+	if !ok {
+		return nil, ErrMissingScope
+	}
+
+	if scopeByte != uuid.scopeBits() {
+		return nil, ErrEnvelopeScope
+	}
+
+	return &uuid, nil
+}
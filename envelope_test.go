@@ -0,0 +1,93 @@
+package uuid_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"github.com/4xoc/uuid"
+	"testing"
+)
+
+func TestEnvelope(t *testing.T) {
+	var (
+		myUUID   *uuid.UUID
+		other    *uuid.UUID
+		decoded  *uuid.UUID
+		envelope []byte
+		err      error
+	)
+
+	setupScopes(t, "user", "org")
+
+	myUUID = mustNew(t, "user")
+
+	envelope, err = myUUID.MarshalEnvelope()
+	if err != nil || len(envelope) != 2+len("user")+16 {
+		t.Fatal("unexpected envelope ", envelope, err)
+	}
+
+	decoded, err = uuid.UnmarshalEnvelope(envelope)
+	if err != nil || decoded.Hex() != myUUID.Hex() || decoded.Scope() != "user" {
+		t.Fatal("envelope doesn't round-trip ", decoded.DebugString(), err)
+	}
+
+	//the scope name may be an alias on the receiving side
+	uuid.AliasScope("user", "member")
+
+	decoded, err = uuid.UnmarshalEnvelope(append([]byte{1, 6}, append([]byte("member"), envelope[6:]...)...))
+	if err != nil || decoded.Hex() != myUUID.Hex() || decoded.Scope() != "user" {
+		t.Error("expected alias to be accepted but got ", decoded.DebugString(), err)
+	}
+
+	if _, err = (*uuid.UUID)(nil).MarshalEnvelope(); err == nil || err.Error() != uuid.ErrorUninitializedUUID {
+		t.Error("expected ErrorUninitializedUUID for nil UUID but got ", err)
+	}
+
+	if _, err = (&uuid.UUID{}).MarshalEnvelope(); err == nil || err.Error() != uuid.ErrorUninitializedUUID {
+		t.Error("expected ErrorUninitializedUUID for uninitialized UUID but got ", err)
+	}
+
+	//the same bytes with the scope byte of another scope
+	other, _ = uuid.Rescope(myUUID, "org")
+	otherBin := other.Bin()
+
+	for index, testCase := range []struct {
+		data []byte
+		err  error
+	}{
+		{nil, uuid.ErrBadEnvelope},
+		{[]byte{1}, uuid.ErrBadEnvelope},
+		{envelope[:len(envelope)-1], uuid.ErrBadEnvelope},
+		{append(bytes.Clone(envelope), 0), uuid.ErrBadEnvelope},
+		{append([]byte{1, 5}, envelope[2:]...), uuid.ErrBadEnvelope},
+		{append([]byte{0}, envelope[1:]...), uuid.ErrEnvelopeVersion},
+		{append([]byte{2}, envelope[1:]...), uuid.ErrEnvelopeVersion},
+		{append([]byte{2}, "any future format"...), uuid.ErrEnvelopeVersion},
+		//the scope name doesn't match the scope byte
+		{append([]byte{1, 3}, append([]byte("org"), envelope[6:]...)...), uuid.ErrEnvelopeScope},
+		{append(bytes.Clone(envelope[:6]), otherBin[:]...), uuid.ErrEnvelopeScope},
+		//unknown scope name or scope byte
+		{append([]byte{1, 5}, append([]byte("group"), envelope[6:]...)...), uuid.ErrMissingScope},
+		{append(bytes.Clone(envelope[:6]), bytes.Repeat([]byte{0xfc}, 16)...), uuid.ErrBadScope},
+	} {
+		if decoded, err = uuid.UnmarshalEnvelope(testCase.data); err != testCase.err || decoded != nil {
+			t.Error("test case ", index, ": expected ", testCase.err, " but got ", decoded.DebugString(), err)
+		}
+	}
+}
+
+// TestEnvelopeFormat pins the envelope format, which other systems decode.
+func TestEnvelopeFormat(t *testing.T) {
+	var (
+		envelope []byte
+		err      error
+	)
+
+	setupScopes(t, "one", "user")
+
+	myUUID := mustRead(t, fixture("0529a1d0-84f3-4d8d-b6cc-682d1ca34dae"))
+
+	envelope, err = myUUID.MarshalEnvelope()
+	if err != nil || hex.EncodeToString(envelope) != "0104"+hex.EncodeToString([]byte("user"))+myUUID.CompactHex() {
+		t.Error("unexpected envelope ", hex.EncodeToString(envelope), err)
+	}
+}
@@ -0,0 +1,75 @@
+package uuid
+
+import (
+	"io"
+)
+
+// ResetScopes clears the configured scopes so that tests can install their own set of scopes.
+func ResetScopes() {
+	ResetForTesting()
+}
+
+// SetEntropySource replaces the reader random data is taken from and empties the entropy buffer. The
+// returned function restores the original state.
+func SetEntropySource(r io.Reader) func() {
+	var (
+		orig io.Reader
+	)
+
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	orig = entropySource
+	entropySource = r
+	pool.off = entropyBufferSize
+
+	return func() {
+		pool.mu.Lock()
+		defer pool.mu.Unlock()
+
+		entropySource = orig
+		pool.off = entropyBufferSize
+	}
+}
+
+// EntropyBufferOffset returns the offset of the first byte in the entropy buffer that hasn't been
+// handed out yet.
+func EntropyBufferOffset() int {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	return pool.off
+}
+
+// MaxBatchSize exposes the largest number of UUIDs NewBatch accepts.
+const MaxBatchSize = maxBatchSize
+
+// SetClock replaces the clock used by NewOrdered and resets its state. The returned function restores
+// the original clock.
+func SetClock(clock func() int64) func() {
+	var (
+		orig func() int64
+	)
+
+	ordered.mu.Lock()
+	defer ordered.mu.Unlock()
+
+	orig = nowMillis
+	nowMillis = clock
+	ordered.millis = 0
+	ordered.counter = 0
+
+	return func() {
+		ordered.mu.Lock()
+		defer ordered.mu.Unlock()
+
+		nowMillis = orig
+		ordered.millis = 0
+		ordered.counter = 0
+	}
+}
+
+// Unscoped returns a UUID holding the given binary data without a resolved scope.
+func Unscoped(bin [16]byte) UUID {
+	return UUID{bin: bin}
+}
@@ -0,0 +1,72 @@
+package uuid
+
+import (
+	"errors"
+)
+
+// ScopeFactory generates UUIDs of a single scope. The scope is resolved once when the factory is
+// created so generating UUIDs can't fail because of an unknown scope anymore.
+type ScopeFactory struct {
+	// scope is the scope of all UUIDs generated by the factory.
+	scope string
+	// scopeByte is the binary representation of scope.
+	scopeByte byte
+}
+
+// ForScope returns a ScopeFactory that generates UUIDs of the given scope. If the scope doesn't
+// exist, an error is returned right away.
+func ForScope(scope string) (*ScopeFactory, error) {
+	var (
+		name      string
+		scopeByte byte
+		err       error
+	)
+
+	name, scopeByte, err = loadScopes().resolveNew(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ScopeFactory{
+		scope:     name,
+		scopeByte: scopeByte,
+	}, nil
+}
+
+// Scope returns the scope of the UUIDs generated by the factory.
+func (factory *ScopeFactory) Scope() string {
+	return factory.scope
+}
+
+// New generates a new UUID of the factory's scope. See New function. If the scope has been deprecated
+// after the factory was created, ErrScopeDeprecated is returned.
+func (factory *ScopeFactory) New() (*UUID, error) {
+	var (
+		uuid UUID
+		err  error
+	)
+
+	if loadScopes().isDeprecated(factory.scope) {
+		return nil, ErrScopeDeprecated
+	}
+
+	err = uuid.generate(factory.scope, factory.scopeByte)
+	if err != nil {
+		return nil, err
+	}
+
+	return &uuid, nil
+}
+
+// NewBatch generates n new UUIDs of the factory's scope. See NewBatch function.
+func (factory *ScopeFactory) NewBatch(n int) ([]*UUID, error) {
+	if n <= 0 || n > maxBatchSize {
+		return nil, errors.New(ErrorBadBatchSize)
+	}
+
+	if loadScopes().isDeprecated(factory.scope) {
+		return nil, ErrScopeDeprecated
+	}
+
+	return newBatch(factory.scope, factory.scopeByte, n)
+}
@@ -0,0 +1,59 @@
+package uuid_test
+
+import (
+	"github.com/4xoc/uuid"
+	"testing"
+)
+
+func TestScopeFactory(t *testing.T) {
+	var (
+		factory *uuid.ScopeFactory
+		myUUID  *uuid.UUID
+		myCopy  *uuid.UUID
+		batch   []*uuid.UUID
+		index   int
+		err     error
+	)
+
+	setupScopes(t, "one", "two", "three")
+
+	_, err = uuid.ForScope("ten")
+	if err == nil || err.Error() != uuid.ErrorMissingScope {
+		t.Error("Expected error for unknown scope")
+	}
+
+	factory, err = uuid.ForScope("three")
+	if err != nil {
+		t.Fatal("Expected factory to be created but failed with error ", err.Error())
+	}
+
+	if factory.Scope() != "three" {
+		t.Error("factory does not match the scope defined on creation time.")
+	}
+
+	myUUID, err = factory.New()
+	if err != nil {
+		t.Fatal("Expected UUID to be generated but failed with error ", err.Error())
+	}
+
+	myCopy, err = uuid.Read(myUUID.Hex())
+	if err != nil || myCopy.Scope() != "three" || myUUID.Scope() != "three" {
+		t.Error("Expected UUID to be read with scope three")
+	}
+
+	_, err = factory.NewBatch(0)
+	if err == nil || err.Error() != uuid.ErrorBadBatchSize {
+		t.Error("Expected error for batch size 0")
+	}
+
+	batch, err = factory.NewBatch(100)
+	if err != nil || len(batch) != 100 {
+		t.Fatal("Expected batch to be generated")
+	}
+
+	for index = range batch {
+		if batch[index].Scope() != "three" {
+			t.Error("UUID does not match the scope of the factory.")
+		}
+	}
+}
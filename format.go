@@ -0,0 +1,184 @@
+package uuid
+
+import (
+	"encoding"
+	"errors"
+)
+
+// AppendHex appends the canonical hex-string representation of the UUID to dst and returns the
+// extended buffer. It only allocates if dst doesn't have enough capacity.
+//
+// If the UUID is not initialized, nothing is appended.
+func (uuid *UUID) AppendHex(dst []byte) []byte {
+	if uuid == nil || uuid.scope == "" {
+		return dst
+	}
+
+	return appendHex(dst, uuid.bin[:])
+}
+
+// AppendCompact appends the hex-string representation of the UUID without dashes (32 characters) to dst
+// and returns the extended buffer. It only allocates if dst doesn't have enough capacity.
+//
+// If the UUID is not initialized, nothing is appended.
+func (uuid *UUID) AppendCompact(dst []byte) []byte {
+	var (
+		index int
+	)
+
+	if uuid == nil || uuid.scope == "" {
+		return dst
+	}
+
+	for index = 0; index < 16; index++ {
+		dst = append(dst, hexDigits[uuid.bin[index]>>4], hexDigits[uuid.bin[index]&0x0f])
+	}
+
+	return dst
+}
+
+var (
+	_ encoding.TextAppender   = (*UUID)(nil)
+	_ encoding.BinaryAppender = (*UUID)(nil)
+)
+
+// AppendText implements encoding.TextAppender by appending the canonical hex-string representation of the
+// UUID to b. It only allocates if b doesn't have enough capacity.
+//
+// If the UUID is nil or not initialized, b is returned unchanged along with ErrorUninitializedUUID.
+func (uuid *UUID) AppendText(b []byte) ([]byte, error) {
+	if uuid == nil || uuid.scope == "" {
+		return b, errors.New(ErrorUninitializedUUID)
+	}
+
+	return appendHex(b, uuid.bin[:]), nil
+}
+
+// AppendBinary implements encoding.BinaryAppender by appending the 16 bytes of the binary representation
+// of the UUID to b. It only allocates if b doesn't have enough capacity.
+//
+// If the UUID is nil or not initialized, b is returned unchanged along with ErrorUninitializedUUID.
+func (uuid *UUID) AppendBinary(b []byte) ([]byte, error) {
+	if uuid == nil || uuid.scope == "" {
+		return b, errors.New(ErrorUninitializedUUID)
+	}
+
+	return append(b, uuid.bin[:]...), nil
+}
+
+// CompactHex returns the hex-string representation of the UUID without dashes (32 lowercase characters).
+// It can be parsed with ReadCompact.
+//
+// If the UUID is not initialized, an empty string is returned.
+func (uuid *UUID) CompactHex() string {
+	var (
+		buf [32]byte
+	)
+
+	if uuid == nil || uuid.scope == "" {
+		return ""
+	}
+
+	return string(uuid.AppendCompact(buf[:0]))
+}
+
+// HexUpper returns the canonical hex-string representation of the UUID in uppercase.
+//
+// If the UUID is not initialized, an empty string is returned.
+func (uuid *UUID) HexUpper() string {
+	var (
+		buf   [36]byte
+		index int
+	)
+
+	if uuid == nil || uuid.scope == "" {
+		return ""
+	}
+
+	appendHex(buf[:0], uuid.bin[:])
+
+	for index = range buf {
+		if buf[index] >= 'a' {
+			buf[index] -= 'a' - 'A'
+		}
+	}
+
+	return string(buf[:])
+}
+
+// ReadCompact parses the hex-string representation of a UUID without dashes as returned by CompactHex.
+// Like Read, only lowercase characters are accepted.
+func ReadCompact(input string) (*UUID, error) {
+	var (
+		uuid UUID
+		err  error
+	)
+
+	err = uuid.readCompact(input)
+	if err != nil {
+		reportParseError(input, err)
+		return nil, err
+	}
+
+	return &uuid, nil
+}
+
+// readCompact sets the binary data and the scope of the uuid from the given hex-string without dashes.
+func (uuid *UUID) readCompact(input string) error {
+	var (
+		err error
+	)
+
+	err = decodeCompact(&uuid.bin, input)
+	if err != nil {
+		return err
+	}
+
+	return uuid.resolveParsed(loadScopes())
+}
+
+// EncodeBinary writes the 16 bytes of the binary representation into dst and returns the number of
+// bytes written. If dst is shorter than 16 bytes, ErrorShortBuffer is returned and nothing is written.
+//
+// If the UUID is nil, ErrorUninitializedUUID is returned.
+func (uuid *UUID) EncodeBinary(dst []byte) (int, error) {
+	if uuid == nil {
+		return 0, errors.New(ErrorUninitializedUUID)
+	}
+
+	if len(dst) < 16 {
+		return 0, errors.New(ErrorShortBuffer)
+	}
+
+	return copy(dst, uuid.bin[:]), nil
+}
+
+// DebugString returns the scope and the canonical hex-string of the UUID separated by a slash, e.g.
+// "user/9c4fb1d0-84f3-4d8d-b6cc-682d1ca34dae". UUIDs without a resolved scope are printed as
+// "unscoped/<hex>", including those of an unknown scope read with ReadAny, and nil UUIDs as "<nil>". The
+// format is stable. UUIDs of scopes set with SetRedactedScopes are printed as returned by Redacted instead.
+func (uuid *UUID) DebugString() string {
+	var (
+		buf []byte
+	)
+
+	if uuid == nil {
+		return "<nil>"
+	}
+
+	if uuid.isRedacted() {
+		return uuid.Redacted()
+	}
+
+	if uuid.scope == "" || uuid.scope == unknownScope {
+		buf = make([]byte, 0, 9+36)
+		buf = append(buf, "unscoped"...)
+	} else {
+		buf = make([]byte, 0, len(uuid.scope)+1+36)
+		buf = append(buf, uuid.scope...)
+	}
+
+	buf = append(buf, '/')
+
+	return string(appendHex(buf, uuid.bin[:]))
+}
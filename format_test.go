@@ -0,0 +1,274 @@
+package uuid_test
+
+import (
+	"fmt"
+	"github.com/4xoc/uuid"
+	"strings"
+	"testing"
+)
+
+func TestAppend(t *testing.T) {
+	var (
+		myUUID *uuid.UUID
+		nilPtr *uuid.UUID
+		buf    []byte
+		bin    [16]byte
+		n      int
+		allocs float64
+		err    error
+	)
+
+	setupScopes(t, "one", "two")
+
+	myUUID, _ = uuid.New("two")
+	bin = myUUID.Bin()
+
+	if string(myUUID.AppendHex([]byte("id="))) != "id="+myUUID.Hex() {
+		t.Error("unexpected result of AppendHex")
+	}
+
+	if string(myUUID.AppendCompact([]byte("id="))) != "id="+strings.Replace(myUUID.Hex(), "-", "", -1) {
+		t.Error("unexpected result of AppendCompact")
+	}
+
+	if len(nilPtr.AppendHex([]byte("id="))) != 3 || len(nilPtr.AppendCompact(nil)) != 0 {
+		t.Error("nil UUID must not append anything")
+	}
+
+	if len((&uuid.UUID{}).AppendHex(nil)) != 0 {
+		t.Error("uninitialized UUID must not append anything")
+	}
+
+	buf = make([]byte, 20)
+
+	n, err = myUUID.EncodeBinary(buf)
+	if err != nil || n != 16 || string(buf[:16]) != string(bin[:]) {
+		t.Error("unexpected result of EncodeBinary")
+	}
+
+	_, err = myUUID.EncodeBinary(buf[:15])
+	if err == nil || err.Error() != uuid.ErrorShortBuffer {
+		t.Error("Expected error for short buffer")
+	}
+
+	_, err = nilPtr.EncodeBinary(buf)
+	if err == nil || err.Error() != uuid.ErrorUninitializedUUID {
+		t.Error("Expected error for nil UUID")
+	}
+
+	//no allocations when the buffer is large enough
+	buf = make([]byte, 0, 64)
+
+	allocs = testing.AllocsPerRun(100, func() {
+		buf = myUUID.AppendHex(buf[:0])
+		buf = myUUID.AppendCompact(buf[:0])
+		myUUID.EncodeBinary(buf[:16])
+	})
+
+	if allocs != 0 {
+		t.Error("Expected no allocations but got ", allocs)
+	}
+}
+
+func BenchmarkAppendHex(b *testing.B) {
+	var (
+		myUUID *uuid.UUID
+		buf    []byte
+	)
+
+	setupScopes(b, "one")
+
+	myUUID, _ = uuid.New("one")
+	buf = make([]byte, 0, 64)
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		buf = myUUID.AppendHex(buf[:0])
+	}
+}
+
+func BenchmarkSprintfHex(b *testing.B) {
+	var (
+		myUUID *uuid.UUID
+		bin    [16]byte
+		buf    []byte
+	)
+
+	setupScopes(b, "one")
+
+	myUUID, _ = uuid.New("one")
+	bin = myUUID.Bin()
+	buf = make([]byte, 0, 64)
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		buf = append(buf[:0], fmt.Sprintf("%x-%x-%x-%x-%x", bin[0:4], bin[4:6], bin[6:8], bin[8:10], bin[10:16])...)
+	}
+}
+
+func BenchmarkAppendCompact(b *testing.B) {
+	var (
+		myUUID *uuid.UUID
+		buf    []byte
+	)
+
+	setupScopes(b, "one")
+
+	myUUID, _ = uuid.New("one")
+	buf = make([]byte, 0, 64)
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		buf = myUUID.AppendCompact(buf[:0])
+	}
+}
+
+func BenchmarkSprintfCompact(b *testing.B) {
+	var (
+		myUUID *uuid.UUID
+		bin    [16]byte
+		buf    []byte
+	)
+
+	setupScopes(b, "one")
+
+	myUUID, _ = uuid.New("one")
+	bin = myUUID.Bin()
+	buf = make([]byte, 0, 64)
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		buf = append(buf[:0], fmt.Sprintf("%x", bin[:])...)
+	}
+}
+
+func TestAppenders(t *testing.T) {
+	var (
+		myUUID *uuid.UUID
+		nilPtr *uuid.UUID
+		buf    []byte
+		bin    [16]byte
+		allocs float64
+		err    error
+	)
+
+	setupScopes(t, "one", "two")
+
+	myUUID = mustNew(t, "two")
+	bin = myUUID.Bin()
+
+	buf, err = myUUID.AppendText([]byte("id="))
+	if err != nil || string(buf) != "id="+myUUID.Hex() {
+		t.Error("unexpected result of AppendText ", string(buf), err)
+	}
+
+	buf, err = myUUID.AppendBinary([]byte("id="))
+	if err != nil || string(buf) != "id="+string(bin[:]) {
+		t.Error("unexpected result of AppendBinary ", buf, err)
+	}
+
+	for _, empty := range []*uuid.UUID{nilPtr, {}} {
+		buf, err = empty.AppendText([]byte("id="))
+		if err == nil || err.Error() != uuid.ErrorUninitializedUUID || string(buf) != "id=" {
+			t.Error("Expected error for uninitialized UUID but got ", err)
+		}
+
+		buf, err = empty.AppendBinary([]byte("id="))
+		if err == nil || err.Error() != uuid.ErrorUninitializedUUID || string(buf) != "id=" {
+			t.Error("Expected error for uninitialized UUID but got ", err)
+		}
+	}
+
+	//no allocations when the buffer is large enough
+	buf = make([]byte, 0, 64)
+
+	allocs = testing.AllocsPerRun(100, func() {
+		buf, _ = myUUID.AppendText(buf[:0])
+		buf, _ = myUUID.AppendBinary(buf[:0])
+	})
+
+	if allocs != 0 {
+		t.Error("Expected no allocations but got ", allocs)
+	}
+}
+
+func TestCompactHexUpper(t *testing.T) {
+	var (
+		myUUID *uuid.UUID
+		nilPtr *uuid.UUID
+		copied *uuid.UUID
+		err    error
+	)
+
+	setupScopes(t, "one", "two")
+
+	myUUID = mustNew(t, "two")
+
+	if myUUID.CompactHex() != strings.Replace(myUUID.Hex(), "-", "", -1) || len(myUUID.CompactHex()) != 32 {
+		t.Error("unexpected compact hex ", myUUID.CompactHex())
+	}
+
+	if myUUID.HexUpper() != strings.ToUpper(myUUID.Hex()) {
+		t.Error("unexpected uppercase hex ", myUUID.HexUpper())
+	}
+
+	//neither of them changes the UUID
+	if myUUID.Hex() != strings.ToLower(myUUID.HexUpper()) {
+		t.Error("HexUpper modified the UUID")
+	}
+
+	copied, err = uuid.ReadCompact(myUUID.CompactHex())
+	if err != nil || copied.Bin() != myUUID.Bin() || copied.Scope() != "two" {
+		t.Error("compact hex doesn't round-trip: ", err)
+	}
+
+	if mustRead(t, strings.ToLower(myUUID.HexUpper())).Bin() != myUUID.Bin() {
+		t.Error("uppercase hex doesn't round-trip")
+	}
+
+	if nilPtr.CompactHex() != "" || nilPtr.HexUpper() != "" || new(uuid.UUID).HexUpper() != "" {
+		t.Error("nil and uninitialized UUIDs must return empty strings")
+	}
+
+	testCases := []struct {
+		input string
+		err   error
+	}{
+		{"", uuid.ErrBadString},
+		{strings.ToUpper(myUUID.CompactHex()), uuid.ErrBadString},
+		{myUUID.CompactHex()[:31], uuid.ErrBadString},
+		{myUUID.CompactHex() + "00", uuid.ErrBadString},
+		{myUUID.Hex(), uuid.ErrBadString},
+		{"fc0000000000000000000000000000fc", uuid.ErrBadScope},
+	}
+
+	for index := range testCases {
+		if _, err = uuid.ReadCompact(testCases[index].input); err != testCases[index].err {
+			t.Error("test case ", index, ": expected error ", testCases[index].err, " but got ", err)
+		}
+	}
+}
+
+func TestDebugString(t *testing.T) {
+	var (
+		nilPtr *uuid.UUID
+	)
+
+	setupScopes(t, "one", "user")
+
+	if nilPtr.DebugString() != "<nil>" {
+		t.Error("unexpected debug string for nil UUID ", nilPtr.DebugString())
+	}
+
+	if (&uuid.UUID{}).DebugString() != "unscoped/00000000-0000-0000-0000-000000000000" {
+		t.Error("unexpected debug string for uninitialized UUID ", (&uuid.UUID{}).DebugString())
+	}
+
+	if mustRead(t, fixture("0529a1d0-84f3-4d8d-b6cc-682d1ca34dae")).DebugString() != "user/"+fixture("0529a1d0-84f3-4d8d-b6cc-682d1ca34dae") {
+		t.Error("unexpected debug string")
+	}
+}
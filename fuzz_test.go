@@ -0,0 +1,279 @@
+package uuid_test
+
+import (
+	"bytes"
+	"github.com/4xoc/uuid"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// canonical matches the canonical hex-string representation of a UUID.
+var canonical = regexp.MustCompile("^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$")
+
+// fuzzScopes are the scopes used by all fuzz targets.
+var fuzzScopes = []string{"one", "two", "three"}
+
+// fuzzStrings seeds the string based fuzz targets with the forms UUIDs are commonly written in.
+var fuzzStrings = []string{
+	"",
+	"04000000-0000-0000-0000-000000000000",
+	"05a1b2c3-d4e5-f6a7-b8c9-d0e1f2a3b4c5",
+	"05A1B2C3-D4E5-F6A7-B8C9-D0E1F2A3B4C5",
+	"fc000000-0000-0000-0000-000000000000",
+	"{04000000-0000-0000-0000-000000000000}",
+	"urn:uuid:04000000-0000-0000-0000-000000000000",
+	"04000000000000000000000000000000",
+	"0400000-00000-0000-0000-000000000000",
+	"04000000-0000-0000-0000-00000000000",
+	"04000000-0000-0000-0000-000000000000.1234",
+	"two_05a1b2c3d4e5f6a7b8c9d0e1f2a3b4c5",
+	"two_05a1b2c3d4e5f6a7b8c9d0e1f2a3b4c",
+	"_",
+	"-___-",
+}
+
+// checkParsed verifies the invariants of a successfully parsed UUID.
+func checkParsed(t *testing.T, myUUID *uuid.UUID) {
+	t.Helper()
+
+	if !myUUID.IsValid() {
+		t.Fatal("parsed UUID is not valid: ", myUUID.Validate())
+	}
+
+	if mustRead(t, myUUID.Hex()).Bin() != myUUID.Bin() {
+		t.Fatal("parsed UUID doesn't survive a round trip")
+	}
+}
+
+func FuzzRead(f *testing.F) {
+	setupScopes(f, fuzzScopes...)
+
+	for _, seed := range fuzzStrings {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, input string) {
+		myUUID, err := uuid.Read(input)
+		if err != nil {
+			if myUUID != nil {
+				t.Fatal("UUID should be nil on error")
+			}
+			return
+		}
+
+		checkParsed(t, myUUID)
+
+		if myUUID.Hex() != input {
+			t.Fatal("parsed UUID ", myUUID.Hex(), " doesn't match input ", input)
+		}
+	})
+}
+
+func FuzzReadChecked(f *testing.F) {
+	setupScopes(f, fuzzScopes...)
+
+	for _, seed := range fuzzStrings {
+		f.Add(seed)
+	}
+	f.Add(mustNew(f, "two").HexChecked())
+
+	f.Fuzz(func(t *testing.T, input string) {
+		myUUID, err := uuid.ReadChecked(input)
+		if err != nil {
+			return
+		}
+
+		checkParsed(t, myUUID)
+
+		if myUUID.HexChecked() != input {
+			t.Fatal("parsed UUID ", myUUID.HexChecked(), " doesn't match input ", input)
+		}
+	})
+}
+
+func FuzzReadPrefixed(f *testing.F) {
+	setupScopes(f, fuzzScopes...)
+
+	for _, seed := range fuzzStrings {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, input string) {
+		myUUID, err := uuid.ReadPrefixed(input)
+		if err != nil {
+			return
+		}
+
+		checkParsed(t, myUUID)
+
+		if myUUID.Prefixed() != input {
+			t.Fatal("parsed UUID ", myUUID.Prefixed(), " doesn't match input ", input)
+		}
+	})
+}
+
+func FuzzReadCompact(f *testing.F) {
+	setupScopes(f, fuzzScopes...)
+
+	for _, seed := range fuzzStrings {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, input string) {
+		myUUID, err := uuid.ReadCompact(input)
+		if err != nil {
+			return
+		}
+
+		checkParsed(t, myUUID)
+
+		if myUUID.CompactHex() != input {
+			t.Fatal("parsed UUID ", myUUID.CompactHex(), " doesn't match input ", input)
+		}
+	})
+}
+
+func FuzzParseList(f *testing.F) {
+	setupScopes(f, fuzzScopes...)
+
+	f.Add(strings.Join(fuzzStrings[1:4], ","), ",")
+	f.Add(" 04000000-0000-0000-0000-000000000000 ,,", ",")
+	f.Add("", "")
+
+	f.Fuzz(func(t *testing.T, input string, sep string) {
+		uuids, err := uuid.ParseList(input, sep)
+		if err != nil {
+			return
+		}
+
+		for _, myUUID := range uuids {
+			checkParsed(t, myUUID)
+		}
+	})
+}
+
+func FuzzScan(f *testing.F) {
+	var (
+		initial *uuid.UUID
+	)
+
+	setupScopes(f, fuzzScopes...)
+
+	initial = mustNew(f, "one")
+
+	f.Add([]byte{})
+	f.Add(make([]byte, 15))
+	f.Add(make([]byte, 16))
+	f.Add(make([]byte, 17))
+	f.Add([]byte(fuzzStrings[1]))
+	f.Add([]byte(fuzzStrings[7]))
+	f.Add([]byte{0xfc, 15: 0xff})
+	f.Add([]byte(fuzzStrings[3]))
+	f.Add([]byte(fuzzStrings[8]))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var (
+			bin [16]byte
+		)
+
+		myUUID := *initial
+
+		err := myUUID.Scan(data)
+
+		if len(data) == 36 {
+			//the string and the []byte of a hex-string are scanned alike, rejecting exactly the
+			//non-canonical strings with ErrBadString
+			fromString := *initial
+			if stringErr := fromString.Scan(string(data)); stringErr != err || fromString != myUUID {
+				t.Fatal("scanning the string results in ", stringErr, " but scanning the bytes in ", err)
+			}
+
+			if canonical.Match(data) == (err == uuid.ErrBadString) {
+				t.Fatal("unexpected error for ", string(data), ": ", err)
+			}
+		}
+
+		if err != nil {
+			//failed scans reset the UUID
+			if myUUID != (uuid.UUID{}) {
+				t.Fatal("failed scan didn't reset the UUID")
+			}
+			return
+		}
+
+		checkParsed(t, &myUUID)
+
+		bin = myUUID.Bin()
+		if len(data) == 36 && myUUID.Hex() != string(data) || len(data) == 32 && myUUID.CompactHex() != string(data) ||
+			len(data) == 16 && !bytes.Equal(bin[:], data) {
+			t.Fatal("scanned UUID doesn't match input")
+		}
+	})
+}
+
+func FuzzFromProtoBytes(f *testing.F) {
+	setupScopes(f, fuzzScopes...)
+
+	f.Add([]byte{})
+	f.Add(make([]byte, 15))
+	f.Add(make([]byte, 16))
+	f.Add([]byte{0xfc, 15: 0xff})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		myUUID, err := uuid.FromProtoBytes(data)
+		if err != nil || len(data) == 0 {
+			return
+		}
+
+		checkParsed(t, myUUID)
+
+		if !bytes.Equal(myUUID.ToProtoBytes(), data) {
+			t.Fatal("parsed UUID doesn't match input")
+		}
+	})
+}
+
+func FuzzDecoder(f *testing.F) {
+	setupScopes(f, fuzzScopes...)
+
+	f.Add([]byte{})
+	f.Add(make([]byte, 20))
+	f.Add(make([]byte, 32))
+	f.Add([]byte{0xfc, 15: 0xff, 16: 0x04})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		decoder := uuid.NewDecoder(bytes.NewReader(data))
+
+		for {
+			myUUID, err := decoder.Decode()
+			if err != nil {
+				return
+			}
+
+			checkParsed(t, myUUID)
+		}
+	})
+}
+
+func FuzzUnmarshalCBOR(f *testing.F) {
+	setupScopes(f, fuzzScopes...)
+
+	f.Add([]byte{})
+	f.Add([]byte{0xf6})
+	f.Add(append([]byte{0xd8, 0x25, 0x50}, make([]byte, 16)...))
+	f.Add(append([]byte{0x50}, make([]byte, 16)...))
+	f.Add(append([]byte{0x78, 0x24}, fuzzStrings[1]...))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var (
+			myUUID uuid.UUID
+		)
+
+		if myUUID.UnmarshalCBOR(data) != nil || myUUID == (uuid.UUID{}) {
+			return
+		}
+
+		checkParsed(t, &myUUID)
+	})
+}
@@ -0,0 +1,3 @@
+module github.com/4xoc/uuid
+
+go 1.24
@@ -0,0 +1,68 @@
+// Package gormuuid provides a UUID type for use with GORM.
+//
+// UUID embeds uuid.UUID and additionally tells GORM the column type to use for each dialect: uuid for
+// Postgres and char(36) for all others. Values are stored as canonical hex-string.
+//
+// Scoped UUIDs can't be generated by the database, so primary keys are generated on insert with a
+// BeforeCreate hook:
+//
+//	type User struct {
+//		ID   gormuuid.UUID `gorm:"primaryKey"`
+//		Name string
+//	}
+//
+//	func (user *User) BeforeCreate(tx *gorm.DB) error {
+//		return user.ID.GenerateIfZero("user")
+//	}
+//
+// The package lives in its own module so that the uuid package doesn't depend on GORM.
+package gormuuid
+
+import (
+	"github.com/4xoc/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// UUID is a uuid.UUID implementing the GORM data type interfaces.
+type UUID struct {
+	uuid.UUID
+}
+
+// GormDataType implements schema.GormDataTypeInterface.
+func (UUID) GormDataType() string {
+	return "uuid"
+}
+
+// GormDBDataType implements migrator.GormDataTypeInterface and returns the column type for the dialect
+// of db.
+func (UUID) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	switch db.Dialector.Name() {
+	case "postgres":
+		return "uuid"
+	default:
+		return "char(36)"
+	}
+}
+
+// GenerateIfZero sets the UUID to a new UUID of the given scope if it is the zero value. An existing
+// UUID is kept, including UUIDs of unknown scopes read with uuid.ReadAny.
+func (id *UUID) GenerateIfZero(scope string) error {
+	var (
+		tmpUUID *uuid.UUID
+		err     error
+	)
+
+	if id.UUID != (uuid.UUID{}) {
+		return nil
+	}
+
+	tmpUUID, err = uuid.New(scope)
+	if err != nil {
+		return err
+	}
+
+	id.UUID = *tmpUUID
+
+	return nil
+}
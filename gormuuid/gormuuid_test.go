@@ -0,0 +1,109 @@
+package gormuuid_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/4xoc/uuid"
+	"github.com/4xoc/uuid/gormuuid"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// user is a model with a UUID primary key.
+type user struct {
+	ID      gormuuid.UUID `gorm:"primaryKey"`
+	Name    string
+	OwnerID gormuuid.UUID
+}
+
+func (u *user) BeforeCreate(tx *gorm.DB) error {
+	return u.ID.GenerateIfZero("user")
+}
+
+func TestMain(m *testing.M) {
+	if err := uuid.SetScopes([64]string{"user", "org"}); err != nil {
+		panic(err)
+	}
+
+	os.Exit(m.Run())
+}
+
+func TestRoundTrip(t *testing.T) {
+	var (
+		db       *gorm.DB
+		created  user
+		loaded   user
+		owner    *uuid.UUID
+		explicit gormuuid.UUID
+		err      error
+	)
+
+	db, err = gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal("failed to open database: ", err)
+	}
+
+	if err = db.AutoMigrate(&user{}); err != nil {
+		t.Fatal("failed to migrate: ", err)
+	}
+
+	owner, _ = uuid.New("org")
+	created = user{Name: "alice", OwnerID: gormuuid.UUID{UUID: *owner}}
+
+	if err = db.Create(&created).Error; err != nil {
+		t.Fatal("failed to create record: ", err)
+	}
+
+	if created.ID.Scope() != "user" {
+		t.Fatal("primary key should have been generated but got ", created.ID.Hex())
+	}
+
+	if err = db.First(&loaded, "id = ?", created.ID).Error; err != nil {
+		t.Fatal("failed to load record: ", err)
+	}
+
+	if loaded.ID.Bin() != created.ID.Bin() || loaded.OwnerID.Bin() != owner.Bin() ||
+		loaded.OwnerID.Scope() != "org" || loaded.Name != "alice" {
+		t.Error("record doesn't round-trip: ", loaded.ID.Hex(), loaded.OwnerID.Hex())
+	}
+
+	//saving keeps the primary key
+	loaded.Name = "bob"
+
+	if err = db.Save(&loaded).Error; err != nil {
+		t.Fatal("failed to save record: ", err)
+	}
+
+	loaded = user{}
+
+	if err = db.First(&loaded, "id = ?", created.ID).Error; err != nil || loaded.Name != "bob" {
+		t.Error("failed to reload saved record: ", err)
+	}
+
+	//existing IDs are not replaced
+	if err = explicit.GenerateIfZero("org"); err != nil || explicit.Scope() != "org" {
+		t.Fatal("expected UUID to be generated but got ", err)
+	}
+
+	bin := explicit.Bin()
+
+	if err = explicit.GenerateIfZero("user"); err != nil || explicit.Bin() != bin {
+		t.Error("existing UUID must be kept")
+	}
+
+	foreign, err := uuid.ReadAny("fc000000-0000-0000-0000-0000000000fc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	explicit.UUID = *foreign
+
+	if err = explicit.GenerateIfZero("user"); err != nil || explicit.UUID != *foreign {
+		t.Error("UUID of an unknown scope must be kept")
+	}
+
+	if err = new(gormuuid.UUID).GenerateIfZero("unknown"); err != uuid.ErrMissingScope {
+		t.Error("expected ErrMissingScope but got ", err)
+	}
+}
@@ -0,0 +1,89 @@
+package uuid
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+var (
+	// generateHook holds the function registered with OnGenerate.
+	generateHook atomic.Pointer[func(scope string)]
+
+	// parseErrorHook holds the function registered with OnParseError.
+	parseErrorHook atomic.Pointer[func(input string, err error)]
+)
+
+// OnGenerate registers a function that is called with the scope of every UUID generated by New,
+// NewBatch and the other generating functions, e.g. to count generated UUIDs. The hook only gets the
+// scope and thus can't modify the UUID. A nil function removes the hook.
+//
+// The hook runs synchronously on the generating goroutine and must be safe for concurrent use. Panics
+// are not recovered and propagate to the caller of the generating function.
+func OnGenerate(hook func(scope string)) {
+	if hook == nil {
+		generateHook.Store(nil)
+		return
+	}
+
+	generateHook.Store(&hook)
+}
+
+// OnParseError registers a function that is called whenever Read or Scan fail, with the input (Scan
+// sources are formatted with %v, byte slices as hex) and the returned error. A nil function removes the
+// hook.
+//
+// The same rules as for OnGenerate apply.
+func OnParseError(hook func(input string, err error)) {
+	if hook == nil {
+		parseErrorHook.Store(nil)
+		return
+	}
+
+	parseErrorHook.Store(&hook)
+}
+
+// reportGenerate calls the generate hook n times if one is registered and updates the counters if they
+// are enabled.
+func reportGenerate(scope string, scopeByte byte, n int) {
+	var (
+		hook  *func(scope string)
+		index int
+	)
+
+	if countersEnabled.Load() {
+		counters[scopeIndex(scopeByte)].Add(uint64(n))
+	}
+
+	hook = generateHook.Load()
+	if hook == nil {
+		return
+	}
+
+	for index = 0; index < n; index++ {
+		(*hook)(scope)
+	}
+}
+
+// reportParseError calls the parse error hook if one is registered.
+func reportParseError(input interface{}, err error) {
+	var (
+		hook *func(input string, err error)
+		text string
+	)
+
+	hook = parseErrorHook.Load()
+	if hook == nil {
+		return
+	}
+
+	switch tmp := input.(type) {
+	case string:
+		text = tmp
+	case []byte:
+		text = fmt.Sprintf("%x", tmp)
+	default:
+		text = fmt.Sprintf("%v", tmp)
+	}
+
+	(*hook)(text, err)
+}
@@ -0,0 +1,105 @@
+package uuid_test
+
+import (
+	"github.com/4xoc/uuid"
+	"sync/atomic"
+	"testing"
+)
+
+func TestHooks(t *testing.T) {
+	var (
+		generated atomic.Int64
+		failed    []string
+		scanned   uuid.UUID
+	)
+
+	setupScopes(t, "one", "two")
+
+	uuid.OnGenerate(func(scope string) {
+		if scope == "two" {
+			generated.Add(1)
+		}
+	})
+	defer uuid.OnGenerate(nil)
+
+	uuid.OnParseError(func(input string, err error) {
+		failed = append(failed, input+": "+err.Error())
+	})
+	defer uuid.OnParseError(nil)
+
+	mustNew(t, "two")
+	mustNew(t, "one")
+	uuid.NewBatch("two", 10)
+	uuid.NewOrdered("two")
+
+	if generated.Load() != 12 {
+		t.Error("Expected 12 generated UUIDs but got ", generated.Load())
+	}
+
+	//failures are not reported
+	uuid.New("ten")
+
+	if generated.Load() != 12 {
+		t.Error("Expected 12 generated UUIDs but got ", generated.Load())
+	}
+
+	mustRead(t, fixture("04000000-0000-0000-0000-000000000000"))
+	uuid.Read("foo")
+	uuid.Read("fc000000-0000-0000-0000-0000000000fc")
+	scanned.Scan(42)
+	scanned.Scan([]byte{0xfc, 15: 0xfc})
+	scanned.Scan([]byte{0xfc, 0x00})
+
+	if len(failed) != 5 ||
+		failed[0] != "foo: "+uuid.ErrorBadString ||
+		failed[1] != "fc000000-0000-0000-0000-0000000000fc: "+uuid.ErrorBadScope ||
+		failed[2][:3] != "42:" ||
+		failed[3] != "fc0000000000000000000000000000fc: "+uuid.ErrorBadScope ||
+		failed[4] != "fc00: "+uuid.ErrorBadLength {
+		t.Error("unexpected parse errors ", failed)
+	}
+
+	//removed hooks are not called anymore
+	uuid.OnGenerate(nil)
+	uuid.OnParseError(nil)
+
+	mustNew(t, "two")
+	uuid.Read("foo")
+
+	if generated.Load() != 12 || len(failed) != 5 {
+		t.Error("removed hooks must not be called")
+	}
+}
+
+func BenchmarkNewWithoutHook(b *testing.B) {
+	setupScopes(b, "one")
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := uuid.New("one"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkNewWithHook(b *testing.B) {
+	var (
+		counter atomic.Int64
+	)
+
+	setupScopes(b, "one")
+
+	uuid.OnGenerate(func(string) {
+		counter.Add(1)
+	})
+	defer uuid.OnGenerate(nil)
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := uuid.New("one"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+// Package httpuuid extracts scoped UUIDs from HTTP requests. Failures are returned as *ParamError whose
+// Kind tells missing, malformatted, unknown and disallowed UUIDs apart, which StatusCode translates into
+// HTTP status codes:
+//
+//	func getUser(w http.ResponseWriter, r *http.Request) {
+//		id, err := httpuuid.PathUUID(r, "id", "user")
+//		if err != nil {
+//			http.Error(w, err.Error(), httpuuid.StatusCode(err))
+//			return
+//		}
+//		...
+//	}
+package httpuuid
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/4xoc/uuid"
+)
+
+var (
+	// ErrMissing is the kind of errors for parameters that are not set or empty.
+	ErrMissing = errors.New("the parameter is missing")
+	// ErrMalformed is the kind of errors for parameters that are not a UUID.
+	ErrMalformed = errors.New("the parameter is not a UUID")
+	// ErrUnknownScope is the kind of errors for UUIDs whose scope is not known.
+	ErrUnknownScope = errors.New("the scope of the UUID is not known")
+	// ErrScopeNotAllowed is the kind of errors for UUIDs whose scope is not one of the allowed scopes.
+	ErrScopeNotAllowed = errors.New("the scope of the UUID is not allowed")
+)
+
+// ParamError describes a parameter of a request that doesn't hold an acceptable UUID. errors.Is reports
+// it as its Kind as well as the underlying error of the uuid package.
+type ParamError struct {
+	// Param is the name of the path wildcard or query parameter.
+	Param string
+	// Input is the offending value; it is empty for ErrMissing.
+	Input string
+	// Kind is one of ErrMissing, ErrMalformed, ErrUnknownScope and ErrScopeNotAllowed.
+	Kind error
+	// Err is the error returned by the uuid package, nil for ErrMissing.
+	Err error
+}
+
+// Error returns the error message including the parameter and its value.
+func (err *ParamError) Error() string {
+	if err.Err == nil {
+		return "parameter " + strconv.Quote(err.Param) + ": " + err.Kind.Error()
+	}
+
+	return "parameter " + strconv.Quote(err.Param) + " (" + strconv.Quote(err.Input) + "): " + err.Err.Error()
+}
+
+// Unwrap returns the kind and the underlying error.
+func (err *ParamError) Unwrap() []error {
+	if err.Err == nil {
+		return []error{err.Kind}
+	}
+
+	return []error{err.Kind, err.Err}
+}
+
+// StatusCode returns the HTTP status code for an error returned by this package: 400 Bad Request for
+// missing and malformatted UUIDs, 404 Not Found for UUIDs of unknown scopes as nothing can be stored
+// under them, and 403 Forbidden for UUIDs of scopes that are not allowed. Other errors result in 500
+// Internal Server Error and nil in 200 OK.
+func StatusCode(err error) int {
+	switch {
+	case err == nil:
+		return http.StatusOK
+	case errors.Is(err, ErrMissing), errors.Is(err, ErrMalformed):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrUnknownScope):
+		return http.StatusNotFound
+	case errors.Is(err, ErrScopeNotAllowed):
+		return http.StatusForbidden
+	}
+
+	return http.StatusInternalServerError
+}
+
+// PathUUID reads the UUID from the path wildcard name (see http.Request.PathValue) and checks that its
+// scope is one of the allowed scopes. Without any allowed scopes, every known scope is accepted.
+func PathUUID(r *http.Request, name string, allowedScopes ...string) (*uuid.UUID, error) {
+	var (
+		value string
+	)
+
+	value = r.PathValue(name)
+	if value == "" {
+		return nil, &ParamError{Param: name, Kind: ErrMissing}
+	}
+
+	return parse(name, value, allowedScopes)
+}
+
+// QueryUUIDs reads all UUIDs of the query parameter key, which can be repeated and hold comma separated
+// lists ("?id=a,b&id=c"). Whitespace around the UUIDs is trimmed and empty items are skipped. Every UUID
+// must be of one of the allowed scopes; without any allowed scopes, every known scope is accepted.
+//
+// The first offending UUID is returned as *ParamError. If the parameter holds no UUIDs at all, the kind
+// of the error is ErrMissing.
+func QueryUUIDs(r *http.Request, key string, allowedScopes ...string) ([]*uuid.UUID, error) {
+	var (
+		uuids  []*uuid.UUID
+		myUUID *uuid.UUID
+		err    error
+	)
+
+	for _, value := range r.URL.Query()[key] {
+		for _, item := range strings.Split(value, ",") {
+			item = strings.TrimSpace(item)
+			if item == "" {
+				continue
+			}
+
+			myUUID, err = parse(key, item, allowedScopes)
+			if err != nil {
+				return nil, err
+			}
+
+			uuids = append(uuids, myUUID)
+		}
+	}
+
+	if len(uuids) == 0 {
+		return nil, &ParamError{Param: key, Kind: ErrMissing}
+	}
+
+	return uuids, nil
+}
+
+// parse reads a single UUID of the given parameter, classifying the error of the uuid package.
+func parse(param string, value string, allowedScopes []string) (*uuid.UUID, error) {
+	var (
+		myUUID *uuid.UUID
+		kind   error
+		err    error
+	)
+
+	myUUID, err = uuid.ValidateScoped(value, allowedScopes...)
+
+	switch {
+	case err == nil:
+		return myUUID, nil
+	case errors.Is(err, uuid.ErrScopeNotAllowed):
+		kind = ErrScopeNotAllowed
+	case errors.Is(err, uuid.ErrBadScope):
+		kind = ErrUnknownScope
+	default:
+		kind = ErrMalformed
+	}
+
+	return nil, &ParamError{Param: param, Input: value, Kind: kind, Err: err}
+}
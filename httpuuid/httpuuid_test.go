@@ -0,0 +1,110 @@
+package httpuuid_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/4xoc/uuid"
+	"github.com/4xoc/uuid/httpuuid"
+	"github.com/4xoc/uuid/uuidtest"
+)
+
+// newMux returns a handler responding with the UUIDs of the request or the error's status code.
+func newMux() *http.ServeMux {
+	var (
+		mux *http.ServeMux
+	)
+
+	mux = http.NewServeMux()
+
+	mux.HandleFunc("GET /users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id, err := httpuuid.PathUUID(r, "id", "user")
+		if err != nil {
+			http.Error(w, err.Error(), httpuuid.StatusCode(err))
+			return
+		}
+
+		w.Write([]byte(id.Hex()))
+	})
+
+	mux.HandleFunc("GET /users", func(w http.ResponseWriter, r *http.Request) {
+		ids, err := httpuuid.QueryUUIDs(r, "id", "user")
+		if err != nil {
+			http.Error(w, err.Error(), httpuuid.StatusCode(err))
+			return
+		}
+
+		w.Write([]byte(uuid.JoinHex(ids, " ")))
+	})
+
+	return mux
+}
+
+func TestHandlers(t *testing.T) {
+	var (
+		mux      *http.ServeMux
+		user     *uuid.UUID
+		other    *uuid.UUID
+		recorder *httptest.ResponseRecorder
+	)
+
+	uuidtest.Scopes(t, "user", "blob")
+
+	mux = newMux()
+	user = uuidtest.Static(t, "user", "alice")
+	other = uuidtest.Static(t, "user", "bob")
+
+	for _, test := range []struct {
+		path   string
+		status int
+		body   string
+	}{
+		{"/users/" + user.Hex(), http.StatusOK, user.Hex()},
+		{"/users/" + strings.ToUpper(user.Hex()), http.StatusBadRequest, "is not a UUID"},
+		{"/users/" + uuidtest.Static(t, "blob", "alice").Hex(), http.StatusForbidden, "not allowed"},
+		{"/users/fc000000-0000-0000-0000-000000000000", http.StatusNotFound, "not supported"},
+		{"/users?id=" + user.Hex(), http.StatusOK, user.Hex()},
+		{"/users?id=" + user.Hex() + ",%20" + other.Hex() + ",&id=" + user.Hex(), http.StatusOK,
+			user.Hex() + " " + other.Hex() + " " + user.Hex()},
+		{"/users", http.StatusBadRequest, "missing"},
+		{"/users?id=,", http.StatusBadRequest, "missing"},
+		{"/users?id=" + user.Hex() + ",nope", http.StatusBadRequest, `"nope"`},
+	} {
+		recorder = httptest.NewRecorder()
+		mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, test.path, nil))
+
+		if recorder.Code != test.status || !strings.Contains(recorder.Body.String(), test.body) {
+			t.Error("unexpected response for ", test.path, ": ", recorder.Code, " ", recorder.Body.String())
+		}
+	}
+}
+
+func TestParamError(t *testing.T) {
+	var (
+		paramErr *httpuuid.ParamError
+		request  *http.Request
+		err      error
+	)
+
+	uuidtest.Scopes(t, "user", "blob")
+
+	request = httptest.NewRequest(http.MethodGet, "/?id=fc000000-0000-0000-0000-000000000000", nil)
+
+	_, err = httpuuid.QueryUUIDs(request, "id")
+	if !errors.As(err, &paramErr) || paramErr.Param != "id" || paramErr.Kind != httpuuid.ErrUnknownScope ||
+		!errors.Is(err, httpuuid.ErrUnknownScope) || !errors.Is(err, uuid.ErrBadScope) {
+		t.Error("unexpected error ", err)
+	}
+
+	_, err = httpuuid.PathUUID(request, "id")
+	if !errors.Is(err, httpuuid.ErrMissing) || err.Error() != `parameter "id": the parameter is missing` {
+		t.Error("unexpected error ", err)
+	}
+
+	if httpuuid.StatusCode(nil) != http.StatusOK || httpuuid.StatusCode(errors.New("other")) != http.StatusInternalServerError {
+		t.Error("unexpected status codes for other errors")
+	}
+}
@@ -0,0 +1,75 @@
+package uuid
+
+import (
+	"errors"
+)
+
+// ScopeLayout defines which byte of a UUID holds its scope.
+type ScopeLayout int
+
+const (
+	// ScopeLeading stores the scope in the upper six bits of the first byte, the default. UUIDs of a
+	// scope share a contiguous range and thus sort by scope.
+	ScopeLeading ScopeLayout = iota
+	// ScopeTrailing stores the scope in the upper six bits of the last byte. UUIDs of all scopes are
+	// spread over the whole range, which avoids hot spots in B-tree indexes holding UUIDs of few busy
+	// scopes, but UUIDs of a scope do not share a range anymore (see ScopeBounds).
+	ScopeTrailing
+)
+
+// scopeLayout holds the layout set with SetScopeLayout.
+var scopeLayout ScopeLayout
+
+// SetScopeLayout sets the layout of all UUIDs. It must be called before SetScopes and defaults to
+// ScopeLeading.
+//
+// The layouts can't be told apart: a UUID is always interpreted by the configured layout, so reading a
+// UUID of the other layout results in ErrBadScope at best and in a UUID of some other scope at worst.
+// The layout therefore must never change once UUIDs have been stored.
+//
+// The documentation of this package describes the default layout. With ScopeTrailing, the scope and its
+// low two bits (see Variant) are stored in the last byte wherever the first byte is mentioned.
+func SetScopeLayout(layout ScopeLayout) error {
+	if layout != ScopeLeading && layout != ScopeTrailing {
+		return errors.New(ErrorBadScopeLayout)
+	}
+
+	if scopesSet() {
+		return errors.New(ErrorScopeLayoutSet)
+	}
+
+	scopeLayout = layout
+
+	return nil
+}
+
+// scopePosition returns the index of the byte holding the scope.
+func scopePosition() int {
+	if scopeLayout == ScopeTrailing {
+		return 15
+	}
+
+	return 0
+}
+
+// scopeBits returns the byte holding the scope with its low two bits cleared, i.e. the binary
+// representation of the scope.
+func (uuid *UUID) scopeBits() byte {
+	return uuid.bin[scopePosition()] & scopeMask()
+}
+
+// setScopeBits sets the binary representation of the scope, keeping the low two bits of the byte.
+func (uuid *UUID) setScopeBits(scopeByte byte) {
+	uuid.bin[scopePosition()] = scopeByte | uuid.bin[scopePosition()]&^scopeMask()
+}
+
+// lowBits returns the low two bits of the byte holding the scope, which are always 0 with 8 bit scopes.
+func (uuid *UUID) lowBits() byte {
+	return uuid.bin[scopePosition()] &^ scopeMask()
+}
+
+// setLowBits sets the low two bits of the byte holding the scope, keeping the scope. It must not be
+// used with 8 bit scopes.
+func (uuid *UUID) setLowBits(bits byte) {
+	uuid.bin[scopePosition()] = uuid.bin[scopePosition()]&scopeMask() | bits
+}
@@ -0,0 +1,153 @@
+package uuid_test
+
+import (
+	"encoding/binary"
+	"github.com/4xoc/uuid"
+	"os"
+	"os/exec"
+	"testing"
+)
+
+// testLayouts maps the values of UUID_TEST_LAYOUT to the scope layout installed by setupScopes.
+var testLayouts = map[string]uuid.ScopeLayout{
+	"leading":  uuid.ScopeLeading,
+	"trailing": uuid.ScopeTrailing,
+}
+
+// testLayout is the scope layout installed by setupScopes, defaulting to ScopeLeading.
+var testLayout = testLayouts[os.Getenv("UUID_TEST_LAYOUT")]
+
+// scopeIndex returns the index of the byte holding the scope in the layout under test.
+func scopeIndex() int {
+	if testLayout == uuid.ScopeTrailing {
+		return 15
+	}
+
+	return 0
+}
+
+// scopeByteOf returns the binary representation of the scope of the UUID in the layout under test.
+func scopeByteOf(myUUID *uuid.UUID) byte {
+	return myUUID.Bin()[scopeIndex()] &^ 0x03
+}
+
+// withoutScope returns the binary representation of the UUID with the byte holding the scope cleared.
+func withoutScope(myUUID *uuid.UUID) [16]byte {
+	var (
+		bin [16]byte
+	)
+
+	bin = myUUID.Bin()
+	bin[scopeIndex()] = 0
+
+	return bin
+}
+
+// fixture converts a canonical hex-string written for the leading layout to the layout under test by
+// swapping its first and last byte, so that the scope ends up in the byte holding it.
+func fixture(hex string) string {
+	if testLayout != uuid.ScopeTrailing || len(hex) != 36 {
+		return hex
+	}
+
+	return hex[34:] + hex[2:34] + hex[:2]
+}
+
+// fixtureBin works like fixture for the binary representation.
+func fixtureBin(bin [16]byte) [16]byte {
+	if testLayout == uuid.ScopeTrailing {
+		bin[0], bin[15] = bin[15], bin[0]
+	}
+
+	return bin
+}
+
+// TestLayouts runs all tests of the package in a new process for every scope layout.
+func TestLayouts(t *testing.T) {
+	if os.Getenv("UUID_TEST_LAYOUT") != "" {
+		t.Skip("already running with a fixed layout")
+	}
+
+	for name := range testLayouts {
+		t.Run(name, func(t *testing.T) {
+			var (
+				cmd    *exec.Cmd
+				output []byte
+				err    error
+			)
+
+			cmd = exec.Command(os.Args[0], "-test.count=1", "-test.run=^Test", "-test.short")
+			cmd.Env = append(os.Environ(), "UUID_TEST_LAYOUT="+name)
+
+			output, err = cmd.CombinedOutput()
+			if err != nil {
+				t.Fatalf("tests failed with layout %s: %s\n%s", name, err, output)
+			}
+		})
+	}
+}
+
+func TestScopeLayout(t *testing.T) {
+	var (
+		myUUID  *uuid.UUID
+		myCopy  *uuid.UUID
+		ordered *uuid.UUID
+		bin     [16]byte
+		err     error
+	)
+
+	uuid.ResetScopes()
+	t.Cleanup(uuid.ResetScopes)
+
+	if err = uuid.SetScopeLayout(uuid.ScopeLayout(2)); err == nil || err.Error() != uuid.ErrorBadScopeLayout {
+		t.Error("Expected ErrorBadScopeLayout but got ", err)
+	}
+
+	if err = uuid.SetScopeLayout(uuid.ScopeTrailing); err != nil {
+		t.Fatal(err)
+	}
+
+	uuid.SetScopes([64]string{"one", "two"})
+
+	if err = uuid.SetScopeLayout(uuid.ScopeLeading); err == nil || err.Error() != uuid.ErrorScopeLayoutSet {
+		t.Error("Expected ErrorScopeLayoutSet but got ", err)
+	}
+
+	myUUID = mustNew(t, "two")
+	bin = myUUID.Bin()
+
+	if bin[15]&^0x03 != 0x04 || myUUID.Variant() != bin[15]&0x03 {
+		t.Error("scope should be stored in the last byte ", myUUID.Hex())
+	}
+
+	myCopy = mustRead(t, "fc000000-0000-0000-0000-000000000004")
+	if myCopy.Scope() != "two" || myCopy.Validate() != nil {
+		t.Error("the first byte should not be interpreted ", myCopy.Scope())
+	}
+
+	//a UUID of scope "two" in the leading layout
+	if _, err = uuid.Read("04000000-0000-0000-0000-0000000000fc"); err != uuid.ErrBadScope {
+		t.Error("Expected ErrBadScope for UUID of the other layout but got ", err)
+	}
+
+	if myUUID.Payload()[15] != bin[15]&0x03 || myUUID.Payload()[0] != bin[0] {
+		t.Error("unexpected payload ", myUUID.Payload())
+	}
+
+	if myUUID.PayloadUint64() != binary.BigEndian.Uint64(bin[7:15]) {
+		t.Error("PayloadUint64 should contain neither scope bits nor the ordered timestamp")
+	}
+
+	ordered, err = uuid.NewOrdered("two")
+	if err != nil || ordered.Bin()[0] != 0 || ordered.Scope() != "two" || ordered.Bin()[15]&^0x03 != 0x04 {
+		t.Error("unexpected ordered UUID ", ordered.Hex(), " ", err)
+	}
+
+	if _, _, err = uuid.ScopeBounds("two"); err == nil || err.Error() != uuid.ErrorLayoutUnsupported {
+		t.Error("Expected ErrorLayoutUnsupported but got ", err)
+	}
+
+	if _, err = myUUID.Next(); err == nil || err.Error() != uuid.ErrorLayoutUnsupported {
+		t.Error("Expected ErrorLayoutUnsupported but got ", err)
+	}
+}
@@ -0,0 +1,156 @@
+package uuid
+
+import (
+	"errors"
+	"iter"
+	"strconv"
+	"strings"
+)
+
+// ParseError describes the failure of parsing one item of a list of UUIDs.
+type ParseError struct {
+	// Index is the position of the item within the list, not counting empty items. Decoder uses the
+	// number of the record, TextScanner the line number and ScanAll the number of the row instead.
+	Index int
+	// Input is the item that failed to parse.
+	Input string
+	// Err is the error returned by Read.
+	Err error
+}
+
+// Error returns the error message including the position and the input of the item.
+func (err *ParseError) Error() string {
+	return "item " + strconv.Itoa(err.Index) + " (" + strconv.Quote(err.Input) + "): " + err.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (err *ParseError) Unwrap() error {
+	return err.Err
+}
+
+// ParseList splits s at every sep and parses each item with Read. Whitespace around items is trimmed and
+// empty items (e.g. caused by a trailing separator) are skipped. If sep is empty, s is split at any
+// whitespace instead.
+//
+// Parsing stops at the first item that fails, returning a *ParseError.
+func ParseList(s string, sep string) ([]*UUID, error) {
+	var (
+		items []string
+		uuids []*UUID
+		uuid  *UUID
+		index int
+		err   error
+	)
+
+	if sep == "" {
+		items = strings.Fields(s)
+	} else {
+		items = strings.Split(s, sep)
+	}
+
+	uuids = make([]*UUID, 0, len(items))
+
+	for _, item := range items {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		uuid, err = Read(item)
+		if err != nil {
+			return nil, &ParseError{Index: index, Input: item, Err: err}
+		}
+
+		uuids = append(uuids, uuid)
+		index++
+	}
+
+	return uuids, nil
+}
+
+// JoinHex returns the canonical hex-strings of all UUIDs separated by sep. Nil and uninitialized UUIDs
+// are skipped.
+func JoinHex(uuids []*UUID, sep string) string {
+	var (
+		buf   []byte
+		first = true
+		index int
+	)
+
+	buf = make([]byte, 0, len(uuids)*(36+len(sep)))
+
+	for index = range uuids {
+		if uuids[index] == nil || uuids[index].scope == "" {
+			continue
+		}
+
+		if !first {
+			buf = append(buf, sep...)
+		}
+
+		buf = uuids[index].AppendHex(buf)
+		first = false
+	}
+
+	return string(buf)
+}
+
+// UnknownScope is the key CountByScope and CountByScopeStrings count UUIDs of unknown scopes with, e.g.
+// those read with ReadAny. Starting with a NUL byte, it can't be a scope name.
+const UnknownScope string = "\x00unknown"
+
+// CountByScope returns the number of UUIDs of each scope. Nil and uninitialized UUIDs are counted with
+// the empty string as scope, which can't be a scope name, and UUIDs of unknown scopes with UnknownScope.
+func CountByScope(uuids []*UUID) map[string]int {
+	var (
+		counts map[string]int
+		index  int
+	)
+
+	counts = make(map[string]int)
+
+	for index = range uuids {
+		if uuids[index] != nil && uuids[index].scope == unknownScope {
+			counts[UnknownScope]++
+			continue
+		}
+
+		counts[uuids[index].Scope()]++
+	}
+
+	return counts
+}
+
+// CountByScopeStrings parses every string of the sequence with Read and returns the number of UUIDs of
+// each scope. Well-formatted UUIDs of an unknown scope are counted with UnknownScope.
+//
+// Strings that are not UUIDs at all don't stop the analysis. They are returned as *ParseError, indexed
+// by their position in the sequence, joined into a single error (see errors.Join).
+func CountByScopeStrings(seq iter.Seq[string]) (map[string]int, error) {
+	var (
+		counts map[string]int
+		errs   []error
+		uuid   *UUID
+		index  int
+		err    error
+	)
+
+	counts = make(map[string]int)
+
+	for input := range seq {
+		uuid, err = Read(input)
+
+		switch {
+		case err == nil:
+			counts[uuid.scope]++
+		case err == ErrBadScope:
+			counts[UnknownScope]++
+		default:
+			errs = append(errs, &ParseError{Index: index, Input: input, Err: err})
+		}
+
+		index++
+	}
+
+	return counts, errors.Join(errs...)
+}
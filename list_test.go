@@ -0,0 +1,122 @@
+package uuid_test
+
+import (
+	"errors"
+	"github.com/4xoc/uuid"
+	"slices"
+	"testing"
+)
+
+func TestParseList(t *testing.T) {
+	var (
+		uuids      []*uuid.UUID
+		parsed     []*uuid.UUID
+		parseError *uuid.ParseError
+		joined     string
+		index      int
+		err        error
+	)
+
+	setupScopes(t, "one", "two")
+
+	uuids, _ = uuid.NewBatch("two", 3)
+
+	joined = uuid.JoinHex(append(uuids, nil), ",")
+	if joined != uuids[0].Hex()+","+uuids[1].Hex()+","+uuids[2].Hex() {
+		t.Fatal("unexpected joined string ", joined)
+	}
+
+	for _, input := range []string{
+		joined,
+		" " + uuids[0].Hex() + " ,\t" + uuids[1].Hex() + ",," + uuids[2].Hex() + ",",
+	} {
+		parsed, err = uuid.ParseList(input, ",")
+		if err != nil || len(parsed) != 3 {
+			t.Fatal("Expected 3 UUIDs but got ", len(parsed), err)
+		}
+
+		for index = range parsed {
+			if !uuid.Equal(parsed[index], uuids[index]) || parsed[index].Scope() != "two" {
+				t.Error("UUID ", index, " doesn't match")
+			}
+		}
+	}
+
+	//whitespace separated
+	parsed, err = uuid.ParseList(uuid.JoinHex(uuids, "\n ")+"\n", "")
+	if err != nil || len(parsed) != 3 {
+		t.Error("Expected 3 UUIDs but got ", len(parsed), err)
+	}
+
+	parsed, err = uuid.ParseList(" , ", ",")
+	if err != nil || len(parsed) != 0 {
+		t.Error("Expected no UUIDs")
+	}
+
+	//the bad item is identified
+	_, err = uuid.ParseList(uuids[0].Hex()+",,foo,"+uuids[1].Hex(), ",")
+	if !errors.As(err, &parseError) || parseError.Index != 1 || parseError.Input != "foo" || parseError.Err.Error() != uuid.ErrorBadString {
+		t.Error("unexpected error ", err)
+	}
+
+	_, err = uuid.ParseList("fc000000-0000-0000-0000-0000000000fc", ",")
+	if !errors.As(err, &parseError) || parseError.Index != 0 || parseError.Err.Error() != uuid.ErrorBadScope {
+		t.Error("unexpected error ", err)
+	}
+
+	if err.Error() != `item 0 ("fc000000-0000-0000-0000-0000000000fc"): `+uuid.ErrorBadScope {
+		t.Error("unexpected error message ", err.Error())
+	}
+}
+
+func TestCountByScope(t *testing.T) {
+	var (
+		counts   map[string]int
+		unknown  *uuid.UUID
+		parseErr *uuid.ParseError
+		err      error
+	)
+
+	setupScopes(t, "one", "two")
+
+	unknown, err = uuid.ReadAny("fc000000-0000-0000-0000-0000000000fc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//nil and uninitialized UUIDs are counted apart from those of unknown scopes
+	counts = uuid.CountByScope([]*uuid.UUID{mustNew(t, "one"), mustNew(t, "two"), mustNew(t, "two"), nil, {}, unknown})
+	if len(counts) != 4 || counts["one"] != 1 || counts["two"] != 2 || counts[""] != 2 || counts[uuid.UnknownScope] != 1 {
+		t.Error("unexpected counts ", counts)
+	}
+
+	if len(uuid.CountByScope(nil)) != 0 {
+		t.Error("empty slice should not count anything")
+	}
+
+	counts, err = uuid.CountByScopeStrings(slices.Values([]string{
+		"00000000-0000-0000-0000-000000000000",
+		"foo",
+		fixture("04000000-0000-0000-0000-000000000000"),
+		"fc000000-0000-0000-0000-0000000000fc",
+		fixture("04000000-0000-0000-0000-000000000001"),
+		"",
+	}))
+
+	if len(counts) != 3 || counts["one"] != 1 || counts["two"] != 2 || counts[uuid.UnknownScope] != 1 {
+		t.Error("unexpected counts ", counts)
+	}
+
+	if !errors.As(err, &parseErr) || parseErr.Index != 1 || parseErr.Input != "foo" || parseErr.Err != uuid.ErrBadString {
+		t.Fatal("unexpected error ", err)
+	}
+
+	if len(err.(interface{ Unwrap() []error }).Unwrap()) != 2 {
+		t.Error("Expected 2 parse errors but got ", err)
+	}
+
+	counts, err = uuid.CountByScopeStrings(slices.Values([]string{fixture("04000000-0000-0000-0000-000000000000")}))
+	if err != nil || counts["two"] != 1 {
+		t.Error("unexpected result ", counts, err)
+	}
+}
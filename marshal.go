@@ -0,0 +1,166 @@
+package uuid
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+)
+
+var (
+	_ encoding.TextMarshaler     = UUID{}
+	_ encoding.TextUnmarshaler   = (*UUID)(nil)
+	_ json.Marshaler             = UUID{}
+	_ json.Unmarshaler           = (*UUID)(nil)
+	_ encoding.BinaryMarshaler   = UUID{}
+	_ encoding.BinaryUnmarshaler = (*UUID)(nil)
+)
+
+// MarshalText implements encoding.TextMarshaler by returning the canonical hex-string. Uninitialized UUIDs
+// return ErrorUninitializedUUID.
+//
+// encoding/json uses it for map keys. TOML is only supported through MarshalText and UnmarshalText: the
+// package doesn't depend on a TOML library and isn't tested against one, it relies on encoders and
+// decoders like github.com/BurntSushi/toml using the text interfaces for values and map keys. As
+// flag.TextVar marshals the default value when the flag is defined, the default must be an initialized
+// UUID.
+func (uuid UUID) MarshalText() ([]byte, error) {
+	return uuid.AppendText(make([]byte, 0, 36))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler by parsing the text like Read. On failure, the UUID
+// is set to the zero value.
+func (uuid *UUID) UnmarshalText(text []byte) error {
+	var (
+		err error
+	)
+
+	*uuid, err = ReadValue(string(text))
+
+	return err
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler by returning the 16 bytes of the binary
+// representation, as used by encoding/gob. Uninitialized UUIDs return ErrorUninitializedUUID.
+func (uuid UUID) MarshalBinary() ([]byte, error) {
+	return uuid.AppendBinary(make([]byte, 0, 16))
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler by reading the 16 bytes of the binary
+// representation. Like Scan does for binary sources, it derives the scope from the scope bits, which must
+// belong to a known scope. Data of any other length returns ErrorBadLength. On failure, the UUID is set to
+// the zero value.
+func (uuid *UUID) UnmarshalBinary(data []byte) error {
+	var (
+		tmpUUID UUID
+		err     error
+	)
+
+	*uuid = UUID{}
+
+	if len(data) != 16 {
+		err = errors.New(ErrorBadLength)
+	} else {
+		copy(tmpUUID.bin[:], data)
+
+		err = tmpUUID.resolveScope()
+	}
+
+	if err != nil {
+		reportParseError(data, err)
+		return err
+	}
+
+	*uuid = tmpUUID
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler by returning the canonical hex-string as JSON string.
+// Uninitialized UUIDs are marshaled as null, like they are written as NULL by Value.
+//
+// Map keys are marshaled with MarshalText instead, which fails for uninitialized UUIDs.
+func (uuid UUID) MarshalJSON() ([]byte, error) {
+	var (
+		buf []byte
+	)
+
+	if uuid.scope == "" {
+		return []byte("null"), nil
+	}
+
+	buf = make([]byte, 0, 38)
+	buf = append(buf, '"')
+	buf = appendHex(buf, uuid.bin[:])
+
+	return append(buf, '"'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler by parsing a JSON string like Read, resolving the scope. null
+// results in the zero value, any other JSON value than a string returns ErrBadString. On failure, the
+// UUID is set to the zero value.
+func (uuid *UUID) UnmarshalJSON(data []byte) error {
+	var (
+		input string
+		err   error
+	)
+
+	*uuid = UUID{}
+
+	if string(data) == "null" {
+		return nil
+	}
+
+	if len(data) < 2 || data[0] != '"' || data[len(data)-1] != '"' {
+		reportParseError(string(data), ErrBadString)
+		return ErrBadString
+	}
+
+	//strings without escape sequences are read directly, the rest is left to encoding/json
+	input = string(data[1 : len(data)-1])
+	if bytes.IndexByte(data, '\\') >= 0 {
+		err = json.Unmarshal(data, &input)
+		if err != nil {
+			return err
+		}
+	}
+
+	*uuid, err = ReadValue(input)
+
+	return err
+}
+
+// MarshalYAML implements the yaml.Marshaler interface of gopkg.in/yaml.v2 and gopkg.in/yaml.v3 by
+// returning the canonical hex-string. Uninitialized UUIDs are marshaled as null, which only decodes back
+// to the zero value into a *UUID or a field that is zero already (see UnmarshalYAML).
+func (uuid UUID) MarshalYAML() (interface{}, error) {
+	if uuid.scope == "" {
+		return nil, nil
+	}
+
+	return formatHex(uuid.bin[:]), nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface of gopkg.in/yaml.v2, which gopkg.in/yaml.v3
+// supports as well. The scalar is parsed like Read, quoted or not; empty strings result in the zero value.
+// On failure, the UUID is set to the zero value as well.
+//
+// gopkg.in/yaml.v3 doesn't call UnmarshalYAML for null, ~ and missing values: a UUID is left unchanged and
+// null entries of a []UUID are dropped. Use *UUID where null is expected, which is set to nil.
+func (uuid *UUID) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var (
+		input string
+		err   error
+	)
+
+	*uuid = UUID{}
+
+	err = unmarshal(&input)
+	if err != nil || input == "" {
+		return err
+	}
+
+	*uuid, err = ReadValue(input)
+
+	return err
+}
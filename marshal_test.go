@@ -0,0 +1,274 @@
+package uuid_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/4xoc/uuid"
+	"io"
+	"testing"
+)
+
+func TestText(t *testing.T) {
+	var (
+		myUUID  *uuid.UUID
+		decoded uuid.UUID
+		text    []byte
+		err     error
+	)
+
+	setupScopes(t, "one", "two")
+
+	myUUID = mustNew(t, "two")
+
+	text, err = myUUID.MarshalText()
+	if err != nil || string(text) != myUUID.Hex() {
+		t.Error("unexpected text ", string(text), err)
+	}
+
+	_, err = uuid.UUID{}.MarshalText()
+	if err == nil || err.Error() != uuid.ErrorUninitializedUUID {
+		t.Error("Expected error for uninitialized UUID but got ", err)
+	}
+
+	if err = decoded.UnmarshalText(text); err != nil || decoded.Bin() != myUUID.Bin() || decoded.Scope() != "two" {
+		t.Error("UUID doesn't round-trip: ", err)
+	}
+
+	for _, input := range []string{"", "foo", "fc000000-0000-0000-0000-0000000000fc"} {
+		decoded = *myUUID
+
+		if err = decoded.UnmarshalText([]byte(input)); err == nil {
+			t.Error("Expected error for ", input)
+		}
+
+		if decoded != (uuid.UUID{}) {
+			t.Error("failed unmarshal didn't reset the UUID")
+		}
+	}
+
+	if err = decoded.UnmarshalText([]byte("fc000000-0000-0000-0000-0000000000fc")); err != uuid.ErrBadScope {
+		t.Error("Expected ErrBadScope but got ", err)
+	}
+}
+
+func TestTextInterfaces(t *testing.T) {
+	var (
+		myUUID   *uuid.UUID
+		fallback *uuid.UUID
+		flagged  uuid.UUID
+		flags    *flag.FlagSet
+		counts   map[uuid.UUID]int
+		data     []byte
+		err      error
+	)
+
+	setupScopes(t, "one", "two")
+
+	myUUID = mustNew(t, "two")
+	fallback = mustNew(t, "one")
+
+	//flags are parsed like Read
+	flags = flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	flags.TextVar(&flagged, "id", fallback, "")
+
+	if flagged != *fallback {
+		t.Error("default not set, got ", flagged.DebugString())
+	}
+
+	if err = flags.Parse([]string{"-id", myUUID.Hex()}); err != nil || flagged != *myUUID {
+		t.Error("unexpected flag value ", flagged.DebugString(), err)
+	}
+
+	if err = flags.Parse([]string{"-id", "fc000000-0000-0000-0000-0000000000fc"}); err == nil || flagged != (uuid.UUID{}) {
+		t.Error("expected unknown scope to fail but got ", flagged.DebugString(), err)
+	}
+
+	//encoding/json uses the text interfaces for map keys
+	data, err = json.Marshal(map[uuid.UUID]int{*myUUID: 1, *fallback: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = json.Unmarshal(data, &counts); err != nil || len(counts) != 2 || counts[*myUUID] != 1 || counts[*fallback] != 2 {
+		t.Error("map doesn't round-trip: ", string(data), err)
+	}
+
+	if _, err = json.Marshal(map[uuid.UUID]int{{}: 1}); err == nil {
+		t.Error("expected uninitialized map key to fail")
+	}
+
+	//the error of an invalid map key is returned as is, so its cause can still be told apart
+	for input, expected := range map[string]error{
+		`{"fc000000-0000-0000-0000-0000000000fc": 1}`: uuid.ErrBadScope,
+		`{"foo": 1}`: uuid.ErrBadString,
+	} {
+		counts = nil
+
+		if err = json.Unmarshal([]byte(input), &counts); !errors.Is(err, expected) {
+			t.Error("expected ", expected, " for map key of ", input, " but got ", err)
+		}
+	}
+}
+
+func TestBinary(t *testing.T) {
+	var (
+		myUUID  *uuid.UUID
+		decoded uuid.UUID
+		bin     [16]byte
+		data    []byte
+		buf     bytes.Buffer
+		err     error
+		record  struct {
+			ID     uuid.UUID
+			Parent *uuid.UUID
+			Owner  uuid.UUID
+		}
+	)
+
+	setupScopes(t, "one", "two")
+
+	myUUID = mustNew(t, "two")
+	bin = myUUID.Bin()
+
+	data, err = myUUID.MarshalBinary()
+	if err != nil || !bytes.Equal(data, bin[:]) {
+		t.Error("unexpected binary ", data, err)
+	}
+
+	if _, err = (uuid.UUID{}).MarshalBinary(); err == nil || err.Error() != uuid.ErrorUninitializedUUID {
+		t.Error("Expected error for uninitialized UUID but got ", err)
+	}
+
+	if err = decoded.UnmarshalBinary(data); err != nil || decoded != *myUUID || decoded.Scope() != "two" {
+		t.Error("UUID doesn't round-trip: ", decoded.DebugString(), err)
+	}
+
+	//uninitialized fields are skipped by gob
+	record.ID = *myUUID
+	record.Parent = myUUID
+
+	if err = gob.NewEncoder(&buf).Encode(record); err != nil {
+		t.Fatal(err)
+	}
+
+	record.ID = uuid.UUID{}
+	record.Parent = nil
+	record.Owner = *myUUID
+
+	if err = gob.NewDecoder(&buf).Decode(&record); err != nil || record.ID != *myUUID || record.Parent == nil ||
+		*record.Parent != *myUUID || record.Owner != *myUUID {
+		t.Error("gob doesn't round-trip: ", record, err)
+	}
+
+	testCases := []struct {
+		data []byte
+		err  string
+	}{
+		{nil, uuid.ErrorBadLength},
+		{bin[:15], uuid.ErrorBadLength},
+		{append(bin[:], 0), uuid.ErrorBadLength},
+		{[]byte{0xfc, 15: 0xfc}, uuid.ErrorBadScope},
+		{[]byte(myUUID.Hex()), uuid.ErrorBadLength},
+	}
+
+	for _, testCase := range testCases {
+		decoded = *myUUID
+
+		if err = decoded.UnmarshalBinary(testCase.data); err == nil || err.Error() != testCase.err || decoded != (uuid.UUID{}) {
+			t.Error("unexpected result for ", testCase.data, ": ", decoded.DebugString(), err)
+		}
+	}
+}
+
+func TestJSON(t *testing.T) {
+	var (
+		myUUID  *uuid.UUID
+		decoded uuid.UUID
+		data    []byte
+		err     error
+		record  struct {
+			ID     uuid.UUID
+			Parent *uuid.UUID
+			Owner  uuid.UUID
+		}
+	)
+
+	setupScopes(t, "one", "two")
+
+	myUUID = mustNew(t, "two")
+
+	record.ID = *myUUID
+	record.Parent = myUUID
+
+	data, err = json.Marshal(record)
+	if err != nil || string(data) != `{"ID":"`+myUUID.Hex()+`","Parent":"`+myUUID.Hex()+`","Owner":null}` {
+		t.Fatal("unexpected JSON ", string(data), err)
+	}
+
+	record.ID = uuid.UUID{}
+	record.Parent = nil
+	record.Owner = *myUUID
+
+	//null resets the UUID like SQL NULL does with Scan
+	if err = json.Unmarshal(data, &record); err != nil || record.ID != *myUUID || record.ID.Scope() != "two" ||
+		record.Parent == nil || *record.Parent != *myUUID || record.Owner != (uuid.UUID{}) {
+		t.Error("UUIDs don't round-trip: ", record, err)
+	}
+
+	//escape sequences are decoded before parsing
+	data = fmt.Appendf(nil, `"\u%04x%s"`, myUUID.Hex()[0], myUUID.Hex()[1:])
+	if err = json.Unmarshal(data, &decoded); err != nil || decoded != *myUUID {
+		t.Error("escaped string results in ", decoded.DebugString(), err)
+	}
+
+	testCases := []struct {
+		data string
+		err  error
+	}{
+		{`"fc000000-0000-0000-0000-0000000000fc"`, uuid.ErrBadScope},
+		{`"foo"`, uuid.ErrBadString},
+		{`""`, uuid.ErrBadString},
+		{`"`, uuid.ErrBadString},
+		{`42`, uuid.ErrBadString},
+		{`["` + myUUID.Hex() + `"]`, uuid.ErrBadString},
+	}
+
+	for _, testCase := range testCases {
+		decoded = *myUUID
+
+		if err = decoded.UnmarshalJSON([]byte(testCase.data)); err != testCase.err || decoded != (uuid.UUID{}) {
+			t.Error("unexpected result for ", testCase.data, ": ", decoded.DebugString(), err)
+		}
+	}
+}
+
+func ExampleUUID_UnmarshalText() {
+	var (
+		config struct {
+			Admins []struct {
+				Name string
+				ID   uuid.UUID
+			}
+			Quotas map[uuid.UUID]int
+		}
+	)
+
+	uuid.ResetScopes()
+	defer uuid.ResetScopes()
+
+	uuid.SetScopes([64]string{"user"})
+
+	//encoding/json uses the text interfaces for map keys only, TOML decoders for values as well
+	err := json.Unmarshal([]byte(`{
+		"Admins": [{"Name": "alice", "ID": "0129a1d0-84f3-4d8d-b6cc-682d1ca34dae"}],
+		"Quotas": {"0129a1d0-84f3-4d8d-b6cc-682d1ca34dae": 10}
+	}`), &config)
+
+	fmt.Println(err, config.Admins[0].ID.Scope(), config.Quotas[config.Admins[0].ID])
+	// Output: <nil> user 10
+}
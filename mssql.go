@@ -0,0 +1,102 @@
+package uuid
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+)
+
+var (
+	_ sql.Scanner   = (*MSSQLUUID)(nil)
+	_ driver.Valuer = MSSQLUUID{}
+)
+
+// swapMSSQL converts between the binary representation of a UUID and the byte order SQL Server uses for
+// uniqueidentifier values, which stores the first three groups little endian. The conversion is its own
+// inverse.
+func swapMSSQL(dst []byte, src []byte) {
+	dst[0], dst[1], dst[2], dst[3] = src[3], src[2], src[1], src[0]
+	dst[4], dst[5] = src[5], src[4]
+	dst[6], dst[7] = src[7], src[6]
+
+	copy(dst[8:16], src[8:16])
+}
+
+// FromMSSQLBytes builds a UUID from the 16 bytes of a SQL Server uniqueidentifier as returned by its
+// drivers, reversing the byte order of the first three groups. Any other length returns ErrorBadLength;
+// if the resulting scope isn't known, ErrorBadScope is returned.
+//
+// Reading such bytes with Scan or FromRFC silently results in another UUID (and most likely another
+// scope), see MSSQLUUID.
+func FromMSSQLBytes(b []byte) (*UUID, error) {
+	var (
+		tmpBin [16]byte
+	)
+
+	if len(b) != 16 {
+		return nil, errors.New(ErrorBadLength)
+	}
+
+	swapMSSQL(tmpBin[:], b)
+
+	return FromRFC(tmpBin)
+}
+
+// ToMSSQLBytes returns the UUID as a new 16 bytes long slice in the byte order SQL Server uses for
+// uniqueidentifier values. It can be read with FromMSSQLBytes.
+//
+// If the UUID is not initialized, nil is returned.
+func (uuid *UUID) ToMSSQLBytes() []byte {
+	var (
+		tmpBytes []byte
+	)
+
+	if uuid == nil || uuid.scope == "" {
+		return nil
+	}
+
+	tmpBytes = make([]byte, 16)
+	swapMSSQL(tmpBytes, uuid.bin[:])
+
+	return tmpBytes
+}
+
+// MSSQLUUID wraps a UUID for uniqueidentifier columns of SQL Server. Its Scan reads 16 byte sources with
+// FromMSSQLBytes and accepts everything else like the Scan function of UUID. Value passes the canonical
+// hex-string, which SQL Server converts to uniqueidentifier regardless of its byte order.
+//
+//	var id uuid.MSSQLUUID
+//	err := db.QueryRow("SELECT id FROM users WHERE name = @p1", name).Scan(&id)
+//	fmt.Println(id.Hex())
+type MSSQLUUID struct {
+	UUID
+}
+
+// Scan implements the database/sql Scanner interface, swapping the byte order of 16 byte sources. Like
+// the Scan function of UUID, it sets the UUID to the zero value on failure.
+func (uuid *MSSQLUUID) Scan(src interface{}) error {
+	var (
+		tmpUUID *UUID
+		err     error
+	)
+
+	if tmp, ok := src.([]byte); ok && len(tmp) == 16 {
+		tmpUUID, err = FromMSSQLBytes(tmp)
+		if err != nil {
+			uuid.UUID = UUID{}
+			reportParseError(src, err)
+			return err
+		}
+
+		uuid.UUID = *tmpUUID
+
+		return nil
+	}
+
+	return uuid.UUID.Scan(src)
+}
+
+// Value implements the database/sql/driver Valuer interface, see the Value function of UUID.
+func (uuid MSSQLUUID) Value() (driver.Value, error) {
+	return uuid.UUID.Value()
+}
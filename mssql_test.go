@@ -0,0 +1,94 @@
+package uuid_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"github.com/4xoc/uuid"
+	"testing"
+)
+
+// mssqlFixtures maps canonical hex-strings to the bytes SQL Server stores for them, e.g. as returned by
+// CONVERT(binary(16), CAST('6f9619ff-8b86-d011-b42d-00c04fc964ff' AS uniqueidentifier)).
+var mssqlFixtures = map[string]string{
+	"6f9619ff-8b86-d011-b42d-00c04fc964ff": "ff19966f868b11d0b42d00c04fc964ff",
+	"01234567-89ab-cdef-0123-456789abcdef": "67452301ab89efcd0123456789abcdef",
+	"04112233-4455-6677-8899-aabbccddeeff": "33221104554477668899aabbccddeeff",
+}
+
+// setupMSSQLScopes sets up scopes covering the first bytes of mssqlFixtures.
+func setupMSSQLScopes(t *testing.T) {
+	uuid.ResetScopes()
+	t.Cleanup(uuid.ResetScopes)
+
+	uuid.SetScopes([64]string{0: "zero", 1: "one", 27: "legacy"})
+}
+
+func TestMSSQLBytes(t *testing.T) {
+	var (
+		myUUID *uuid.UUID
+		myCopy *uuid.UUID
+		stored []byte
+		err    error
+	)
+
+	setupMSSQLScopes(t)
+
+	for canonical, fixture := range mssqlFixtures {
+		stored, _ = hex.DecodeString(fixture)
+		myUUID = mustRead(t, canonical)
+
+		if !bytes.Equal(myUUID.ToMSSQLBytes(), stored) {
+			t.Error("unexpected bytes ", hex.EncodeToString(myUUID.ToMSSQLBytes()), " for ", canonical)
+		}
+
+		myCopy, err = uuid.FromMSSQLBytes(stored)
+		if err != nil || myCopy.Hex() != canonical || myCopy.Scope() != myUUID.Scope() {
+			t.Error("unexpected UUID ", myCopy.Hex(), " for ", fixture, ": ", err)
+		}
+	}
+
+	if (*uuid.UUID)(nil).ToMSSQLBytes() != nil || new(uuid.UUID).ToMSSQLBytes() != nil {
+		t.Error("uninitialized UUIDs should return nil")
+	}
+
+	if _, err = uuid.FromMSSQLBytes(make([]byte, 15)); err == nil || err.Error() != uuid.ErrorBadLength {
+		t.Error("Expected ErrorBadLength but got ", err)
+	}
+
+	//first byte 0xff after swapping
+	if _, err = uuid.FromMSSQLBytes(bytes.Repeat([]byte{0xff}, 16)); err != uuid.ErrBadScope {
+		t.Error("Expected ErrBadScope but got ", err)
+	}
+}
+
+func TestMSSQLUUID(t *testing.T) {
+	var (
+		myUUID uuid.MSSQLUUID
+		stored []byte
+		err    error
+	)
+
+	setupMSSQLScopes(t)
+
+	stored, _ = hex.DecodeString(mssqlFixtures["6f9619ff-8b86-d011-b42d-00c04fc964ff"])
+
+	err = myUUID.Scan(stored)
+	if err != nil || myUUID.Hex() != "6f9619ff-8b86-d011-b42d-00c04fc964ff" || myUUID.Scope() != "legacy" {
+		t.Fatal("unexpected UUID ", myUUID.Hex(), ": ", err)
+	}
+
+	value, err := myUUID.Value()
+	if err != nil || value != "6f9619ff-8b86-d011-b42d-00c04fc964ff" {
+		t.Error("unexpected value ", value, " ", err)
+	}
+
+	err = myUUID.Scan("01234567-89ab-cdef-0123-456789abcdef")
+	if err != nil || myUUID.Scope() != "zero" {
+		t.Error("strings should be read like Scan of UUID: ", err)
+	}
+
+	err = myUUID.Scan(bytes.Repeat([]byte{0xff}, 16))
+	if err != uuid.ErrBadScope || myUUID.UUID != (uuid.UUID{}) {
+		t.Error("failed Scan must result in the zero value: ", err)
+	}
+}
@@ -0,0 +1,72 @@
+package uuid
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// nodeVariant is the variant (low two bits of the first byte) of UUIDs generated by NewWithNode.
+const nodeVariant byte = 0x01
+
+var (
+	// nodeID holds the node identifier embedded by NewWithNode.
+	nodeID atomic.Pointer[uint16]
+)
+
+// SetNodeID sets the identifier of this node which NewWithNode embeds into UUIDs. The node ID can only be
+// set once.
+func SetNodeID(id uint16) error {
+	if !nodeID.CompareAndSwap(nil, &id) {
+		return errors.New(ErrorNodeIDSet)
+	}
+
+	return nil
+}
+
+// NewWithNode generates a new UUID of the given scope embedding the node ID set with SetNodeID, so that
+// the node which generated a UUID can be traced.
+//
+// The first byte holds the scope with the variant set to 1, bytes 1-2 contain the node ID (big endian)
+// and bytes 3-15 random data. UUIDs generated by NewWithNode on different nodes can't collide with each
+// other. The variant isn't reserved though: New, NewWithVariant(scope, 1) and sub-scopes generate UUIDs
+// of variant 1 as well, which only differ from those of NewWithNode in random bits. Collisions with them
+// are as unlikely as those of random UUIDs, but NodeID can't tell them apart. If no node ID is set,
+// ErrorNoNodeID is returned.
+func NewWithNode(scope string) (*UUID, error) {
+	var (
+		uuid *UUID
+		id   *uint16
+		err  error
+	)
+
+	id = nodeID.Load()
+	if id == nil {
+		return nil, errors.New(ErrorNoNodeID)
+	}
+
+	if !hasLowBits() {
+		return nil, errors.New(ErrorLayoutUnsupported)
+	}
+
+	uuid, err = New(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	uuid.setLowBits(nodeVariant)
+	uuid.bin[1] = byte(*id >> 8)
+	uuid.bin[2] = byte(*id)
+
+	return uuid, nil
+}
+
+// NodeID returns the node ID embedded by NewWithNode. The second return value is false if the variant of
+// the UUID is not the one set by NewWithNode. As UUIDs generated by New carry a random variant, the node
+// ID is only meaningful for UUIDs known to be generated by NewWithNode.
+func (uuid *UUID) NodeID() (uint16, bool) {
+	if uuid == nil || uuid.scope == "" || uuid.lowBits() != nodeVariant {
+		return 0, false
+	}
+
+	return uint16(uuid.bin[1])<<8 | uint16(uuid.bin[2]), true
+}
@@ -0,0 +1,59 @@
+package uuid_test
+
+import (
+	"github.com/4xoc/uuid"
+	"testing"
+)
+
+func TestNodeID(t *testing.T) {
+	var (
+		myUUID *uuid.UUID
+		id     uint16
+		ok     bool
+		err    error
+	)
+
+	setupScopes(t, "one", "two")
+
+	if _, err = uuid.NewWithNode("two"); err == nil || err.Error() != uuid.ErrorNoNodeID {
+		t.Error("Expected error when no node ID is set but got ", err)
+	}
+
+	if err = uuid.SetNodeID(0x1234); err != nil {
+		t.Fatal("Expected node ID to be set but failed with error ", err.Error())
+	}
+
+	if err = uuid.SetNodeID(0x4321); err == nil || err.Error() != uuid.ErrorNodeIDSet {
+		t.Error("Expected error when setting the node ID twice but got ", err)
+	}
+
+	if _, err = uuid.NewWithNode("ten"); err != uuid.ErrMissingScope {
+		t.Error("Expected ErrMissingScope but got ", err)
+	}
+
+	for i := 0; i < 100; i++ {
+		myUUID, err = uuid.NewWithNode("two")
+		if err != nil {
+			t.Fatal("Expected UUID to be generated but failed with error ", err.Error())
+		}
+
+		if myUUID.Scope() != "two" || myUUID.Variant() != 1 || myUUID.Hex()[2:6] != "1234" {
+			t.Fatal("unexpected UUID ", myUUID.Hex())
+		}
+
+		//the node ID survives parsing
+		id, ok = mustRead(t, myUUID.Hex()).NodeID()
+		if !ok || id != 0x1234 {
+			t.Fatal("unexpected node ID ", id, ok)
+		}
+	}
+
+	myUUID, _ = uuid.NewWithVariant("two", 2)
+	if _, ok = myUUID.NodeID(); ok {
+		t.Error("UUIDs of other variants must not report a node ID")
+	}
+
+	if _, ok = (*uuid.UUID)(nil).NodeID(); ok {
+		t.Error("nil UUID must not report a node ID")
+	}
+}
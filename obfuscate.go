@@ -0,0 +1,87 @@
+package uuid
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+)
+
+// checkSize is the number of bytes of the HMAC-SHA256 appended to obfuscated UUIDs.
+const checkSize int = 8
+
+// obfuscationCheck calculates the check value of an encrypted UUID with the given key.
+func obfuscationCheck(encrypted []byte, key []byte) []byte {
+	var (
+		mac = hmac.New(sha256.New, key)
+	)
+
+	//keeps the check value apart from the signatures of Sign
+	mac.Write([]byte("obfuscate"))
+	mac.Write(encrypted)
+
+	return mac.Sum(nil)[:checkSize]
+}
+
+// Obfuscate encrypts the binary representation of the UUID with AES using the given key (16, 24 or 32
+// bytes), appends the first 8 bytes of a HMAC-SHA256 over the encrypted block as check value and returns
+// it base64url encoded, i.e. as 32 characters. The result doesn't reveal the scope of the UUID. Since the
+// UUID is exactly one AES block, it is encrypted directly, so the same UUID always results in the same
+// token for the same key. See Deobfuscate.
+func (uuid *UUID) Obfuscate(key []byte) (string, error) {
+	var (
+		block     cipher.Block
+		encrypted []byte
+		err       error
+	)
+
+	if uuid == nil || uuid.scope == "" {
+		return "", errors.New(ErrorUninitializedUUID)
+	}
+
+	block, err = aes.NewCipher(key)
+	if err != nil {
+		return "", errors.New("Error creating cipher: " + err.Error())
+	}
+
+	encrypted = make([]byte, 16, 16+checkSize)
+	block.Encrypt(encrypted, uuid.bin[:])
+
+	return base64.RawURLEncoding.EncodeToString(append(encrypted, obfuscationCheck(encrypted, key)...)), nil
+}
+
+// Deobfuscate verifies the check value of a token as returned by Obfuscate, decrypts it and resolves the
+// scope of the recovered UUID.
+//
+// Malformatted tokens return ErrBadString. Tokens whose check value doesn't match the key, e.g. because
+// they were obfuscated with another key or modified, return ErrInvalidSignature; the check value is
+// verified in constant time before decrypting. With 8 bytes, it detects wrong keys reliably but is
+// shorter than the signature of Sign, which should be used where authenticity matters.
+func Deobfuscate(input string, key []byte) (*UUID, error) {
+	var (
+		tmpBytes  []byte
+		block     cipher.Block
+		decrypted [16]byte
+		err       error
+	)
+
+	tmpBytes, err = base64.RawURLEncoding.DecodeString(input)
+	if err != nil || len(tmpBytes) != 16+checkSize {
+		return nil, ErrBadString
+	}
+
+	block, err = aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.New("Error creating cipher: " + err.Error())
+	}
+
+	if !hmac.Equal(tmpBytes[16:], obfuscationCheck(tmpBytes[:16], key)) {
+		return nil, ErrInvalidSignature
+	}
+
+	block.Decrypt(decrypted[:], tmpBytes[:16])
+
+	return FromRFC(decrypted)
+}
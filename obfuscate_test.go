@@ -0,0 +1,77 @@
+package uuid_test
+
+import (
+	"github.com/4xoc/uuid"
+	"testing"
+)
+
+func TestObfuscate(t *testing.T) {
+	var (
+		myUUID *uuid.UUID
+		nilPtr *uuid.UUID
+		myCopy *uuid.UUID
+		token  string
+		key    = []byte("0123456789abcdef")
+		other  = []byte("fedcba9876543210")
+		index  int
+		err    error
+	)
+
+	setupScopes(t, "one", "two")
+
+	_, err = nilPtr.Obfuscate(key)
+	if err == nil || err.Error() != uuid.ErrorUninitializedUUID {
+		t.Error("Expected error for nil UUID")
+	}
+
+	myUUID, _ = uuid.New("two")
+
+	_, err = myUUID.Obfuscate([]byte("short"))
+	if err == nil {
+		t.Error("Expected error for bad key size")
+	}
+
+	token, err = myUUID.Obfuscate(key)
+	if err != nil || len(token) != 32 {
+		t.Fatal("unexpected token ", token)
+	}
+
+	//deterministic per key
+	if tmp, _ := myUUID.Obfuscate(key); tmp != token {
+		t.Error("token should be the same for the same key")
+	}
+
+	myCopy, err = uuid.Deobfuscate(token, key)
+	if err != nil || myCopy.Hex() != myUUID.Hex() || myCopy.Scope() != "two" {
+		t.Fatal("token should round-trip")
+	}
+
+	for _, input := range []string{"", token[:22], token[:31], token + "A", "!" + token[1:]} {
+		_, err = uuid.Deobfuscate(input, key)
+		if err == nil || err.Error() != uuid.ErrorBadString {
+			t.Error("Expected error for malformatted token ", input)
+		}
+	}
+
+	//a wrong key or a modified token fails even if the decrypted scope bits match a scope
+	for index = 0; index < 100; index++ {
+		token, _ = mustNew(t, "one").Obfuscate(key)
+
+		if _, err = uuid.Deobfuscate(token, other); err != uuid.ErrInvalidSignature {
+			t.Fatal("Expected ErrInvalidSignature for wrong key but got ", err)
+		}
+	}
+
+	tampered := []byte(token)
+	if tampered[5] = 'A'; token[5] == 'A' {
+		tampered[5] = 'B'
+	}
+
+	if _, err = uuid.Deobfuscate(string(tampered), key); err != uuid.ErrInvalidSignature {
+		t.Error("Expected ErrInvalidSignature for modified token but got ", err)
+	}
+
+	if myCopy, err = uuid.Deobfuscate(token, key); err != nil || myCopy.Scope() != "one" {
+		t.Error("token should round-trip: ", err)
+	}
+}
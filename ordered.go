@@ -0,0 +1,97 @@
+package uuid
+
+import (
+	"sync"
+	"time"
+)
+
+var (
+	// nowMillis returns the current unix time in milliseconds.
+	nowMillis = func() int64 {
+		return time.Now().UnixMilli()
+	}
+
+	// ordered holds the state needed to keep ordered UUIDs monotonic.
+	ordered struct {
+		mu sync.Mutex
+		// millis is the timestamp of the last ordered UUID.
+		millis int64
+		// counter is the counter value of the last ordered UUID.
+		counter uint16
+	}
+)
+
+// NewOrdered generates a new UUID of the given scope which sorts by its time of creation.
+//
+// The first byte holds the scope with both low bits set to 0, bytes 1-6 contain the unix time in
+// milliseconds (big endian), bytes 7-8 a counter and bytes 9-15 random data. With ScopeTrailing the first
+// byte is 0 and the scope is stored in the last byte instead, replacing 6 of the random bits. Within this
+// process all ordered UUIDs of a scope compare in the order they have been generated, even if generated
+// within the same millisecond:
+//
+//   - the counter increments for every UUID within the same millisecond and resets to 0 once the clock
+//     advances.
+//   - if the counter overflows, the timestamp is advanced by one millisecond instead of waiting for
+//     the clock to do so.
+//   - if the clock goes backwards, the last timestamp is kept and the counter continues to increment
+//     until the clock has caught up.
+//
+// Ordered UUIDs only carry 56 random bits and thus must not be used where UUIDs need to be hard to
+// guess.
+func NewOrdered(scope string) (*UUID, error) {
+	var (
+		uuid      UUID
+		millis    int64
+		counter   uint16
+		index     int
+		name      string
+		scopeByte byte
+		err       error
+	)
+
+	name, scopeByte, err = loadScopes().resolveNew(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	err = readEntropy(uuid.bin[9:])
+	if err != nil {
+		return nil, err
+	}
+
+	ordered.mu.Lock()
+
+	millis = nowMillis()
+
+	switch {
+	case millis > ordered.millis:
+		ordered.counter = 0
+		ordered.millis = millis
+	case ordered.counter == 0xffff:
+		//counter overflow; borrowing the next millisecond
+		ordered.counter = 0
+		ordered.millis++
+	default:
+		ordered.counter++
+	}
+
+	millis = ordered.millis
+	counter = ordered.counter
+
+	ordered.mu.Unlock()
+
+	uuid.setScopeBits(scopeByte)
+
+	for index = 0; index < 6; index++ {
+		uuid.bin[1+index] = byte(millis >> (8 * (5 - index)))
+	}
+
+	uuid.bin[7] = byte(counter >> 8)
+	uuid.bin[8] = byte(counter)
+
+	uuid.scope = name
+
+	reportGenerate(uuid.scope, scopeByte, 1)
+
+	return &uuid, nil
+}
@@ -0,0 +1,109 @@
+package uuid_test
+
+import (
+	"bytes"
+	"github.com/4xoc/uuid"
+	"testing"
+)
+
+// isOrdered checks that the binary form of all UUIDs is strictly increasing.
+func isOrdered(t *testing.T, uuids []*uuid.UUID) {
+	var (
+		prev  [16]byte
+		next  [16]byte
+		index int
+	)
+
+	t.Helper()
+
+	for index = 1; index < len(uuids); index++ {
+		prev = uuids[index-1].Bin()
+		next = uuids[index].Bin()
+
+		if bytes.Compare(prev[:], next[:]) >= 0 {
+			t.Fatal("UUID ", index, " (", uuids[index].Hex(), ") doesn't sort after ", uuids[index-1].Hex())
+		}
+	}
+}
+
+func TestNewOrdered(t *testing.T) {
+	var (
+		uuids []*uuid.UUID
+		index int
+		err   error
+	)
+
+	setupScopes(t, "one", "two")
+
+	_, err = uuid.NewOrdered("ten")
+	if err == nil || err.Error() != uuid.ErrorMissingScope {
+		t.Error("Expected error for unknown scope")
+	}
+
+	uuids = make([]*uuid.UUID, 100000)
+
+	for index = range uuids {
+		uuids[index], err = uuid.NewOrdered("two")
+		if err != nil {
+			t.Fatal("Expected UUID to be generated but failed with error ", err.Error())
+		}
+	}
+
+	isOrdered(t, uuids)
+
+	if _, err = uuid.Read(uuids[0].Hex()); err != nil {
+		t.Error("Expected ordered UUID to be readable but failed with error ", err.Error())
+	}
+}
+
+func TestNewOrderedClock(t *testing.T) {
+	var (
+		now   int64
+		uuids []*uuid.UUID
+		bin   [16]byte
+		index int
+	)
+
+	setupScopes(t, "one")
+
+	now = 1000
+	t.Cleanup(uuid.SetClock(func() int64 {
+		return now
+	}))
+
+	//enough UUIDs within one millisecond to overflow the counter, then the clock goes backwards
+	//before it advances again
+	uuids = make([]*uuid.UUID, 0, 0x20000+20)
+
+	for index = 0; index < 0x20000; index++ {
+		uuids = append(uuids, mustNewOrdered(t))
+	}
+
+	for now = 990; now < 1010; now++ {
+		uuids = append(uuids, mustNewOrdered(t))
+	}
+
+	isOrdered(t, uuids)
+
+	//the timestamp is stored in bytes 1-6, 1000 = 0x3e8
+	bin = uuids[0].Bin()
+	if !bytes.Equal(bin[1:7], []byte{0, 0, 0, 0, 0x03, 0xe8}) {
+		t.Error("unexpected timestamp in UUID ", uuids[0].Hex())
+	}
+}
+
+func mustNewOrdered(t *testing.T) *uuid.UUID {
+	var (
+		myUUID *uuid.UUID
+		err    error
+	)
+
+	t.Helper()
+
+	myUUID, err = uuid.NewOrdered("one")
+	if err != nil {
+		t.Fatal("Expected UUID to be generated but failed with error ", err.Error())
+	}
+
+	return myUUID
+}
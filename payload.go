@@ -0,0 +1,60 @@
+package uuid
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// Payload returns the binary representation of the UUID with the scope bits set to 0, leaving only the
+// random part and the low two bits of the byte holding the scope (the first or, with ScopeTrailing, the
+// last byte).
+//
+// If the UUID is not initialized, all bytes are 0.
+func (uuid *UUID) Payload() [16]byte {
+	var (
+		payload [16]byte
+	)
+
+	if uuid == nil {
+		return payload
+	}
+
+	payload = uuid.bin
+	payload[scopePosition()] &^= scopeMask()
+
+	return payload
+}
+
+// PayloadUint64 returns bytes 8-15 of the UUID as a big endian unsigned integer, e.g. for modulo based
+// bucketing. Those bytes never contain scope bits. With ScopeTrailing, bytes 7-14 are used instead, as
+// the last byte holds the scope and bytes 1-6 the timestamp of ordered UUIDs (see NewOrdered).
+//
+// The bytes used are guaranteed to stay the same in future versions, so values derived from it can be
+// persisted. If the UUID is not initialized, 0 is returned.
+func (uuid *UUID) PayloadUint64() uint64 {
+	if uuid == nil {
+		return 0
+	}
+
+	if scopeLayout == ScopeTrailing {
+		return binary.BigEndian.Uint64(uuid.bin[7:15])
+	}
+
+	return binary.BigEndian.Uint64(uuid.bin[8:16])
+}
+
+// ShardOf assigns the UUID to one of n shards and returns the shard in the range [0, n). The shard is
+// PayloadUint64() modulo n, i.e. the big endian unsigned integer of bytes 8-15 (7-14 with ScopeTrailing)
+// modulo n. The scope bits are not used so shards are balanced across scopes. This derivation is
+// guaranteed to stay the same in future versions.
+func (uuid *UUID) ShardOf(n int) (int, error) {
+	if uuid == nil {
+		return 0, errors.New(ErrorUninitializedUUID)
+	}
+
+	if n <= 0 {
+		return 0, errors.New(ErrorBadShardCount)
+	}
+
+	return int(uuid.PayloadUint64() % uint64(n)), nil
+}
@@ -0,0 +1,122 @@
+package uuid_test
+
+import (
+	"github.com/4xoc/uuid"
+	"testing"
+)
+
+func TestPayload(t *testing.T) {
+	var (
+		myUUID *uuid.UUID
+		nilPtr *uuid.UUID
+	)
+
+	setupScopes(t, "one", "two")
+
+	if nilPtr.Payload() != [16]byte{} || nilPtr.PayloadUint64() != 0 {
+		t.Error("nil UUID should return zero values")
+	}
+
+	//pinned values; they must never change
+	myUUID = mustRead(t, payloadFixture())
+	payload := [16]byte{0x03, 0xa1, 0xb2, 0xc3, 8: 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08}
+	other := "03a1b2c3-0000-0000-0102-030405060708"
+
+	if testLayout == uuid.ScopeTrailing {
+		payload = [16]byte{7: 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x03}
+		other = "00000000-0000-0001-0203-040506070803"
+	}
+
+	if myUUID.Payload() != payload {
+		t.Error("unexpected payload ", myUUID.Payload())
+	}
+
+	if myUUID.PayloadUint64() != 0x0102030405060708 {
+		t.Error("unexpected payload integer ", myUUID.PayloadUint64())
+	}
+
+	//the same payload in another scope is equal
+	if mustRead(t, other).Payload() != myUUID.Payload() {
+		t.Error("payload should not depend on the scope")
+	}
+}
+
+func TestShardOf(t *testing.T) {
+	var (
+		nilPtr *uuid.UUID
+		uuids  []*uuid.UUID
+		counts [7]int
+		shard  int
+		index  int
+		err    error
+	)
+
+	setupScopes(t, "one", "two")
+
+	if _, err = nilPtr.ShardOf(4); err == nil || err.Error() != uuid.ErrorUninitializedUUID {
+		t.Error("Expected error for nil UUID")
+	}
+
+	for _, n := range []int{0, -1} {
+		if _, err = mustNew(t, "one").ShardOf(n); err == nil || err.Error() != uuid.ErrorBadShardCount {
+			t.Error("Expected error for shard count ", n)
+		}
+	}
+
+	//pinned value; this must never change
+	shard, _ = mustRead(t, payloadFixture()).ShardOf(1000)
+	if shard != int(uint64(0x0102030405060708)%1000) {
+		t.Error("unexpected shard ", shard)
+	}
+
+	uuids, _ = uuid.NewBatch("one", 35000)
+
+	for index = range uuids {
+		shard, err = uuids[index].ShardOf(len(counts))
+		if err != nil || shard < 0 || shard >= len(counts) {
+			t.Fatal("unexpected shard ", shard)
+		}
+
+		counts[shard]++
+	}
+
+	//about 5000 per shard expected
+	for index = range counts {
+		if counts[index] < 4500 || counts[index] > 5500 {
+			t.Error("shard ", index, " received ", counts[index], " of ", len(uuids), " UUIDs")
+		}
+	}
+}
+
+// payloadFixture returns a UUID of scope two in the layout under test whose payload integer is
+// 0x0102030405060708.
+func payloadFixture() string {
+	if testLayout == uuid.ScopeTrailing {
+		return "00000000-0000-0001-0203-040506070807"
+	}
+
+	return "07a1b2c3-0000-0000-0102-030405060708"
+}
+
+func TestPayloadOrdered(t *testing.T) {
+	var (
+		first  *uuid.UUID
+		second *uuid.UUID
+		err    error
+	)
+
+	setupScopes(t, "one", "two")
+
+	//the low 48 bits of the payload integer are random in both layouts, not part of the timestamp
+	if first, err = uuid.NewOrdered("two"); err != nil {
+		t.Fatal(err)
+	}
+
+	if second, err = uuid.NewOrdered("two"); err != nil {
+		t.Fatal(err)
+	}
+
+	if first.PayloadUint64()&0xffffffffffff == second.PayloadUint64()&0xffffffffffff {
+		t.Error("ordered UUIDs don't differ in the random bits of their payload integer")
+	}
+}
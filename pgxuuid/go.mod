@@ -0,0 +1,10 @@
+module github.com/4xoc/uuid/pgxuuid
+
+go 1.24
+
+require (
+	github.com/4xoc/uuid v0.0.0
+	github.com/jackc/pgx/v5 v5.7.2
+)
+
+replace github.com/4xoc/uuid => ../
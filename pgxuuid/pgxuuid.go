@@ -0,0 +1,151 @@
+// Package pgxuuid integrates uuid.UUID with the pgx v5 Postgres driver.
+//
+// After calling Register on a connection's type map, *uuid.UUID and uuid.UUID can be used directly as
+// query arguments and scan destinations for columns of the Postgres uuid type, in both the text and the
+// binary protocol. NULL is mapped to the zero value of uuid.UUID and vice versa.
+//
+//	config.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+//		pgxuuid.Register(conn.TypeMap())
+//		return nil
+//	}
+//
+// The package lives in its own module so that the uuid package doesn't depend on pgx.
+package pgxuuid
+
+import (
+	"errors"
+
+	"github.com/4xoc/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// UUID is a uuid.UUID implementing the pgtype interfaces used by pgtype.UUIDCodec.
+type UUID uuid.UUID
+
+// ScanUUID implements pgtype.UUIDScanner. NULL results in the zero value, any other value must belong
+// to a known scope.
+func (u *UUID) ScanUUID(v pgtype.UUID) error {
+	if !v.Valid {
+		*u = UUID{}
+		return nil
+	}
+
+	return (*uuid.UUID)(u).Scan(v.Bytes[:])
+}
+
+// UUIDValue implements pgtype.UUIDValuer. The zero value is encoded as NULL.
+func (u UUID) UUIDValue() (pgtype.UUID, error) {
+	var (
+		tmpUUID uuid.UUID
+	)
+
+	tmpUUID = uuid.UUID(u)
+
+	//UUIDs of unknown scopes read with uuid.ReadAny have no scope name either but aren't NULL
+	if tmpUUID == (uuid.UUID{}) {
+		return pgtype.UUID{}, nil
+	}
+
+	return pgtype.UUID{Bytes: tmpUUID.Bin(), Valid: true}, nil
+}
+
+// Codec is a pgtype.UUIDCodec decoding values into uuid.UUID instead of pgtype.UUID.
+type Codec struct {
+	pgtype.UUIDCodec
+}
+
+// DecodeValue decodes src into a uuid.UUID. NULL is decoded as nil.
+func (Codec) DecodeValue(tm *pgtype.Map, oid uint32, format int16, src []byte) (any, error) {
+	var (
+		target UUID
+		plan   pgtype.ScanPlan
+		err    error
+	)
+
+	if src == nil {
+		return nil, nil
+	}
+
+	plan = tm.PlanScan(oid, format, &target)
+	if plan == nil {
+		return nil, errors.New("no scan plan for uuid.UUID")
+	}
+
+	err = plan.Scan(src, &target)
+	if err != nil {
+		return nil, err
+	}
+
+	return uuid.UUID(target), nil
+}
+
+// Register registers the codec for the uuid type and the plans wrapping uuid.UUID in the given type map.
+func Register(tm *pgtype.Map) {
+	tm.TryWrapEncodePlanFuncs = append([]pgtype.TryWrapEncodePlanFunc{TryWrapEncodePlan}, tm.TryWrapEncodePlanFuncs...)
+	tm.TryWrapScanPlanFuncs = append([]pgtype.TryWrapScanPlanFunc{TryWrapScanPlan}, tm.TryWrapScanPlanFuncs...)
+
+	tm.RegisterType(&pgtype.Type{Name: "uuid", OID: pgtype.UUIDOID, Codec: Codec{}})
+}
+
+// TryWrapEncodePlan is a pgtype.TryWrapEncodePlanFunc encoding uuid.UUID and *uuid.UUID as UUID. A nil
+// *uuid.UUID is left to pgx which encodes it as NULL.
+func TryWrapEncodePlan(value any) (plan pgtype.WrappedEncodePlanNextSetter, nextValue any, ok bool) {
+	switch value := value.(type) {
+	case uuid.UUID:
+		return &wrapEncodePlan{}, UUID(value), true
+	case *uuid.UUID:
+		if value != nil {
+			return &wrapPtrEncodePlan{}, UUID(*value), true
+		}
+	}
+
+	return nil, nil, false
+}
+
+// wrapEncodePlan encodes uuid.UUID values as UUID.
+type wrapEncodePlan struct {
+	next pgtype.EncodePlan
+}
+
+func (plan *wrapEncodePlan) SetNext(next pgtype.EncodePlan) {
+	plan.next = next
+}
+
+func (plan *wrapEncodePlan) Encode(value any, buf []byte) ([]byte, error) {
+	return plan.next.Encode(UUID(value.(uuid.UUID)), buf)
+}
+
+// wrapPtrEncodePlan encodes *uuid.UUID values as UUID.
+type wrapPtrEncodePlan struct {
+	next pgtype.EncodePlan
+}
+
+func (plan *wrapPtrEncodePlan) SetNext(next pgtype.EncodePlan) {
+	plan.next = next
+}
+
+func (plan *wrapPtrEncodePlan) Encode(value any, buf []byte) ([]byte, error) {
+	return plan.next.Encode(UUID(*value.(*uuid.UUID)), buf)
+}
+
+// TryWrapScanPlan is a pgtype.TryWrapScanPlanFunc scanning into *uuid.UUID via *UUID.
+func TryWrapScanPlan(target any) (plan pgtype.WrappedScanPlanNextSetter, nextDst any, ok bool) {
+	if target, ok := target.(*uuid.UUID); ok {
+		return &wrapScanPlan{}, (*UUID)(target), true
+	}
+
+	return nil, nil, false
+}
+
+// wrapScanPlan scans into *uuid.UUID via *UUID.
+type wrapScanPlan struct {
+	next pgtype.ScanPlan
+}
+
+func (plan *wrapScanPlan) SetNext(next pgtype.ScanPlan) {
+	plan.next = next
+}
+
+func (plan *wrapScanPlan) Scan(src []byte, dst any) error {
+	return plan.next.Scan(src, (*UUID)(dst.(*uuid.UUID)))
+}
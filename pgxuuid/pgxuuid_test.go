@@ -0,0 +1,110 @@
+package pgxuuid_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/4xoc/uuid"
+	"github.com/4xoc/uuid/pgxuuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestMain(m *testing.M) {
+	if err := uuid.SetScopes([64]string{"one", "two"}); err != nil {
+		panic(err)
+	}
+
+	os.Exit(m.Run())
+}
+
+func TestRoundTrip(t *testing.T) {
+	var (
+		tm      *pgtype.Map
+		myUUID  *uuid.UUID
+		scanned uuid.UUID
+		value   any
+		buf     []byte
+		err     error
+	)
+
+	tm = pgtype.NewMap()
+	pgxuuid.Register(tm)
+
+	myUUID, err = uuid.New("two")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, format := range []int16{pgtype.TextFormatCode, pgtype.BinaryFormatCode} {
+		for _, arg := range []any{myUUID, *myUUID} {
+			buf, err = tm.Encode(pgtype.UUIDOID, format, arg, nil)
+			if err != nil {
+				t.Fatal("format ", format, ": failed to encode: ", err)
+			}
+
+			if format == pgtype.TextFormatCode && string(buf) != myUUID.Hex() {
+				t.Error("format ", format, ": unexpected text encoding ", string(buf))
+			}
+
+			scanned = uuid.UUID{}
+
+			err = tm.Scan(pgtype.UUIDOID, format, buf, &scanned)
+			if err != nil {
+				t.Fatal("format ", format, ": failed to scan: ", err)
+			}
+
+			if scanned.Bin() != myUUID.Bin() || scanned.Scope() != "two" {
+				t.Error("format ", format, ": UUID doesn't round-trip")
+			}
+		}
+
+		//NULL maps to the zero value
+		buf, err = tm.Encode(pgtype.UUIDOID, format, uuid.UUID{}, nil)
+		if err != nil || buf != nil {
+			t.Error("format ", format, ": zero value should be encoded as NULL but got ", buf, err)
+		}
+
+		buf, err = tm.Encode(pgtype.UUIDOID, format, (*uuid.UUID)(nil), nil)
+		if err != nil || buf != nil {
+			t.Error("format ", format, ": nil pointer should be encoded as NULL but got ", buf, err)
+		}
+
+		scanned = *myUUID
+
+		err = tm.Scan(pgtype.UUIDOID, format, nil, &scanned)
+		if err != nil || scanned != (uuid.UUID{}) {
+			t.Error("format ", format, ": NULL should be scanned as zero value but got ", err)
+		}
+
+		//UUIDs of unknown scopes read with ReadAny are written as they are
+		foreign, err := uuid.ReadAny("fc000000-0000-0000-0000-0000000000fc")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		buf, err = tm.Encode(pgtype.UUIDOID, format, foreign, nil)
+		if err != nil || buf == nil || format == pgtype.TextFormatCode && string(buf) != foreign.Hex() {
+			t.Error("format ", format, ": unexpected encoding of unknown scope ", buf, err)
+		}
+
+		//values of unknown scopes are rejected
+		buf, _ = tm.Encode(pgtype.UUIDOID, format, pgtype.UUID{Bytes: [16]byte{0xfc}, Valid: true}, nil)
+
+		err = tm.Scan(pgtype.UUIDOID, format, buf, &scanned)
+		if err == nil {
+			t.Error("format ", format, ": expected error for unknown scope")
+		}
+	}
+
+	//decoding into any yields uuid.UUID
+	buf, _ = tm.Encode(pgtype.UUIDOID, pgtype.BinaryFormatCode, myUUID, nil)
+
+	value, err = pgxuuid.Codec{}.DecodeValue(tm, pgtype.UUIDOID, pgtype.BinaryFormatCode, buf)
+	if err != nil {
+		t.Fatal("failed to decode value: ", err)
+	}
+
+	if decoded, ok := value.(uuid.UUID); !ok || decoded.Bin() != myUUID.Bin() {
+		t.Error("unexpected decoded value ", value)
+	}
+}
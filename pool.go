@@ -0,0 +1,114 @@
+package uuid
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// poolBatchSize limits the number of UUIDs a Pool generates at once when refilling.
+const poolBatchSize int = 64
+
+// Pool pre-generates UUIDs of a single scope in the background so that Get usually doesn't have to wait
+// for random data. A Pool is safe for concurrent use.
+type Pool struct {
+	// factory generates the UUIDs of the pool's scope.
+	factory *ScopeFactory
+	// ids holds the pre-generated UUIDs.
+	ids chan *UUID
+	// done is closed by Close to stop the refiller.
+	done chan struct{}
+	// closeOnce makes sure done is only closed once.
+	closeOnce sync.Once
+	// wg waits for the refiller to stop.
+	wg sync.WaitGroup
+}
+
+// NewPool returns a Pool holding up to size pre-generated UUIDs of the given scope and starts refilling it
+// in the background. If the scope doesn't exist, an error is returned right away.
+func NewPool(scope string, size int) (*Pool, error) {
+	var (
+		pool *Pool
+		err  error
+	)
+
+	if size <= 0 {
+		return nil, errors.New(ErrorBadPoolSize)
+	}
+
+	pool = &Pool{
+		ids:  make(chan *UUID, size),
+		done: make(chan struct{}),
+	}
+
+	pool.factory, err = ForScope(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	pool.wg.Add(1)
+	go pool.refill()
+
+	return pool, nil
+}
+
+// refill keeps the pool filled until Close is called. Failing to generate UUIDs is retried after a short
+// pause; Get reports such errors when the pool is drained.
+func (pool *Pool) refill() {
+	var (
+		batch []*UUID
+		index int
+		err   error
+	)
+
+	defer pool.wg.Done()
+
+	for {
+		batch, err = pool.factory.NewBatch(min(poolBatchSize, cap(pool.ids)))
+		if err != nil {
+			select {
+			case <-pool.done:
+				return
+			case <-time.After(10 * entropyBackoff):
+				continue
+			}
+		}
+
+		for index = range batch {
+			select {
+			case <-pool.done:
+				return
+			case pool.ids <- batch[index]:
+			}
+		}
+	}
+}
+
+// Get returns a pre-generated UUID of the pool's scope. If the pool is drained or closed, the UUID is
+// generated synchronously like ScopeFactory.New. Every UUID is handed out once at most. Once the scope
+// is deprecated, ErrScopeDeprecated is returned even if pre-generated UUIDs are left.
+func (pool *Pool) Get() (*UUID, error) {
+	if loadScopes().isDeprecated(pool.factory.scope) {
+		return nil, ErrScopeDeprecated
+	}
+
+	select {
+	case uuid := <-pool.ids:
+		return uuid, nil
+	default:
+		return pool.factory.New()
+	}
+}
+
+// Close stops refilling the pool and discards all pre-generated UUIDs. Get keeps working afterwards by
+// generating UUIDs synchronously. Calling Close more than once has no effect.
+func (pool *Pool) Close() {
+	pool.closeOnce.Do(func() {
+		close(pool.done)
+		pool.wg.Wait()
+
+		for len(pool.ids) > 0 {
+			<-pool.ids
+		}
+	})
+}
@@ -0,0 +1,130 @@
+package uuid_test
+
+import (
+	"github.com/4xoc/uuid"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPool(t *testing.T) {
+	var (
+		pool  *uuid.Pool
+		seen  map[[16]byte]bool
+		mu    sync.Mutex
+		wg    sync.WaitGroup
+		index int
+		err   error
+	)
+
+	setupScopes(t, "one", "two")
+
+	if _, err = uuid.NewPool("ten", 10); err != uuid.ErrMissingScope {
+		t.Error("Expected ErrMissingScope but got ", err)
+	}
+
+	if _, err = uuid.NewPool("two", 0); err == nil || err.Error() != uuid.ErrorBadPoolSize {
+		t.Error("Expected error for bad pool size but got ", err)
+	}
+
+	pool, err = uuid.NewPool("two", 100)
+	if err != nil {
+		t.Fatal("Expected pool to be created but failed with error ", err.Error())
+	}
+
+	seen = make(map[[16]byte]bool)
+
+	//getting more UUIDs than the pool holds, before and after closing it
+	for index = 0; index < 8; index++ {
+		wg.Add(1)
+
+		go func(index int) {
+			defer wg.Done()
+
+			for i := 0; i < 500; i++ {
+				if index == 0 && i == 250 {
+					pool.Close()
+				}
+
+				myUUID, err := pool.Get()
+				if err != nil {
+					t.Error("Expected UUID but failed with error ", err.Error())
+					return
+				}
+
+				if myUUID.Scope() != "two" {
+					t.Error("unexpected scope ", myUUID.Scope())
+				}
+
+				mu.Lock()
+				if seen[myUUID.Bin()] {
+					t.Error("UUID ", myUUID.Hex(), " has been handed out twice")
+				}
+				seen[myUUID.Bin()] = true
+				mu.Unlock()
+			}
+		}(index)
+	}
+
+	wg.Wait()
+
+	//closing again is a no-op
+	pool.Close()
+
+	if len(seen) != 4000 {
+		t.Error("Expected 4000 UUIDs but got ", len(seen))
+	}
+}
+
+func TestPoolRefillError(t *testing.T) {
+	var (
+		pool *uuid.Pool
+		err  error
+	)
+
+	setupScopes(t, "one")
+	t.Cleanup(uuid.SetEntropySource(failingReader{}))
+
+	pool, err = uuid.NewPool("one", 10)
+	if err != nil {
+		t.Fatal("Expected pool to be created but failed with error ", err.Error())
+	}
+	defer pool.Close()
+
+	time.Sleep(10 * time.Millisecond)
+
+	//the drained pool reports the error of synchronous generation
+	if _, err = pool.Get(); err == nil {
+		t.Error("Expected an error when no entropy can be read")
+	}
+}
+
+func BenchmarkPoolGet(b *testing.B) {
+	setupScopes(b, "one")
+
+	pool, err := uuid.NewPool("one", 1024)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer pool.Close()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := pool.Get(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkNewParallel(b *testing.B) {
+	setupScopes(b, "one")
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := uuid.New("one"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,195 @@
+package uuid
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+	"strings"
+)
+
+const (
+	// minShortHex and maxShortHex limit the number of characters returned by ShortHex.
+	minShortHex int = 4
+	maxShortHex int = 32
+	// maxPrefixCandidates limits the number of candidates listed by AmbiguousPrefixError.
+	maxPrefixCandidates int = 5
+)
+
+var (
+	// ErrAmbiguousPrefix is matched by errors.Is for every *AmbiguousPrefixError.
+	ErrAmbiguousPrefix = errors.New(ErrorAmbiguousPrefix)
+	// ErrNotFound is returned by PrefixIndex.Find when no UUID matches. Its message is ErrorNotFound.
+	ErrNotFound = errors.New(ErrorNotFound)
+)
+
+// ShortHex returns the first n hex characters of the UUID without dashes, similar to abbreviated commit
+// hashes in git. n is clamped to the range [4, 32]. Use PrefixIndex to resolve such prefixes.
+//
+// If the UUID is not initialized, an empty string is returned.
+func (uuid *UUID) ShortHex(n int) string {
+	var (
+		buf [32]byte
+	)
+
+	if uuid == nil || uuid.scope == "" {
+		return ""
+	}
+
+	n = max(minShortHex, min(n, maxShortHex))
+
+	return string(uuid.AppendCompact(buf[:0])[:n])
+}
+
+// AmbiguousPrefixError is returned by PrefixIndex.Find when a prefix matches more than one UUID.
+// errors.Is reports it as ErrAmbiguousPrefix.
+type AmbiguousPrefixError struct {
+	// Prefix is the prefix as passed to Find.
+	Prefix string
+	// Candidates holds up to five of the matching UUIDs in ascending order.
+	Candidates []*UUID
+}
+
+// Error returns the error message including the prefix and the listed candidates.
+func (err *AmbiguousPrefixError) Error() string {
+	var (
+		msg strings.Builder
+	)
+
+	msg.WriteString(ErrorAmbiguousPrefix)
+	msg.WriteString(" (")
+	msg.WriteString(err.Prefix)
+	msg.WriteString("): ")
+	msg.WriteString(JoinHex(err.Candidates, ", "))
+
+	return msg.String()
+}
+
+// Is reports whether target is ErrAmbiguousPrefix.
+func (err *AmbiguousPrefixError) Is(target error) bool {
+	return target == ErrAmbiguousPrefix
+}
+
+// PrefixIndex resolves hex prefixes (see ShortHex) to the UUID they abbreviate. It holds the UUIDs
+// sorted by their binary representation, so lookups take O(log n) time. A PrefixIndex is immutable and
+// safe for concurrent use.
+type PrefixIndex struct {
+	uuids []*UUID
+}
+
+// NewPrefixIndex returns an index of the given UUIDs. Nil and uninitialized UUIDs are skipped and
+// duplicates are only indexed once. The slice itself is not modified.
+func NewPrefixIndex(uuids []*UUID) *PrefixIndex {
+	var (
+		index *PrefixIndex
+	)
+
+	index = &PrefixIndex{uuids: make([]*UUID, 0, len(uuids))}
+
+	for _, uuid := range uuids {
+		if uuid != nil && uuid.scope != "" {
+			index.uuids = append(index.uuids, uuid)
+		}
+	}
+
+	Sort(index.uuids)
+
+	index.uuids = compactSorted(index.uuids)
+
+	return index
+}
+
+// Len returns the number of UUIDs in the index.
+func (index *PrefixIndex) Len() int {
+	return len(index.uuids)
+}
+
+// Find returns the only UUID starting with the given hex prefix. The prefix is case-insensitive and may
+// contain dashes anywhere. If more than one UUID matches, an *AmbiguousPrefixError is returned; if none
+// matches, ErrNotFound. A prefix that is not hex or longer than a UUID returns ErrBadString.
+func (index *PrefixIndex) Find(prefix string) (*UUID, error) {
+	var (
+		low     [16]byte
+		nibbles int
+		first   int
+		last    int
+		nibble  byte
+		err     error
+	)
+
+	for position := 0; position < len(prefix); position++ {
+		if prefix[position] == '-' {
+			continue
+		}
+
+		nibble, err = hexNibble(prefix[position])
+		if err != nil || nibbles == 2*len(low) {
+			return nil, ErrBadString
+		}
+
+		low[nibbles/2] |= nibble << (4 * (1 - nibbles%2))
+		nibbles++
+	}
+
+	//the first match is the smallest UUID not sorting before the prefix padded with zeros
+	first = sort.Search(len(index.uuids), func(i int) bool {
+		return bytes.Compare(index.uuids[i].bin[:], low[:]) >= 0
+	})
+
+	for last = first; last < len(index.uuids) && hasPrefix(index.uuids[last].bin, low, nibbles); last++ {
+		if last-first == maxPrefixCandidates {
+			break
+		}
+	}
+
+	switch last - first {
+	case 0:
+		return nil, ErrNotFound
+	case 1:
+		return index.uuids[first], nil
+	}
+
+	return nil, &AmbiguousPrefixError{
+		Prefix:     prefix,
+		Candidates: append([]*UUID(nil), index.uuids[first:last]...),
+	}
+}
+
+// hasPrefix returns true if the first nibbles hex digits of bin and prefix are equal.
+func hasPrefix(bin [16]byte, prefix [16]byte, nibbles int) bool {
+	if !bytes.Equal(bin[:nibbles/2], prefix[:nibbles/2]) {
+		return false
+	}
+
+	return nibbles%2 == 0 || bin[nibbles/2]&0xf0 == prefix[nibbles/2]
+}
+
+// hexNibble returns the value of a single hex digit regardless of its case.
+func hexNibble(digit byte) (byte, error) {
+	switch {
+	case digit >= '0' && digit <= '9':
+		return digit - '0', nil
+	case digit >= 'a' && digit <= 'f':
+		return digit - 'a' + 10, nil
+	case digit >= 'A' && digit <= 'F':
+		return digit - 'A' + 10, nil
+	}
+
+	return 0, ErrBadString
+}
+
+// compactSorted removes consecutive UUIDs with the same binary representation from a sorted slice.
+func compactSorted(uuids []*UUID) []*UUID {
+	var (
+		result []*UUID
+	)
+
+	result = uuids[:0]
+
+	for _, uuid := range uuids {
+		if len(result) == 0 || result[len(result)-1].bin != uuid.bin {
+			result = append(result, uuid)
+		}
+	}
+
+	return result
+}
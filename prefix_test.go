@@ -0,0 +1,151 @@
+package uuid_test
+
+import (
+	"errors"
+	"github.com/4xoc/uuid"
+	"strings"
+	"testing"
+)
+
+func TestShortHex(t *testing.T) {
+	var (
+		myUUID *uuid.UUID
+	)
+
+	setupScopes(t, "one", "two")
+
+	//valid in both layouts
+	myUUID = mustRead(t, "04a1b2c3-d4e5-f607-1829-3a4b5c6d7e05")
+
+	for n, expected := range map[int]string{
+		-1:  "04a1",
+		4:   "04a1",
+		9:   "04a1b2c3d",
+		32:  "04a1b2c3d4e5f60718293a4b5c6d7e05",
+		100: "04a1b2c3d4e5f60718293a4b5c6d7e05",
+	} {
+		if myUUID.ShortHex(n) != expected {
+			t.Error("unexpected ShortHex(", n, ") ", myUUID.ShortHex(n))
+		}
+	}
+
+	if (*uuid.UUID)(nil).ShortHex(8) != "" {
+		t.Error("expected empty string for nil UUID")
+	}
+}
+
+func TestPrefixIndex(t *testing.T) {
+	var (
+		index     *uuid.PrefixIndex
+		found     *uuid.UUID
+		ambiguous *uuid.AmbiguousPrefixError
+		uuids     []*uuid.UUID
+		err       error
+	)
+
+	setupScopes(t, "one", "two")
+
+	//valid in both layouts
+	for _, input := range []string{
+		"04a1b2c3-0000-0000-0000-000000000000",
+		"04a1b2c4-0000-0000-0000-000000000000",
+		"04a1b2c4-1000-0000-0000-000000000001",
+		"04f00000-0000-0000-0000-000000000000",
+		"050fffff-ffff-ffff-ffff-ffffffffff07",
+	} {
+		uuids = append(uuids, mustRead(t, input))
+	}
+
+	//duplicates, nil and uninitialized UUIDs are skipped
+	index = uuid.NewPrefixIndex(append(uuids, nil, new(uuid.UUID), mustRead(t, uuids[0].Hex())))
+	if index.Len() != 5 {
+		t.Fatal("unexpected index size ", index.Len())
+	}
+
+	for prefix, expected := range map[string]*uuid.UUID{
+		"04a1b2c3":                             uuids[0],
+		"04A1-B2C3":                            uuids[0],
+		"04a1b2c40000-0":                       uuids[1],
+		"04a1b2c4-1000-0000-0000-000000000001": uuids[2],
+		"04f":                                  uuids[3],
+		"050f":                                 uuids[4],
+		"05":                                   uuids[4],
+	} {
+		found, err = index.Find(prefix)
+		if err != nil || found != expected {
+			t.Error("unexpected match for ", prefix, ": ", found.Hex(), " ", err)
+		}
+	}
+
+	_, err = index.Find("04a1")
+	if !errors.As(err, &ambiguous) || !errors.Is(err, uuid.ErrAmbiguousPrefix) || len(ambiguous.Candidates) != 3 ||
+		ambiguous.Candidates[0] != uuids[0] || !strings.Contains(err.Error(), uuids[2].Hex()) {
+		t.Error("expected ambiguous prefix but got ", err)
+	}
+
+	for _, prefix := range []string{"1", "04a1b2c5", "04e", "050fffff-ffff-ffff-ffff-fffffffffffe"} {
+		if _, err = index.Find(prefix); err != uuid.ErrNotFound {
+			t.Error("expected ErrNotFound for ", prefix, " but got ", err)
+		}
+	}
+
+	for _, prefix := range []string{"04x", "04a1b2c3-0000-0000-0000-0000000000000"} {
+		if _, err = index.Find(prefix); err != uuid.ErrBadString {
+			t.Error("expected ErrBadString for ", prefix, " but got ", err)
+		}
+	}
+}
+
+func TestPrefixIndexCandidates(t *testing.T) {
+	var (
+		ambiguous *uuid.AmbiguousPrefixError
+		uuids     []*uuid.UUID
+		err       error
+	)
+
+	setupScopes(t, "one", "two")
+
+	uuids, err = uuid.NewBatch("one", 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = uuid.NewPrefixIndex(uuids).Find("")
+	if !errors.As(err, &ambiguous) || len(ambiguous.Candidates) != 5 {
+		t.Error("expected five candidates but got ", err)
+	}
+}
+
+func BenchmarkPrefixIndexFind(b *testing.B) {
+	var (
+		index  *uuid.PrefixIndex
+		uuids  []*uuid.UUID
+		prefix []string
+		err    error
+	)
+
+	uuid.ResetScopes()
+	defer uuid.ResetScopes()
+
+	uuid.SetScopes([64]string{"one"})
+
+	uuids, err = uuid.NewBatch("one", 1000000)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	index = uuid.NewPrefixIndex(uuids)
+
+	for _, myUUID := range uuids[:1024] {
+		prefix = append(prefix, myUUID.ShortHex(12))
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, err = index.Find(prefix[i%len(prefix)])
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package uuid
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+// Prefixed returns the scope of the UUID followed by an underscore and the hex-string of the UUID
+// without dashes, e.g. "user_9c4fb1d084f34d8db6cc682d1ca34dae".
+//
+// If the UUID is not initialized or of an unknown scope (see ReadAny), an empty string is returned.
+func (uuid *UUID) Prefixed() string {
+	var (
+		buf []byte
+	)
+
+	if uuid == nil || uuid.scope == "" || uuid.scope == unknownScope {
+		return ""
+	}
+
+	buf = make([]byte, 0, len(uuid.scope)+1+32)
+	buf = append(buf, uuid.scope...)
+	buf = append(buf, '_')
+
+	return string(uuid.AppendCompact(buf))
+}
+
+// ReadPrefixed parses a string as returned by Prefixed. The string is split at its last underscore so
+// scopes may contain underscores themselves. The prefix must be a known scope (ErrorBadScope otherwise)
+// and must match the scope stored in the UUID; a mismatch indicates tampering and returns
+// ErrorScopeMismatch.
+func ReadPrefixed(input string) (*UUID, error) {
+	var (
+		uuid     UUID
+		table    *scopeTable
+		name     string
+		index    int
+		tmpBytes []byte
+		ok       bool
+		err      error
+	)
+
+	index = strings.LastIndexByte(input, '_')
+	if index < 0 || len(input)-index-1 != 32 || strings.ToLower(input[index+1:]) != input[index+1:] {
+		return nil, ErrBadString
+	}
+
+	table = loadScopes()
+
+	name, _, ok = table.resolve(input[:index])
+	if !ok {
+		return nil, ErrBadScope
+	}
+
+	tmpBytes, err = hex.DecodeString(input[index+1:])
+	if err != nil {
+		return nil, ErrBadString
+	}
+
+	copy(uuid.bin[:], tmpBytes)
+
+	err = uuid.resolveScopeIn(table)
+	if err != nil {
+		return nil, ErrBadScope
+	}
+
+	if uuid.scope != name {
+		return nil, errors.New(ErrorScopeMismatch)
+	}
+
+	return &uuid, nil
+}
@@ -0,0 +1,64 @@
+package uuid_test
+
+import (
+	"github.com/4xoc/uuid"
+	"testing"
+)
+
+func TestPrefixed(t *testing.T) {
+	var (
+		myUUID   *uuid.UUID
+		nilPtr   *uuid.UUID
+		myCopy   *uuid.UUID
+		prefixed string
+		err      error
+	)
+
+	setupScopes(t, "user", "legacy_order")
+
+	if nilPtr.Prefixed() != "" {
+		t.Error("nil UUID should return an empty string")
+	}
+
+	//valid in both layouts
+	myUUID = mustRead(t, "0529a1d0-84f3-4d8d-b6cc-682d1ca34d05")
+	prefixed = myUUID.Prefixed()
+
+	if prefixed != "legacy_order_0529a1d084f34d8db6cc682d1ca34d05" {
+		t.Fatal("unexpected prefixed string ", prefixed)
+	}
+
+	myCopy, err = uuid.ReadPrefixed(prefixed)
+	if err != nil || myCopy.Hex() != myUUID.Hex() || myCopy.Scope() != "legacy_order" {
+		t.Fatal("prefixed string should round-trip")
+	}
+
+	myUUID, _ = uuid.New("user")
+
+	myCopy, err = uuid.ReadPrefixed(myUUID.Prefixed())
+	if err != nil || myCopy.Hex() != myUUID.Hex() || myCopy.Scope() != "user" {
+		t.Fatal("prefixed string should round-trip")
+	}
+
+	testCases := []struct {
+		input string
+		err   string
+	}{
+		{"", uuid.ErrorBadString},
+		{"0529a1d084f34d8db6cc682d1ca34d05", uuid.ErrorBadString},
+		{"legacy_order_0529a1d084f34d8db6cc682d1ca34d0", uuid.ErrorBadString},
+		{"legacy_order_0529A1D084F34D8DB6CC682D1CA34D05", uuid.ErrorBadString},
+		{"legacy_order_0529a1d084f34d8db6cc682d1ca34d0z", uuid.ErrorBadString},
+		{"order_0529a1d084f34d8db6cc682d1ca34d05", uuid.ErrorBadScope},
+		{"_0529a1d084f34d8db6cc682d1ca34d05", uuid.ErrorBadScope},
+		{"legacy_order_fc29a1d084f34d8db6cc682d1ca34dfc", uuid.ErrorBadScope},
+		{"user_0529a1d084f34d8db6cc682d1ca34d05", uuid.ErrorScopeMismatch},
+	}
+
+	for index := range testCases {
+		_, err = uuid.ReadPrefixed(testCases[index].input)
+		if err == nil || err.Error() != testCases[index].err {
+			t.Error("test case ", index, ": expected error ", testCases[index].err, " but got ", err)
+		}
+	}
+}
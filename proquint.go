@@ -0,0 +1,94 @@
+package uuid
+
+import (
+	"strings"
+)
+
+const (
+	// proquintConsonants holds the consonants of proquints, each encoding 4 bits.
+	proquintConsonants string = "bdfghjklmnprstvz"
+	// proquintVowels holds the vowels of proquints, each encoding 2 bits.
+	proquintVowels string = "aiou"
+)
+
+// Proquint returns the UUID as eight pronounceable five-letter groups separated by hyphens, e.g.
+// "lusab-babad-...". Each group encodes 16 bits as alternating consonants and vowels as defined by the
+// proquint specification (https://arxiv.org/html/0901.4016).
+//
+// If the UUID is not initialized, an empty string is returned.
+func (uuid *UUID) Proquint() string {
+	var (
+		buf   [47]byte
+		word  uint16
+		index int
+	)
+
+	if uuid == nil || uuid.scope == "" {
+		return ""
+	}
+
+	for index = 0; index < 8; index++ {
+		word = uint16(uuid.bin[2*index])<<8 | uint16(uuid.bin[2*index+1])
+
+		buf[index*6] = proquintConsonants[word>>12]
+		buf[index*6+1] = proquintVowels[word>>10&0x03]
+		buf[index*6+2] = proquintConsonants[word>>6&0x0f]
+		buf[index*6+3] = proquintVowels[word>>4&0x03]
+		buf[index*6+4] = proquintConsonants[word&0x0f]
+
+		if index < 7 {
+			buf[index*6+5] = '-'
+		}
+	}
+
+	return string(buf[:])
+}
+
+// ReadProquint parses a UUID encoded by Proquint. Letters are case-insensitive and hyphens are optional.
+// A group holding anything but a proquint syllable is returned as *ParseError indexed by the position of
+// the group; like Read, the scope of the UUID must be known.
+func ReadProquint(input string) (*UUID, error) {
+	var (
+		uuid  UUID
+		tmp   string
+		word  uint16
+		pos   [5]int
+		index int
+		err   error
+	)
+
+	tmp = strings.ToLower(strings.Replace(input, "-", "", -1))
+
+	if len(tmp) != 40 {
+		err = ErrBadString
+	}
+
+	for index = 0; err == nil && index < 8; index++ {
+		pos[0] = strings.IndexByte(proquintConsonants, tmp[index*5])
+		pos[1] = strings.IndexByte(proquintVowels, tmp[index*5+1])
+		pos[2] = strings.IndexByte(proquintConsonants, tmp[index*5+2])
+		pos[3] = strings.IndexByte(proquintVowels, tmp[index*5+3])
+		pos[4] = strings.IndexByte(proquintConsonants, tmp[index*5+4])
+
+		if pos[0] < 0 || pos[1] < 0 || pos[2] < 0 || pos[3] < 0 || pos[4] < 0 {
+			err = &ParseError{Index: index, Input: tmp[index*5 : index*5+5], Err: ErrBadString}
+			break
+		}
+
+		word = uint16(pos[0])<<12 | uint16(pos[1])<<10 | uint16(pos[2])<<6 | uint16(pos[3])<<4 | uint16(pos[4])
+
+		uuid.bin[2*index] = byte(word >> 8)
+		uuid.bin[2*index+1] = byte(word)
+	}
+
+	if err == nil && uuid.resolveScope() != nil {
+		err = ErrBadScope
+	}
+
+	if err != nil {
+		reportParseError(input, err)
+		return nil, err
+	}
+
+	return &uuid, nil
+}
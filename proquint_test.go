@@ -0,0 +1,66 @@
+package uuid_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/4xoc/uuid"
+	"strings"
+	"testing"
+)
+
+func TestProquint(t *testing.T) {
+	var (
+		myUUID   *uuid.UUID
+		copied   *uuid.UUID
+		parseErr *uuid.ParseError
+		err      error
+	)
+
+	setupScopes(t, "one", "two")
+
+	for i := 0; i < 100; i++ {
+		myUUID = mustNew(t, "two")
+
+		for _, input := range []string{
+			myUUID.Proquint(),
+			strings.ToUpper(myUUID.Proquint()),
+			strings.Replace(myUUID.Proquint(), "-", "", -1),
+		} {
+			copied, err = uuid.ReadProquint(input)
+			if err != nil || copied.Bin() != myUUID.Bin() || copied.Scope() != "two" {
+				t.Fatal("proquint ", input, " doesn't round-trip: ", err)
+			}
+		}
+	}
+
+	if len(myUUID.Proquint()) != 47 || (*uuid.UUID)(nil).Proquint() != "" {
+		t.Error("unexpected proquint ", myUUID.Proquint())
+	}
+
+	_, err = uuid.ReadProquint("babab-babab-xabab-babab-babab-babab-babab-babab")
+	if !errors.As(err, &parseErr) || parseErr.Index != 2 || parseErr.Input != "xabab" || !errors.Is(err, uuid.ErrBadString) {
+		t.Error("Expected positioned error but got ", err)
+	}
+
+	if _, err = uuid.ReadProquint("babab-babab"); err != uuid.ErrBadString {
+		t.Error("Expected ErrBadString for short input but got ", err)
+	}
+
+	//scope 0xfc in the first and the last byte
+	if _, err = uuid.ReadProquint("zabab-babab-babab-babab-babab-babab-babab-bagus"); err != uuid.ErrBadScope {
+		t.Error("Expected ErrBadScope but got ", err)
+	}
+}
+
+func ExampleUUID_Proquint() {
+	uuid.ResetScopes()
+	defer uuid.ResetScopes()
+
+	uuid.SetScopes([64]string{31: "ip"})
+
+	//127.0.0.1 and 63.84.220.193 as given in the proquint specification
+	myUUID, _ := uuid.Read("7f000001-3f54-dcc1-0000-000000000000")
+
+	fmt.Println(myUUID.Proquint())
+	// Output: lusab-babad-gutih-tugad-babab-babab-babab-babab
+}
@@ -0,0 +1,74 @@
+package uuid_test
+
+import (
+	"github.com/4xoc/uuid"
+	"math/rand"
+	"testing"
+	"testing/quick"
+)
+
+var _ quick.Generator = (*uuid.UUID)(nil)
+
+func TestGenerate(t *testing.T) {
+	var (
+		seen   map[string]int
+		zeros  int
+		config *quick.Config
+		err    error
+	)
+
+	setupScopes(t, "one", "two", "three")
+
+	seen = make(map[string]int)
+	config = &quick.Config{MaxCount: 1000, Rand: rand.New(rand.NewSource(1))}
+
+	err = quick.Check(func(myUUID *uuid.UUID, myOther *uuid.UUID) bool {
+		var (
+			myCopy *uuid.UUID
+			err    error
+		)
+
+		if !myUUID.IsValid() || !myOther.IsValid() {
+			return false
+		}
+
+		myCopy, err = uuid.Read(myUUID.Hex())
+		if err != nil || myCopy.Hex() != myUUID.Hex() || myCopy.Scope() != myUUID.Scope() {
+			return false
+		}
+
+		seen[myUUID.Scope()]++
+
+		if myUUID.Payload() == [16]byte{} {
+			zeros++
+		}
+
+		return true
+	}, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, scope := range []string{"one", "two", "three"} {
+		if seen[scope] < 250 {
+			t.Errorf("scope %s generated %d times", scope, seen[scope])
+		}
+	}
+
+	if zeros == 0 {
+		t.Error("no UUID with zero payload generated")
+	}
+}
+
+func TestGenerateNoScopes(t *testing.T) {
+	uuid.ResetScopes()
+	t.Cleanup(uuid.ResetScopes)
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic without scopes")
+		}
+	}()
+
+	new(uuid.UUID).Generate(rand.New(rand.NewSource(1)), 0)
+}
@@ -0,0 +1,91 @@
+package uuid
+
+// ReadAll parses all strings like Read, e.g. when importing millions of IDs at once. All UUIDs share a
+// single backing array and the strings are decoded without intermediate copies, so that the number of
+// allocations doesn't grow with the number of strings. The scope table is loaded once for all strings.
+//
+// Parsing stops at the first string that fails, returning a *ParseError holding its index and the error
+// Read returns for that string. Use ReadAllPartial to parse all strings regardless of errors.
+func ReadAll(inputs []string) ([]UUID, error) {
+	var (
+		uuids []UUID
+		table *scopeTable
+		index int
+		err   error
+	)
+
+	uuids = make([]UUID, len(inputs))
+	table = loadScopes()
+
+	for index = range inputs {
+		err = uuids[index].readCanonical(inputs[index], table)
+		if err != nil {
+			reportParseError(inputs[index], err)
+			return nil, &ParseError{Index: index, Input: inputs[index], Err: err}
+		}
+	}
+
+	return uuids, nil
+}
+
+// ReadAllPartial works like ReadAll but doesn't stop at strings that fail. Those are left as
+// uninitialized UUIDs in the result, and errs holds a *ParseError at their index while it is nil for
+// all others. If all strings are parsed successfully, errs is nil.
+func ReadAllPartial(inputs []string) (uuids []UUID, errs []error) {
+	var (
+		table *scopeTable
+		index int
+		err   error
+	)
+
+	uuids = make([]UUID, len(inputs))
+	table = loadScopes()
+
+	for index = range inputs {
+		err = uuids[index].readCanonical(inputs[index], table)
+		if err == nil {
+			continue
+		}
+
+		reportParseError(inputs[index], err)
+
+		if errs == nil {
+			errs = make([]error, len(inputs))
+		}
+
+		errs[index] = &ParseError{Index: index, Input: inputs[index], Err: err}
+		uuids[index] = UUID{}
+	}
+
+	return uuids, errs
+}
+
+// readCanonical sets the binary data and the scope of the uuid from the canonical hex-string using the
+// given scope table. It returns the same errors as Read.
+func (uuid *UUID) readCanonical(input string, table *scopeTable) error {
+	var (
+		err error
+	)
+
+	err = decodeCanonical(&uuid.bin, input)
+	if err != nil {
+		return err
+	}
+
+	return uuid.resolveParsed(table)
+}
+
+// resolveParsed resolves the scope of a UUID parsed from a hex-string using the given scope table. Like
+// Read, it returns ErrBadScope for every error other than ErrTableVersion.
+func (uuid *UUID) resolveParsed(table *scopeTable) error {
+	var (
+		err error
+	)
+
+	err = uuid.resolveScopeIn(table)
+	if err != nil && err != ErrTableVersion {
+		return ErrBadScope
+	}
+
+	return err
+}
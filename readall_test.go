@@ -0,0 +1,214 @@
+package uuid_test
+
+import (
+	"errors"
+	"github.com/4xoc/uuid"
+	"strings"
+	"testing"
+)
+
+// readAllInputs returns valid and malformatted strings covering every class of error returned by Read.
+func readAllInputs(t testing.TB) []string {
+	var (
+		valid string
+	)
+
+	t.Helper()
+
+	valid = mustNew(t, "two").Hex()
+
+	return []string{
+		mustNew(t, "one").Hex(),
+		valid,
+		strings.ToUpper(valid),
+		valid[:35],
+		valid + "0",
+		strings.Replace(valid, "-", "", 1) + "0",
+		strings.Replace(valid, "-", "0", -1),
+		"g" + valid[1:],
+		valid[:35] + "ä"[:1],
+		"fc000000-0000-0000-0000-0000000000fc",
+		"",
+		mustNew(t, "one").Hex(),
+	}
+}
+
+func TestReadAll(t *testing.T) {
+	var (
+		inputs []string
+		uuids  []uuid.UUID
+		parse  *uuid.ParseError
+		err    error
+	)
+
+	setupScopes(t, "one", "two")
+
+	inputs = readAllInputs(t)
+
+	uuids, err = uuid.ReadAll([]string{inputs[0], inputs[1], inputs[11]})
+	if err != nil || len(uuids) != 3 {
+		t.Fatal("expected all UUIDs to be parsed but got ", err)
+	}
+
+	for index, input := range []string{inputs[0], inputs[1], inputs[11]} {
+		if uuids[index].Hex() != input || !uuids[index].IsValid() {
+			t.Errorf("unexpected UUID %s at index %d", uuids[index].Hex(), index)
+		}
+	}
+
+	uuids, err = uuid.ReadAll(inputs)
+	if uuids != nil || !errors.As(err, &parse) || parse.Index != 2 || parse.Input != inputs[2] ||
+		!errors.Is(err, uuid.ErrBadString) {
+		t.Fatal("expected error at index 2 but got ", err)
+	}
+
+	uuids, err = uuid.ReadAll(nil)
+	if err != nil || len(uuids) != 0 {
+		t.Fatal("expected empty result but got ", err)
+	}
+}
+
+func TestReadAllPartial(t *testing.T) {
+	var (
+		inputs []string
+		uuids  []uuid.UUID
+		errs   []error
+		myUUID *uuid.UUID
+		parse  *uuid.ParseError
+		err    error
+	)
+
+	setupScopes(t, "one", "two")
+
+	inputs = readAllInputs(t)
+
+	uuids, errs = uuid.ReadAllPartial(inputs)
+	if len(uuids) != len(inputs) || len(errs) != len(inputs) {
+		t.Fatal("unexpected result length ", len(uuids), len(errs))
+	}
+
+	for index, input := range inputs {
+		myUUID, err = uuid.Read(input)
+
+		if err == nil {
+			if errs[index] != nil || uuids[index].Hex() != myUUID.Hex() || uuids[index].Scope() != myUUID.Scope() {
+				t.Errorf("unexpected result at index %d: %v", index, errs[index])
+			}
+
+			continue
+		}
+
+		if !errors.As(errs[index], &parse) || parse.Index != index || parse.Input != input || parse.Err != err {
+			t.Errorf("expected error %v at index %d but got %v", err, index, errs[index])
+		}
+
+		if uuids[index] != (uuid.UUID{}) {
+			t.Errorf("expected uninitialized UUID at index %d", index)
+		}
+	}
+
+	uuids, errs = uuid.ReadAllPartial(inputs[:2])
+	if errs != nil || len(uuids) != 2 {
+		t.Fatal("expected no errors but got ", errs)
+	}
+}
+
+func TestReadAllTableVersion(t *testing.T) {
+	var (
+		input string
+		errs  []error
+		err   error
+	)
+
+	setupScopes(t, "one")
+
+	input = mustNew(t, "one").Hex()
+
+	if err = uuid.SetScopeTableVersion(5); err != nil {
+		t.Fatal(err)
+	}
+
+	uuid.SetStrictTableVersion(true)
+	t.Cleanup(func() { uuid.SetStrictTableVersion(false) })
+
+	_, err = uuid.Read(input)
+	_, errs = uuid.ReadAllPartial([]string{input})
+
+	//the low bits of byte 1 are random, so the UUID may carry the version by chance
+	if err != nil && !errors.Is(errs[0], uuid.ErrTableVersion) {
+		t.Fatal("expected ErrTableVersion but got ", errs[0])
+	}
+}
+
+func TestReadAllNoScopes(t *testing.T) {
+	var (
+		inputs []string
+		err    error
+	)
+
+	setupScopes(t, "one")
+	inputs = []string{mustNew(t, "one").Hex()}
+
+	uuid.ResetScopes()
+
+	if _, err = uuid.ReadAll(inputs); !errors.Is(err, uuid.ErrBadScope) {
+		t.Fatal("expected ErrBadScope but got ", err)
+	}
+}
+
+// benchmarkInputs returns n canonical hex-strings of UUIDs.
+func benchmarkInputs(b *testing.B, n int) []string {
+	var (
+		inputs []string
+	)
+
+	setupScopes(b, "one")
+
+	inputs = make([]string, n)
+	for index := range inputs {
+		inputs[index] = mustNew(b, "one").Hex()
+	}
+
+	return inputs
+}
+
+func BenchmarkReadAll(b *testing.B) {
+	var (
+		inputs []string
+	)
+
+	inputs = benchmarkInputs(b, 10000)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := uuid.ReadAll(inputs); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkReadLoop(b *testing.B) {
+	var (
+		inputs []string
+		uuids  []*uuid.UUID
+	)
+
+	inputs = benchmarkInputs(b, 10000)
+	uuids = make([]*uuid.UUID, len(inputs))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for index := range inputs {
+			myUUID, err := uuid.Read(inputs[index])
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			uuids[index] = myUUID
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package uuid
+
+// unknownScope is stored as scope of UUIDs read by ReadAny whose scope byte isn't known. It can't be set
+// as scope name with SetScopes, and Scope returns the empty string for it, while other methods treat such
+// UUIDs as initialized.
+const unknownScope string = UnknownScope
+
+// ReadAny works like Read but accepts UUIDs of unknown scopes, e.g. IDs generated by other services using
+// a scope table that isn't loaded. The hex-string is validated like by Read, returning ErrBadString for
+// anything that isn't a UUID.
+//
+// If the scope byte isn't known (or the UUID is of another scope table version while strict table
+// versions are enabled), Scope returns the empty string and ScopeMatches false. The UUID can still be
+// formatted, marshaled and written to databases to forward it as it is, but parsing it back with Read,
+// Scan or any of the decoders fails like for every other UUID of an unknown scope. Validate returns
+// ErrMissingScope for such UUIDs.
+func ReadAny(input string) (*UUID, error) {
+	var (
+		uuid UUID
+		err  error
+	)
+
+	err = decodeCanonical(&uuid.bin, input)
+	if err != nil {
+		reportParseError(input, err)
+		return nil, err
+	}
+
+	if uuid.resolveScope() != nil {
+		uuid.scope = unknownScope
+	}
+
+	return &uuid, nil
+}
@@ -0,0 +1,128 @@
+package uuid_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/4xoc/uuid"
+	"testing"
+)
+
+func TestReadAny(t *testing.T) {
+	var (
+		known  *uuid.UUID
+		strict *uuid.UUID
+		myUUID *uuid.UUID
+		err    error
+	)
+
+	setupScopes(t, "one", "two")
+
+	known = mustNew(t, "two")
+
+	//UUIDs of known scopes are read like by Read
+	strict, err = uuid.Read(known.Hex())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	myUUID, err = uuid.ReadAny(known.Hex())
+	if err != nil || *myUUID != *strict || myUUID.Scope() != "two" || !myUUID.ScopeMatches([]string{"two"}) {
+		t.Error("unexpected result for a known scope ", myUUID.DebugString(), err)
+	}
+
+	//neither layout knows the scope byte 0xfc
+	for _, input := range []string{"fc000000-0000-0000-0000-0000000000fc", "fcfcfcfc-fcfc-fcfc-fcfc-fcfcfcfcfcfc"} {
+		strict, err = uuid.Read(input)
+		if err != uuid.ErrBadScope || strict != nil {
+			t.Error("expected Read of ", input, " to fail with ErrBadScope but got ", strict.DebugString(), err)
+		}
+
+		myUUID, err = uuid.ReadAny(input)
+		if err != nil || myUUID.Scope() != "" || myUUID.Hex() != input || myUUID.DebugString() != "unscoped/"+input {
+			t.Error("unexpected result for ", input, ": ", myUUID.DebugString(), err)
+		}
+
+		if myUUID.ScopeMatches([]string{"", "one", "two"}) {
+			t.Error("UUID of an unknown scope matches")
+		}
+	}
+
+	//syntax errors are the same for both
+	for _, input := range []string{"", "05a1b2c3-d4e5", "FC000000-0000-0000-0000-0000000000FC", "fc000000000000000000000000000000fc"} {
+		if _, err = uuid.Read(input); err != uuid.ErrBadString {
+			t.Error("expected Read of ", input, " to fail with ErrBadString but got ", err)
+		}
+
+		if myUUID, err = uuid.ReadAny(input); err != uuid.ErrBadString || myUUID != nil {
+			t.Error("expected ReadAny of ", input, " to fail with ErrBadString but got ", myUUID.DebugString(), err)
+		}
+	}
+}
+
+func TestReadAnyForwarding(t *testing.T) {
+	var (
+		input  string
+		myUUID *uuid.UUID
+		bin    [16]byte
+		data   []byte
+		value  interface{}
+		err    error
+	)
+
+	setupScopes(t, "one", "two")
+
+	input = "fc0a1b2c-3d4e-5f60-7182-93a4b5c6d7fc"
+
+	myUUID, err = uuid.ReadAny(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bin = myUUID.Bin()
+
+	if value, err = myUUID.Value(); err != nil || value != input {
+		t.Error("Value results in ", value, err)
+	}
+
+	if data, err = myUUID.MarshalText(); err != nil || string(data) != input {
+		t.Error("MarshalText results in ", string(data), err)
+	}
+
+	if data, err = json.Marshal(myUUID); err != nil || string(data) != `"`+input+`"` {
+		t.Error("json.Marshal results in ", string(data), err)
+	}
+
+	if data, err = myUUID.AppendBinary(nil); err != nil || !bytes.Equal(data, bin[:]) || bin[0] != 0xfc {
+		t.Error("AppendBinary results in ", data, err)
+	}
+
+	//the strict decoders still reject the UUID
+	if err = json.Unmarshal([]byte(`"`+input+`"`), new(uuid.UUID)); err == nil {
+		t.Error("expected json.Unmarshal to fail")
+	}
+
+	if err = new(uuid.UUID).Scan(input); err == nil {
+		t.Error("expected Scan to fail")
+	}
+
+	//without a scope name, nothing can be derived from the scope
+	if err = myUUID.Validate(); err != uuid.ErrMissingScope {
+		t.Error("expected Validate to fail with ErrMissingScope but got ", err)
+	}
+
+	if myUUID.Prefixed() != "" {
+		t.Error("expected no prefixed form but got ", myUUID.Prefixed())
+	}
+
+	if _, err = myUUID.MarshalEnvelope(); err != uuid.ErrBadScope {
+		t.Error("expected MarshalEnvelope to fail with ErrBadScope but got ", err)
+	}
+
+	if err = myUUID.Regenerate(); err != uuid.ErrBadScope || myUUID.Hex() != input {
+		t.Error("expected Regenerate to fail with ErrBadScope but got ", myUUID.DebugString(), err)
+	}
+
+	if report := uuid.Analyze([]*uuid.UUID{myUUID}); report.Count != 1 || report.Scopes[""] != 1 {
+		t.Error("unexpected report ", report.Scopes)
+	}
+}
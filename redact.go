@@ -0,0 +1,93 @@
+package uuid
+
+import (
+	"log/slog"
+	"sync/atomic"
+)
+
+var (
+	// redactedScopes holds the scopes set with SetRedactedScopes, nil if there are none.
+	redactedScopes atomic.Pointer[map[string]struct{}]
+)
+
+// SetRedactedScopes defines the scopes whose UUIDs are printed in the redacted form (see Redacted) by
+// DebugString and LogValue, replacing the scopes set before. UUIDs of all other scopes are printed in
+// full. Calling it without any scope prints all UUIDs in full again. Aliases (see AliasScope) are
+// resolved to their scope if they are known at the time of the call.
+//
+// It is safe to call SetRedactedScopes while UUIDs are printed concurrently.
+func SetRedactedScopes(scopes ...string) {
+	var (
+		table    *scopeTable
+		redacted map[string]struct{}
+		name     string
+		ok       bool
+	)
+
+	if len(scopes) == 0 {
+		redactedScopes.Store(nil)
+		return
+	}
+
+	table = loadScopes()
+	redacted = make(map[string]struct{}, len(scopes))
+
+	for _, scope := range scopes {
+		if name, _, ok = table.resolve(scope); ok {
+			scope = name
+		}
+
+		redacted[scope] = struct{}{}
+	}
+
+	redactedScopes.Store(&redacted)
+}
+
+// Redacted returns the scope and a shortened hex-string of the UUID separated by a slash, keeping only
+// the first 8 and the last 5 characters of the hex-string with the groups in between replaced by "…"
+// (U+2026), e.g. "user/9c4fb1d0-…-…-…-…34dae". Like DebugString, UUIDs without a resolved scope are
+// printed as "unscoped/<redacted hex>" and nil UUIDs as "<nil>". The format is stable.
+//
+// The redacted form is meant for logs that must not hold full identifiers while still allowing to
+// correlate log lines.
+func (uuid *UUID) Redacted() string {
+	var (
+		buf [36]byte
+		hex []byte
+	)
+
+	if uuid == nil {
+		return "<nil>"
+	}
+
+	hex = appendHex(buf[:0], uuid.bin[:])
+
+	if uuid.scope == "" || uuid.scope == unknownScope {
+		return "unscoped/" + string(hex[:9]) + "…-…-…-…" + string(hex[31:])
+	}
+
+	return uuid.scope + "/" + string(hex[:9]) + "…-…-…-…" + string(hex[31:])
+}
+
+// isRedacted returns true if the UUID is of a scope set with SetRedactedScopes.
+func (uuid *UUID) isRedacted() bool {
+	var (
+		redacted *map[string]struct{}
+		ok       bool
+	)
+
+	redacted = redactedScopes.Load()
+	if redacted == nil || uuid == nil {
+		return false
+	}
+
+	_, ok = (*redacted)[uuid.scope]
+
+	return ok
+}
+
+// LogValue implements slog.LogValuer, logging the UUID as returned by DebugString. UUIDs of scopes set
+// with SetRedactedScopes are logged in the redacted form.
+func (uuid *UUID) LogValue() slog.Value {
+	return slog.StringValue(uuid.DebugString())
+}
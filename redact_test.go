@@ -0,0 +1,99 @@
+package uuid_test
+
+import (
+	"bytes"
+	"github.com/4xoc/uuid"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestRedacted(t *testing.T) {
+	var (
+		nilPtr *uuid.UUID
+		myUUID *uuid.UUID
+		other  *uuid.UUID
+		buf    bytes.Buffer
+		logger *slog.Logger
+	)
+
+	setupScopes(t, "one", "user")
+	uuid.AliasScope("user", "member")
+
+	//valid in both layouts
+	myUUID = mustRead(t, "0529a1d0-84f3-4d8d-b6cc-682d1ca34d05")
+	other = mustRead(t, "0029a1d0-84f3-4d8d-b6cc-682d1ca34d01")
+
+	if myUUID.Redacted() != "user/0529a1d0-…-…-…-…34d05" {
+		t.Error("unexpected redacted string ", myUUID.Redacted())
+	}
+
+	if nilPtr.Redacted() != "<nil>" || (&uuid.UUID{}).Redacted() != "unscoped/00000000-…-…-…-…00000" {
+		t.Error("unexpected redacted string for nil or uninitialized UUID")
+	}
+
+	//nothing is redacted by default
+	if myUUID.DebugString() != "user/0529a1d0-84f3-4d8d-b6cc-682d1ca34d05" {
+		t.Error("unexpected debug string ", myUUID.DebugString())
+	}
+
+	uuid.SetRedactedScopes("member")
+
+	if myUUID.DebugString() != myUUID.Redacted() || other.DebugString() != "one/0029a1d0-84f3-4d8d-b6cc-682d1ca34d01" {
+		t.Error("unexpected debug strings ", myUUID.DebugString(), other.DebugString())
+	}
+
+	logger = slog.New(slog.NewTextHandler(&buf, nil))
+	logger.Info("test", "user", myUUID, "other", other)
+
+	if !strings.Contains(buf.String(), "user=user/0529a1d0-…-…-…-…34d05") ||
+		!strings.Contains(buf.String(), "other=one/0029a1d0-84f3-4d8d-b6cc-682d1ca34d01") {
+		t.Error("unexpected log output ", buf.String())
+	}
+
+	uuid.SetRedactedScopes()
+
+	if myUUID.DebugString() != "user/0529a1d0-84f3-4d8d-b6cc-682d1ca34d05" {
+		t.Error("expected redaction to be disabled but got ", myUUID.DebugString())
+	}
+
+	if nilPtr.LogValue().String() != "<nil>" {
+		t.Error("unexpected log value for nil UUID ", nilPtr.LogValue())
+	}
+}
+
+func TestRedactedConcurrent(t *testing.T) {
+	var (
+		wg     sync.WaitGroup
+		myUUID *uuid.UUID
+	)
+
+	setupScopes(t, "one")
+
+	myUUID = mustNew(t, "one")
+
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+
+		for range 1000 {
+			uuid.SetRedactedScopes("one")
+			uuid.SetRedactedScopes()
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		for range 1000 {
+			if debug := myUUID.DebugString(); debug != myUUID.Redacted() && debug != "one/"+myUUID.Hex() {
+				t.Error("unexpected debug string ", debug)
+				return
+			}
+		}
+	}()
+
+	wg.Wait()
+}
@@ -0,0 +1,36 @@
+package uuid
+
+// ResetForTesting clears the scopes and all other global configuration (default scope, node ID, table
+// version, hooks, counters, well-known UUIDs, sub-scopes, scope infos and redacted scopes) so that
+// SetScopes can be called again. It only exists for tests, which usually use it via the uuidtest
+// package, and must not be called while other goroutines use the package.
+func ResetForTesting() {
+	currentScopes.Store(nil)
+	scopeLayout = ScopeLeading
+	scopeWidth = 6
+	defaultScope.Store(nil)
+	nodeID.Store(nil)
+	tableVersion.Store(nil)
+	strictTableVersion.Store(false)
+	generateHook.Store(nil)
+	parseErrorHook.Store(nil)
+	redactedScopes.Store(nil)
+
+	countersEnabled.Store(false)
+	for index := range counters {
+		counters[index].Store(0)
+	}
+
+	wellKnown.mu.Lock()
+	wellKnown.byName = nil
+	wellKnown.byBin = nil
+	wellKnown.mu.Unlock()
+
+	subScopes.mu.Lock()
+	subScopes.names = nil
+	subScopes.mu.Unlock()
+
+	scopeInfos.mu.Lock()
+	scopeInfos.byName = nil
+	scopeInfos.mu.Unlock()
+}
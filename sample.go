@@ -0,0 +1,79 @@
+package uuid
+
+import (
+	"math/bits"
+)
+
+// sampleHash returns the hash Sample and SampleN are derived from: PayloadUint64 mixed with the
+// finalizer of SplitMix64. Mixing makes the decision independent of the shard assigned by ShardOf,
+// which uses the same bytes unmixed.
+func (uuid *UUID) sampleHash() uint64 {
+	var (
+		hash uint64
+	)
+
+	hash = uuid.PayloadUint64()
+	hash ^= hash >> 30
+	hash *= 0xbf58476d1ce4e5b9
+	hash ^= hash >> 27
+	hash *= 0x94d049bb133111eb
+	hash ^= hash >> 31
+
+	return hash
+}
+
+// Sample reports whether the UUID is sampled at the given rate. The decision only depends on the bytes
+// used by PayloadUint64, which don't contain the scope, so the same fraction of UUIDs is sampled in
+// every scope and every service sampling the same UUID at the same rate comes to the same decision. A
+// UUID sampled at a rate is sampled at every higher rate as well.
+//
+// The decision is made by mixing the big endian unsigned integer of PayloadUint64 with the finalizer
+// of SplitMix64 (x ^= x >> 30; x *= 0xbf58476d1ce4e5b9; x ^= x >> 27; x *= 0x94d049bb133111eb;
+// x ^= x >> 31), mapping the upper 53 bits of the result to a float64 in [0, 1) and comparing it to rate.
+// This derivation is guaranteed to stay the same in future versions.
+//
+// A rate of 0 or less (and NaN) never samples, a rate of 1 or more always does. Nil and uninitialized
+// UUIDs are only sampled at a rate of 1 or more.
+func (uuid *UUID) Sample(rate float64) bool {
+	if rate >= 1 {
+		return true
+	}
+
+	if !(rate > 0) || uuid == nil || uuid.scope == "" {
+		return false
+	}
+
+	return float64(uuid.sampleHash()>>11)/(1<<53) < rate
+}
+
+// SampleN reports whether the UUID is sampled at the ratio n out of outOf, e.g. 1 out of 1000. Like
+// Sample, the decision is consistent across scopes and services and a UUID sampled at a ratio is
+// sampled at every higher ratio as well.
+//
+// The hash of Sample is mapped to [0, outOf) by taking the upper 64 bits of its 128 bit product with
+// outOf, and the UUID is sampled if the result is less than n. This derivation is guaranteed to stay
+// the same in future versions.
+//
+// If n is 0 or outOf is 0, the UUID is never sampled; if n is outOf or more, it always is. Nil and
+// uninitialized UUIDs are only sampled in the latter case.
+func (uuid *UUID) SampleN(n uint64, outOf uint64) bool {
+	var (
+		bucket uint64
+	)
+
+	if outOf == 0 || n == 0 {
+		return false
+	}
+
+	if n >= outOf {
+		return true
+	}
+
+	if uuid == nil || uuid.scope == "" {
+		return false
+	}
+
+	bucket, _ = bits.Mul64(uuid.sampleHash(), outOf)
+
+	return bucket < n
+}
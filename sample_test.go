@@ -0,0 +1,122 @@
+package uuid_test
+
+import (
+	"github.com/4xoc/uuid"
+	"math"
+	"testing"
+)
+
+func TestSample(t *testing.T) {
+	var (
+		nilPtr *uuid.UUID
+		myUUID *uuid.UUID
+	)
+
+	setupScopes(t, "one", "two")
+
+	myUUID = mustNew(t, "one")
+
+	for _, rate := range []float64{0, -1, math.NaN(), math.Inf(-1)} {
+		if myUUID.Sample(rate) || nilPtr.Sample(rate) {
+			t.Error("expected no sampling at rate ", rate)
+		}
+	}
+
+	for _, rate := range []float64{1, 2, math.Inf(1)} {
+		if !myUUID.Sample(rate) || !nilPtr.Sample(rate) || !(&uuid.UUID{}).Sample(rate) {
+			t.Error("expected sampling at rate ", rate)
+		}
+	}
+
+	if nilPtr.Sample(0.99) || (&uuid.UUID{}).Sample(0.99) {
+		t.Error("expected nil and uninitialized UUIDs not to be sampled")
+	}
+
+	if myUUID.SampleN(0, 10) || myUUID.SampleN(1, 0) || !myUUID.SampleN(10, 10) || !myUUID.SampleN(11, 10) ||
+		nilPtr.SampleN(9, 10) || !nilPtr.SampleN(10, 10) {
+		t.Error("unexpected decisions for edge cases of SampleN")
+	}
+}
+
+// TestSampleStable pins the derivation of the sampling decision, which must never change.
+func TestSampleStable(t *testing.T) {
+	var (
+		myUUID *uuid.UUID
+		other  *uuid.UUID
+	)
+
+	setupScopes(t, "one", "two")
+
+	//the hash of both UUIDs maps to 0.92671031... and to 926 out of 1000, as the bytes used by
+	//PayloadUint64 are the same
+	if testLayout == uuid.ScopeTrailing {
+		myUUID = mustRead(t, "0029a1d0-84f3-4db6-cc68-2d1ca34dae05")
+		other = mustRead(t, "0029a1d0-84f3-4db6-cc68-2d1ca34dae01")
+	} else {
+		myUUID = mustRead(t, "0529a1d0-84f3-4d8d-b6cc-682d1ca34dae")
+		other = mustRead(t, "0029a1d0-84f3-4d8d-b6cc-682d1ca34dae")
+	}
+
+	if myUUID.Sample(0.9267) || !myUUID.Sample(0.9268) || myUUID.SampleN(926, 1000) || !myUUID.SampleN(927, 1000) {
+		t.Error("the sampling decision changed")
+	}
+
+	//the scope doesn't change the decision
+	if other.Sample(0.9267) || !other.Sample(0.9268) || other.SampleN(926, 1000) || !other.SampleN(927, 1000) {
+		t.Error("the sampling decision depends on the scope")
+	}
+}
+
+// TestSampleRate checks that the realized rate of a large sample matches the requested rate in every
+// scope.
+func TestSampleRate(t *testing.T) {
+	const (
+		count = 100000
+	)
+
+	setupScopes(t, "one", "two")
+
+	for _, scope := range []string{"one", "two"} {
+		uuids, err := uuid.NewBatch(scope, count)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, ratio := range []struct{ n, outOf uint64 }{{1, 100}, {1, 10}, {1, 2}, {9, 10}} {
+			var (
+				sampled   int
+				sampledN  int
+				notNested bool
+			)
+
+			rate := float64(ratio.n) / float64(ratio.outOf)
+
+			for _, myUUID := range uuids {
+				if myUUID.Sample(rate) {
+					sampled++
+
+					//UUIDs sampled at a rate are sampled at every higher one
+					notNested = notNested || !myUUID.Sample(math.Nextafter(rate, 1))
+				}
+
+				if myUUID.SampleN(ratio.n, ratio.outOf) {
+					sampledN++
+
+					notNested = notNested || !myUUID.SampleN(ratio.n+1, ratio.outOf)
+				}
+			}
+
+			//allowing 5 standard deviations of the binomial distribution
+			tolerance := 5 * math.Sqrt(count*rate*(1-rate))
+
+			if math.Abs(float64(sampled)-count*rate) > tolerance || math.Abs(float64(sampledN)-count*rate) > tolerance {
+				t.Errorf("scope %s: expected about %.0f sampled UUIDs at rate %v but got %d and %d", scope, count*rate,
+					rate, sampled, sampledN)
+			}
+
+			if notNested {
+				t.Error("scope ", scope, ": UUIDs sampled at rate ", rate, " are not sampled at a higher rate")
+			}
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package uuid
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// ScanAll reads the first column of every remaining row of rows via Scan, so rows may hold the UUID in
+// any representation Scan accepts, even mixed within the same result. Further columns are ignored. Like
+// for Scan, NULL results in an uninitialized UUID.
+//
+// Reading stops at the first row that fails to scan, returning a *ParseError with the number of the row
+// (starting at 0) as Index. Errors of the iteration itself are returned as reported by rows.Err. Rows is
+// not closed except by reaching its end, which is done by database/sql.
+func ScanAll(rows *sql.Rows) ([]*UUID, error) {
+	var (
+		uuids []*UUID
+		err   error
+	)
+
+	err = ScanAllInto(rows, &uuids)
+	if err != nil {
+		return nil, err
+	}
+
+	return uuids, nil
+}
+
+// ScanAllInto works like ScanAll but stores the UUIDs in *dst, reusing its capacity. On failure, *dst
+// holds the UUIDs of all rows before the failing one.
+func ScanAllInto(rows *sql.Rows, dst *[]*UUID) error {
+	var (
+		columns []string
+		dest    []interface{}
+		src     interface{}
+		uuid    *UUID
+		row     int
+		err     error
+	)
+
+	*dst = (*dst)[:0]
+
+	columns, err = rows.Columns()
+	if err != nil {
+		return err
+	}
+
+	//additional columns are scanned into a placeholder that accepts everything
+	dest = make([]interface{}, max(len(columns), 1))
+	dest[0] = &src
+
+	for index := 1; index < len(dest); index++ {
+		dest[index] = new(interface{})
+	}
+
+	for ; rows.Next(); row++ {
+		err = rows.Scan(dest...)
+		if err != nil {
+			return err
+		}
+
+		uuid = new(UUID)
+
+		err = uuid.Scan(src)
+		if err != nil {
+			return &ParseError{Index: row, Input: scanInput(src), Err: err}
+		}
+
+		*dst = append(*dst, uuid)
+	}
+
+	return rows.Err()
+}
+
+// scanInput returns the source of a failed row in the form used as Input of a ParseError.
+func scanInput(src interface{}) string {
+	switch src := src.(type) {
+	case string:
+		return src
+	case []byte:
+		return string(src)
+	default:
+		return fmt.Sprint(src)
+	}
+}
@@ -0,0 +1,174 @@
+package uuid_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"github.com/4xoc/uuid"
+	"testing"
+)
+
+func TestScanAll(t *testing.T) {
+	var (
+		db     *sql.DB
+		first  *uuid.UUID
+		second *uuid.UUID
+		bin    [16]byte
+		uuids  []*uuid.UUID
+		err    error
+	)
+
+	setupScopes(t, "one", "two")
+
+	first = mustNew(t, "one")
+	second = mustNew(t, "two")
+	bin = second.Bin()
+
+	db = sql.OpenDB(&recordingConnector{})
+	defer db.Close()
+
+	//text and binary values mixed across rows
+	recorded = []driver.Value{first.Hex(), bin[:], []byte(first.Hex())}
+
+	uuids, err = scanAll(t, db, "SELECT ROWS")
+	if err != nil || len(uuids) != 3 || *uuids[0] != *first || *uuids[1] != *second || *uuids[2] != *first {
+		t.Error("unexpected result ", uuids, err)
+	}
+
+	if uuids[0] == uuids[2] {
+		t.Error("rows share the same UUID")
+	}
+
+	//additional columns are ignored
+	recorded = []driver.Value{second.Hex(), "name", int64(42)}
+
+	uuids, err = scanAll(t, db, "SELECT")
+	if err != nil || len(uuids) != 1 || *uuids[0] != *second {
+		t.Error("unexpected result with additional columns ", uuids, err)
+	}
+
+	//NULL results in the zero value like for Scan
+	recorded = []driver.Value{nil, second.Hex()}
+
+	uuids, err = scanAll(t, db, "SELECT ROWS")
+	if err != nil || len(uuids) != 2 || *uuids[0] != (uuid.UUID{}) || *uuids[1] != *second {
+		t.Error("unexpected result with NULL ", uuids, err)
+	}
+
+	recorded = nil
+
+	uuids, err = scanAll(t, db, "SELECT ROWS")
+	if err != nil || len(uuids) != 0 {
+		t.Error("unexpected result of an empty result set ", uuids, err)
+	}
+}
+
+func TestScanAllErrors(t *testing.T) {
+	var (
+		db         *sql.DB
+		first      *uuid.UUID
+		uuids      []*uuid.UUID
+		parseError *uuid.ParseError
+		err        error
+	)
+
+	setupScopes(t, "one", "two")
+
+	first = mustNew(t, "one")
+
+	db = sql.OpenDB(&recordingConnector{})
+	defer db.Close()
+
+	recorded = []driver.Value{first.Hex(), "fc000000-0000-0000-0000-0000000000fc", first.Hex()}
+
+	uuids, err = scanAll(t, db, "SELECT ROWS")
+	if !errors.As(err, &parseError) || parseError.Index != 1 || parseError.Input != "fc000000-0000-0000-0000-0000000000fc" ||
+		!errors.Is(err, uuid.ErrBadScope) || uuids != nil {
+		t.Error("unexpected result of an unknown scope ", uuids, err)
+	}
+
+	recorded = []driver.Value{first.Hex(), 42}
+
+	if _, err = scanAll(t, db, "SELECT ROWS"); !errors.As(err, &parseError) || parseError.Index != 1 || parseError.Input != "42" {
+		t.Error("unexpected result of an integer ", err)
+	}
+
+	//errors of the iteration are returned as they are
+	recorded = []driver.Value{first.Hex(), first.Hex()}
+
+	uuids, err = scanAll(t, db, "SELECT ROWS BROKEN")
+	if err != errConnection || uuids != nil {
+		t.Error("unexpected result of a broken connection ", uuids, err)
+	}
+}
+
+func TestScanAllInto(t *testing.T) {
+	var (
+		db     *sql.DB
+		rows   *sql.Rows
+		first  *uuid.UUID
+		second *uuid.UUID
+		uuids  []*uuid.UUID
+		err    error
+	)
+
+	setupScopes(t, "one", "two")
+
+	first = mustNew(t, "one")
+	second = mustNew(t, "two")
+
+	db = sql.OpenDB(&recordingConnector{})
+	defer db.Close()
+
+	//the previous content is replaced
+	uuids = make([]*uuid.UUID, 1, 8)
+	recorded = []driver.Value{first.Hex(), second.Hex(), "05a1b2c3-d4e5"}
+
+	rows, err = db.Query("SELECT ROWS")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = uuid.ScanAllInto(rows, &uuids)
+	if err == nil || len(uuids) != 2 || cap(uuids) != 8 || *uuids[0] != *first || *uuids[1] != *second {
+		t.Error("unexpected result ", uuids, err)
+	}
+
+	//rows are left to the caller
+	if err = rows.Close(); err != nil {
+		t.Error(err)
+	}
+
+	recorded = []driver.Value{second.Hex()}
+
+	rows, err = db.Query("SELECT ROWS BROKEN")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer rows.Close()
+
+	err = uuid.ScanAllInto(rows, &uuids)
+	if err != errConnection || len(uuids) != 1 || *uuids[0] != *second {
+		t.Error("unexpected result of a broken connection ", uuids, err)
+	}
+}
+
+// scanAll runs query and reads the result with ScanAll.
+func scanAll(t *testing.T, db *sql.DB, query string) ([]*uuid.UUID, error) {
+	var (
+		rows *sql.Rows
+		err  error
+	)
+
+	t.Helper()
+
+	rows, err = db.Query(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer rows.Close()
+
+	return uuid.ScanAll(rows)
+}
@@ -0,0 +1,75 @@
+package uuid
+
+import (
+	"maps"
+	"sync"
+)
+
+// ScopeInfo describes what a scope is used for, e.g. for admin tooling. It has no effect on generating
+// or parsing UUIDs.
+type ScopeInfo struct {
+	// Description describes what the UUIDs of the scope identify.
+	Description string `json:"description,omitempty"`
+	// Owner names the team or service owning the scope.
+	Owner string `json:"owner,omitempty"`
+	// Labels holds arbitrary further information.
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+var (
+	// scopeInfos holds the ScopeInfo of each scope it has been set for.
+	scopeInfos struct {
+		mu     sync.RWMutex
+		byName map[string]ScopeInfo
+	}
+)
+
+// SetScopeInfo sets the ScopeInfo of a known scope, replacing any ScopeInfo set before. Unlike the scopes
+// themselves, it can be changed at any time. Setting it for an alias (see AliasScope) sets it for the
+// scope the alias resolves to. Labels are copied, so the map can be changed afterwards.
+func SetScopeInfo(scope string, info ScopeInfo) error {
+	var (
+		ok bool
+	)
+
+	scope, _, ok = loadScopes().resolve(scope)
+	if !ok {
+		return ErrMissingScope
+	}
+
+	info.Labels = maps.Clone(info.Labels)
+
+	scopeInfos.mu.Lock()
+	defer scopeInfos.mu.Unlock()
+
+	if scopeInfos.byName == nil {
+		scopeInfos.byName = make(map[string]ScopeInfo)
+	}
+
+	scopeInfos.byName[scope] = info
+
+	return nil
+}
+
+// LookupScopeInfo returns the ScopeInfo set for the scope or the scope an alias resolves to. The second
+// return value is false if the scope is not known or no ScopeInfo has been set for it. The labels of the
+// returned ScopeInfo are a copy.
+func LookupScopeInfo(scope string) (ScopeInfo, bool) {
+	var (
+		info ScopeInfo
+		ok   bool
+	)
+
+	scope, _, ok = loadScopes().resolve(scope)
+	if !ok {
+		return ScopeInfo{}, false
+	}
+
+	scopeInfos.mu.RLock()
+	info, ok = scopeInfos.byName[scope]
+	scopeInfos.mu.RUnlock()
+
+	info.Labels = maps.Clone(info.Labels)
+
+	return info, ok
+}
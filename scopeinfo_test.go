@@ -0,0 +1,125 @@
+package uuid_test
+
+import (
+	"encoding/json"
+	"github.com/4xoc/uuid"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestScopeInfo(t *testing.T) {
+	var (
+		info   uuid.ScopeInfo
+		labels map[string]string
+		before *uuid.UUID
+		table  uuid.ScopeTable
+		data   []byte
+		ok     bool
+		err    error
+	)
+
+	setupScopes(t, "user", "post")
+	uuid.AliasScope("user", "member")
+
+	before = mustNew(t, "user")
+
+	if err = uuid.SetScopeInfo("team", uuid.ScopeInfo{Owner: "core"}); err != uuid.ErrMissingScope {
+		t.Error("expected ErrMissingScope but got ", err)
+	}
+
+	if _, ok = uuid.LookupScopeInfo("user"); ok {
+		t.Error("expected no info before it is set")
+	}
+
+	if _, ok = uuid.LookupScopeInfo("team"); ok {
+		t.Error("expected no info for unknown scope")
+	}
+
+	labels = map[string]string{"pii": "yes"}
+
+	if err = uuid.SetScopeInfo("member", uuid.ScopeInfo{Description: "users", Owner: "identity", Labels: labels}); err != nil {
+		t.Fatal("expected info to be set but failed with error ", err.Error())
+	}
+
+	labels["pii"] = "no"
+
+	info, ok = uuid.LookupScopeInfo("user")
+	if !ok || info.Description != "users" || info.Owner != "identity" || info.Labels["pii"] != "yes" {
+		t.Error("unexpected info ", info)
+	}
+
+	info.Labels["pii"] = "no"
+
+	if info, _ = uuid.LookupScopeInfo("member"); info.Labels["pii"] != "yes" {
+		t.Error("labels of the returned info should be a copy")
+	}
+
+	//updating replaces the whole info
+	if err = uuid.SetScopeInfo("user", uuid.ScopeInfo{Owner: "accounts"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if info, _ = uuid.LookupScopeInfo("user"); info.Owner != "accounts" || info.Description != "" || info.Labels != nil {
+		t.Error("unexpected info after update ", info)
+	}
+
+	data, err = uuid.DumpScopes(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = json.Unmarshal(data, &table); err != nil || table.Scopes[0].Info == nil ||
+		table.Scopes[0].Info.Owner != "accounts" || table.Scopes[1].Info != nil {
+		t.Error("unexpected dump ", string(data))
+	}
+
+	//generating and parsing is not affected
+	if myCopy := mustRead(t, before.Hex()); myCopy.Scope() != "user" || !myCopy.IsValid() {
+		t.Error("unexpected scope ", myCopy.Scope())
+	}
+
+	if after := mustNew(t, "member"); after.Scope() != "user" || scopeByteOf(after) != scopeByteOf(before) {
+		t.Error("unexpected UUID ", after.DebugString())
+	}
+}
+
+func TestScopeInfoConcurrent(t *testing.T) {
+	var (
+		wg sync.WaitGroup
+	)
+
+	setupScopes(t, "one", "two")
+
+	for worker := range 4 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for index := range 500 {
+				if err := uuid.SetScopeInfo("one", uuid.ScopeInfo{Owner: strconv.Itoa(worker), Labels: map[string]string{"index": strconv.Itoa(index)}}); err != nil {
+					t.Error(err)
+					return
+				}
+
+				if info, ok := uuid.LookupScopeInfo("one"); !ok || info.Labels["index"] == "" {
+					t.Error("unexpected info ", info)
+					return
+				}
+
+				if _, err := uuid.DumpScopes(false); err != nil {
+					t.Error(err)
+					return
+				}
+
+				if _, err := uuid.New("two"); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,65 @@
+package uuid
+
+import (
+	"errors"
+	"strconv"
+)
+
+// maxScopeNameLength limits the length of scope names accepted by SetScopes.
+const maxScopeNameLength int = 32
+
+const (
+	// Rules a scope name can violate, used in ScopeNameError.
+	RuleScopeNameLength  string = "must not be longer than 32 characters"
+	RuleScopeNameCharset string = "must only contain the characters a-z, 0-9, '_' and '-'"
+	RuleScopeNameEmpty   string = "must not be empty"
+)
+
+// ErrBadScopeName is matched by errors.Is for every *ScopeNameError.
+var ErrBadScopeName = errors.New(ErrorBadScopeName)
+
+// ScopeNameError is returned by SetScopes and AliasScope when a scope name violates one of the naming
+// rules. errors.Is reports it as ErrBadScopeName.
+type ScopeNameError struct {
+	// Index is the position of the scope in the array passed to SetScopes, which is the index of its
+	// binary representation for ReplaceScopes. For aliases, it is the index of the canonical scope.
+	Index int
+	// Name is the offending scope name.
+	Name string
+	// Rule is the violated rule, one of the Rule* constants.
+	Rule string
+}
+
+// Error returns the error message including the offending entry and the violated rule.
+func (err *ScopeNameError) Error() string {
+	return ErrorBadScopeName + ": scope " + strconv.Itoa(err.Index) + " (" + strconv.Quote(err.Name) + ") " + err.Rule
+}
+
+// Is reports whether target is ErrBadScopeName.
+func (err *ScopeNameError) Is(target error) bool {
+	return target == ErrBadScopeName
+}
+
+// checkScopeName returns the rule violated by the given scope name or an empty string if the name is
+// valid.
+func checkScopeName(name string) string {
+	var (
+		index int
+	)
+
+	if len(name) > maxScopeNameLength {
+		return RuleScopeNameLength
+	}
+
+	for index = 0; index < len(name); index++ {
+		switch {
+		case name[index] >= 'a' && name[index] <= 'z':
+		case name[index] >= '0' && name[index] <= '9':
+		case name[index] == '_' || name[index] == '-':
+		default:
+			return RuleScopeNameCharset
+		}
+	}
+
+	return ""
+}
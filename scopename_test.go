@@ -0,0 +1,86 @@
+package uuid_test
+
+import (
+	"errors"
+	"github.com/4xoc/uuid"
+	"strings"
+	"testing"
+)
+
+func TestSetScopesNames(t *testing.T) {
+	var (
+		nameErr *uuid.ScopeNameError
+		err     error
+	)
+
+	testCases := []struct {
+		scopes [64]string
+		index  int
+		rule   string
+	}{
+		{[64]string{"user", "post_v2", "comment-1"}, 0, ""},
+		{[64]string{"user", 63: strings.Repeat("a", 32)}, 0, ""},
+		{[64]string{"user", "user "}, 1, uuid.RuleScopeNameCharset},
+		{[64]string{"user", 5: "a,b"}, 5, uuid.RuleScopeNameCharset},
+		{[64]string{"User"}, 0, uuid.RuleScopeNameCharset},
+		{[64]string{"user", 63: strings.Repeat("a", 33)}, 63, uuid.RuleScopeNameLength},
+	}
+
+	t.Cleanup(uuid.ResetScopes)
+
+	for index := range testCases {
+		uuid.ResetScopes()
+		err = uuid.SetScopes(testCases[index].scopes)
+
+		if testCases[index].rule == "" {
+			if err != nil {
+				t.Error("test case ", index, ": unexpected error ", err)
+			}
+			continue
+		}
+
+		if !errors.Is(err, uuid.ErrBadScopeName) || !errors.As(err, &nameErr) {
+			t.Error("test case ", index, ": expected ErrBadScopeName but got ", err)
+			continue
+		}
+
+		if nameErr.Index != testCases[index].index || nameErr.Rule != testCases[index].rule ||
+			nameErr.Name != testCases[index].scopes[testCases[index].index] {
+			t.Error("test case ", index, ": unexpected error ", nameErr)
+		}
+
+		//rejected scopes must not be set
+		if _, err = uuid.New("user"); err != uuid.ErrMissingScope {
+			t.Error("test case ", index, ": scopes should not have been set")
+		}
+	}
+
+	uuid.ResetScopes()
+	err = uuid.SetScopes([64]string{"user", "a,b"})
+	if err.Error() != uuid.ErrorBadScopeName+`: scope 1 ("a,b") `+uuid.RuleScopeNameCharset {
+		t.Error("unexpected error message ", err.Error())
+	}
+}
+
+func TestSetScopesUnchecked(t *testing.T) {
+	var (
+		myUUID *uuid.UUID
+		err    error
+	)
+
+	uuid.ResetScopes()
+	t.Cleanup(uuid.ResetScopes)
+
+	if err = uuid.SetScopesUnchecked([64]string{"user ", "a,b"}); err != nil {
+		t.Fatal("unexpected error ", err)
+	}
+
+	myUUID, err = uuid.New("a,b")
+	if err != nil || myUUID.Scope() != "a,b" {
+		t.Error("expected UUID of scope a,b but got ", err)
+	}
+
+	if err = uuid.SetScopesUnchecked([64]string{"user"}); err == nil || err.Error() != uuid.ErrorScopesAlreadySet {
+		t.Error("scopes should only be set once but got ", err)
+	}
+}
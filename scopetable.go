@@ -0,0 +1,176 @@
+package uuid
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// scopeTable is an immutable set of scopes. Changing the scopes replaces the whole table, so that every
+// function working on a single table sees either the old or the new scopes, never a mix of both.
+type scopeTable struct {
+	// byName maps the names of all scopes and aliases to the binary representation of the scope in
+	// `scopes` (or `wideScopes`). Aliases point to the same byte as their scope.
+	byName map[string]*byte
+	// names is the reverse lookup of byName, holding the name of each scope at the index of its binary
+	// representation (see scopeIndex). Bytes without a scope hold an empty string.
+	names [maxScopes]string
+	// deprecated marks the scopes at the same index as in names that can't be used for new UUIDs anymore
+	// (see DeprecateScope).
+	deprecated [maxScopes]bool
+}
+
+var (
+	// currentScopes holds the scope table in use, nil as long as no scopes are set.
+	currentScopes atomic.Pointer[scopeTable]
+
+	// noScopes is used in place of the scope table as long as no scopes are set.
+	noScopes = &scopeTable{}
+
+	// scopesMu serializes changes of the scope table.
+	scopesMu sync.Mutex
+)
+
+// loadScopes returns the scope table in use. If no scopes are set, an empty table with a nil byName is
+// returned.
+func loadScopes() *scopeTable {
+	var (
+		table *scopeTable
+	)
+
+	table = currentScopes.Load()
+	if table == nil {
+		return noScopes
+	}
+
+	return table
+}
+
+// scopesSet returns true if scopes have been set.
+func scopesSet() bool {
+	return currentScopes.Load() != nil
+}
+
+// resolve returns the name and the binary representation of the known scope the given scope resolves
+// to. The name is the scope itself unless it is an alias (see AliasScope). ok is false if the scope is
+// not known.
+func (table *scopeTable) resolve(scope string) (name string, scopeByte byte, ok bool) {
+	if table.byName[scope] == nil {
+		return "", 0, false
+	}
+
+	scopeByte = *table.byName[scope]
+
+	return table.names[scopeIndex(scopeByte)], scopeByte, true
+}
+
+// isAlias returns true if the given known scope is an alias of another scope.
+func (table *scopeTable) isAlias(scope string) bool {
+	var (
+		name string
+	)
+
+	name, _, _ = table.resolve(scope)
+
+	return name != scope
+}
+
+// name returns the name of the scope with the given binary representation or an empty string.
+func (table *scopeTable) name(scopeByte byte) string {
+	return table.names[scopeIndex(scopeByte)]
+}
+
+// clone returns a copy of the table that can be changed before it is stored.
+func (table *scopeTable) clone() *scopeTable {
+	var (
+		tmpTable *scopeTable
+		scope    string
+	)
+
+	tmpTable = &scopeTable{
+		byName:     make(map[string]*byte, len(table.byName)+1),
+		names:      table.names,
+		deprecated: table.deprecated,
+	}
+
+	for scope = range table.byName {
+		tmpTable.byName[scope] = table.byName[scope]
+	}
+
+	return tmpTable
+}
+
+// ReplaceScopes replaces all scopes with the given ones at runtime, e.g. when the scope configuration
+// is loaded from a config service. The map holds the binary representation of each scope as returned by
+// ScopesSeq: with 6 bit scopes (see SetScopeWidth) the low two bits must be 0. Names must follow the
+// same rules as in SetScopes and no two scopes may share a binary representation. If scopes haven't
+// been set before, ReplaceScopes sets them like SetScopes.
+//
+// The new scopes are swapped in atomically: New, Read and all other functions use either the old or the
+// new scopes, never a mix of both. Aliases (see AliasScope) and deprecations (see DeprecateScope) are
+// dropped. A ScopeFactory or Pool created before keeps generating UUIDs with the binary representation
+// of its scope at that time.
+//
+// UUIDs of scopes that are removed or whose binary representation changes can't be read as that scope
+// anymore. This is allowed, but the names of all those scopes are returned, sorted, so that callers can
+// log or reject the change.
+func ReplaceScopes(newScopes map[string]byte) ([]string, error) {
+	var (
+		table     *scopeTable
+		old       *scopeTable
+		removed   []string
+		scope     string
+		scopeByte byte
+		index     int
+		rule      string
+	)
+
+	table = &scopeTable{byName: make(map[string]*byte, len(newScopes))}
+
+	for scope, scopeByte = range newScopes {
+		if scopeByte&^scopeMask() != 0 {
+			return nil, errors.New(ErrorBadScopeByte)
+		}
+
+		index = scopeIndex(scopeByte)
+
+		rule = checkScopeName(scope)
+		if scope == "" {
+			rule = RuleScopeNameEmpty
+		}
+
+		if rule != "" {
+			return nil, &ScopeNameError{Index: index, Name: scope, Rule: rule}
+		}
+
+		if table.names[index] != "" {
+			return nil, errors.New(ErrorDuplicateScope)
+		}
+
+		table.names[index] = scope
+
+		if scopeWidth == 8 {
+			table.byName[scope] = &wideScopes[index]
+		} else {
+			table.byName[scope] = &scopes[index]
+		}
+	}
+
+	scopesMu.Lock()
+	defer scopesMu.Unlock()
+
+	old = loadScopes()
+
+	for index = range old.names {
+		if old.names[index] != "" && table.names[index] != old.names[index] {
+			removed = append(removed, old.names[index])
+		}
+	}
+
+	currentScopes.Store(table)
+
+	sort.Strings(removed)
+
+	return removed, nil
+}
@@ -0,0 +1,196 @@
+package uuid_test
+
+import (
+	"errors"
+	"github.com/4xoc/uuid"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestReplaceScopes(t *testing.T) {
+	var (
+		user    *uuid.UUID
+		post    *uuid.UUID
+		myCopy  *uuid.UUID
+		removed []string
+		nameErr *uuid.ScopeNameError
+		scopes  []string
+		err     error
+	)
+
+	setupScopes(t, "user", "post", "comment")
+
+	user = mustNew(t, "user")
+	post = mustNew(t, "post")
+
+	if err = uuid.AliasScope("user", "member"); err != nil {
+		t.Fatal(err)
+	}
+
+	//"comment" is removed, "post" moves from 0x04 to 0x0c, "tag" is new
+	removed, err = uuid.ReplaceScopes(map[string]byte{"user": 0x00, "tag": 0x04, "post": 0x0c})
+	if err != nil || !slices.Equal(removed, []string{"comment", "post"}) {
+		t.Fatal("unexpected result ", removed, " ", err)
+	}
+
+	myCopy, err = uuid.Read(user.Hex())
+	if err != nil || myCopy.Scope() != "user" {
+		t.Error("unchanged scopes should keep working: ", err)
+	}
+
+	myCopy, err = uuid.Read(post.Hex())
+	if err != nil || myCopy.Scope() != "tag" {
+		t.Error("UUIDs of moved scopes should be read with the new table ", myCopy.Scope(), " ", err)
+	}
+
+	if scopeByteOf(mustNew(t, "post")) != 0x0c {
+		t.Error("new UUIDs should use the new binary representation")
+	}
+
+	if _, err = uuid.New("comment"); err != uuid.ErrMissingScope {
+		t.Error("removed scopes should be unknown but got ", err)
+	}
+
+	if _, err = uuid.New("member"); err != uuid.ErrMissingScope {
+		t.Error("aliases should be dropped but got ", err)
+	}
+
+	for scope := range uuid.ScopesSeq() {
+		scopes = append(scopes, scope)
+	}
+
+	if !slices.Equal(scopes, []string{"user", "tag", "post"}) {
+		t.Error("unexpected scopes ", scopes)
+	}
+
+	for _, newScopes := range []map[string]byte{
+		{"user": 0x01},
+		{"user": 0x00, "post": 0x00},
+	} {
+		if _, err = uuid.ReplaceScopes(newScopes); err == nil {
+			t.Error("Expected error for ", newScopes)
+		}
+	}
+
+	_, err = uuid.ReplaceScopes(map[string]byte{"User": 0x08})
+	if !errors.As(err, &nameErr) || nameErr.Index != 2 || nameErr.Rule != uuid.RuleScopeNameCharset {
+		t.Error("Expected ScopeNameError but got ", err)
+	}
+
+	_, err = uuid.ReplaceScopes(map[string]byte{"": 0x08})
+	if !errors.As(err, &nameErr) || nameErr.Rule != uuid.RuleScopeNameEmpty {
+		t.Error("Expected ScopeNameError but got ", err)
+	}
+
+	//failed replacements keep the scopes
+	if _, err = uuid.New("tag"); err != nil {
+		t.Error("scopes should not change on errors: ", err)
+	}
+}
+
+func TestReplaceScopesUnset(t *testing.T) {
+	var (
+		removed []string
+		err     error
+	)
+
+	uuid.ResetScopes()
+	t.Cleanup(uuid.ResetScopes)
+
+	if err = uuid.SetScopeLayout(testLayout); err != nil {
+		t.Fatal(err)
+	}
+
+	removed, err = uuid.ReplaceScopes(map[string]byte{"one": 0x10})
+	if err != nil || removed != nil {
+		t.Fatal("unexpected result ", removed, " ", err)
+	}
+
+	if scopeByteOf(mustNew(t, "one")) != 0x10 {
+		t.Error("unexpected binary representation")
+	}
+
+	if err = uuid.SetScopes([64]string{"one"}); err == nil || err.Error() != uuid.ErrorScopesAlreadySet {
+		t.Error("Expected ErrorScopesAlreadySet but got ", err)
+	}
+}
+
+// TestReplaceScopesConcurrent swaps between two tables while UUIDs are generated and read. Every UUID
+// must be consistent with one of the tables. Run with -race.
+func TestReplaceScopesConcurrent(t *testing.T) {
+	var (
+		tables = []map[string]byte{
+			{"one": 0x00, "two": 0x04},
+			{"one": 0x08, "two": 0x0c},
+		}
+		wg      sync.WaitGroup
+		stop    = make(chan struct{})
+		swapped = make(chan struct{})
+	)
+
+	setupScopes(t, "one", "two")
+
+	//swapping tables until all readers are done
+	go func() {
+		defer close(swapped)
+
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+
+			if _, err := uuid.ReplaceScopes(tables[i%2]); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+	}()
+
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < 2000; j++ {
+				checkReplacedScopes(t, tables)
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(stop)
+	<-swapped
+}
+
+// checkReplacedScopes generates and reads a UUID, failing if it doesn't match any of the tables.
+func checkReplacedScopes(t *testing.T, tables []map[string]byte) {
+	var (
+		myUUID *uuid.UUID
+		myCopy *uuid.UUID
+		err    error
+	)
+
+	myUUID, err = uuid.New("two")
+	if err != nil {
+		t.Error("unexpected error ", err)
+		return
+	}
+
+	if scopeByteOf(myUUID) != tables[0]["two"] && scopeByteOf(myUUID) != tables[1]["two"] {
+		t.Error("UUID of no table ", myUUID.Hex())
+	}
+
+	//the table may have changed, so the UUID is either read as "two" or as "one" of the other table
+	myCopy, err = uuid.Read(myUUID.Hex())
+	if err != nil && err != uuid.ErrBadScope {
+		t.Error("unexpected error ", err)
+	}
+
+	if err == nil && myCopy.Scope() != "one" && myCopy.Scope() != "two" {
+		t.Error("unexpected scope ", myCopy.Scope())
+	}
+}
@@ -0,0 +1,168 @@
+package uuid
+
+import (
+	"bytes"
+	"sort"
+)
+
+// Set is a collection of unique UUIDs. UUIDs are considered equal if their binary representation is
+// equal, regardless of how they have been created. The zero value is an empty set ready to use.
+//
+// A Set is not safe for concurrent use.
+type Set struct {
+	// members maps the binary representation of each UUID to its scope.
+	members map[[16]byte]string
+}
+
+// NewSet returns a new Set containing the given UUIDs.
+func NewSet(uuids ...*UUID) *Set {
+	var (
+		set   Set
+		index int
+	)
+
+	set.members = make(map[[16]byte]string, len(uuids))
+
+	for index = range uuids {
+		set.Add(uuids[index])
+	}
+
+	return &set
+}
+
+// Add adds the UUID to the set. Nil UUIDs are ignored.
+func (set *Set) Add(uuid *UUID) {
+	if uuid == nil {
+		return
+	}
+
+	if set.members == nil {
+		set.members = make(map[[16]byte]string)
+	}
+
+	set.members[uuid.bin] = uuid.scope
+}
+
+// Contains returns true if the UUID is part of the set.
+func (set *Set) Contains(uuid *UUID) bool {
+	var (
+		ok bool
+	)
+
+	if uuid == nil {
+		return false
+	}
+
+	_, ok = set.members[uuid.bin]
+	return ok
+}
+
+// Remove removes the UUID from the set.
+func (set *Set) Remove(uuid *UUID) {
+	if uuid == nil {
+		return
+	}
+
+	delete(set.members, uuid.bin)
+}
+
+// Len returns the number of UUIDs in the set.
+func (set *Set) Len() int {
+	return len(set.members)
+}
+
+// Union returns a new set containing all UUIDs of both sets.
+func (set *Set) Union(other *Set) *Set {
+	var (
+		result *Set
+		bin    [16]byte
+		scope  string
+	)
+
+	result = &Set{members: make(map[[16]byte]string, len(set.members)+len(other.members))}
+
+	for bin, scope = range set.members {
+		result.members[bin] = scope
+	}
+
+	for bin, scope = range other.members {
+		result.members[bin] = scope
+	}
+
+	return result
+}
+
+// Intersect returns a new set containing all UUIDs that are part of both sets.
+func (set *Set) Intersect(other *Set) *Set {
+	var (
+		result *Set
+		bin    [16]byte
+		scope  string
+		ok     bool
+	)
+
+	result = &Set{members: make(map[[16]byte]string)}
+
+	for bin, scope = range set.members {
+		if _, ok = other.members[bin]; ok {
+			result.members[bin] = scope
+		}
+	}
+
+	return result
+}
+
+// FilterScope returns a new set containing all UUIDs of the given scope.
+func (set *Set) FilterScope(scope string) *Set {
+	var (
+		result *Set
+		bin    [16]byte
+		tmp    string
+	)
+
+	result = &Set{members: make(map[[16]byte]string)}
+
+	if name, _, ok := loadScopes().resolve(scope); ok {
+		scope = name
+	}
+
+	for bin, tmp = range set.members {
+		if tmp == scope {
+			result.members[bin] = tmp
+		}
+	}
+
+	return result
+}
+
+// Slice returns all UUIDs of the set sorted in ascending order (see Compare function).
+func (set *Set) Slice() []*UUID {
+	var (
+		uuids  []UUID
+		result []*UUID
+		bin    [16]byte
+		scope  string
+		index  int
+	)
+
+	uuids = make([]UUID, 0, len(set.members))
+
+	for bin, scope = range set.members {
+		uuids = append(uuids, UUID{
+			scope: scope,
+			bin:   bin,
+		})
+	}
+
+	sort.Slice(uuids, func(i, j int) bool {
+		return bytes.Compare(uuids[i].bin[:], uuids[j].bin[:]) < 0
+	})
+
+	result = make([]*UUID, len(uuids))
+
+	for index = range uuids {
+		result[index] = &uuids[index]
+	}
+
+	return result
+}
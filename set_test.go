@@ -0,0 +1,134 @@
+package uuid_test
+
+import (
+	"github.com/4xoc/uuid"
+	"slices"
+	"testing"
+)
+
+func TestSet(t *testing.T) {
+	var (
+		set    *uuid.Set
+		other  *uuid.Set
+		empty  uuid.Set
+		ones   []*uuid.UUID
+		twos   []*uuid.UUID
+		result []*uuid.UUID
+		err    error
+	)
+
+	setupScopes(t, "one", "two")
+
+	ones, err = uuid.NewBatch("one", 10)
+	if err != nil {
+		t.Fatal("Expected batch to be generated but failed with error ", err.Error())
+	}
+
+	twos, err = uuid.NewBatch("two", 10)
+	if err != nil {
+		t.Fatal("Expected batch to be generated but failed with error ", err.Error())
+	}
+
+	//the zero value is usable
+	if empty.Len() != 0 || empty.Contains(ones[0]) || len(empty.Slice()) != 0 {
+		t.Error("zero value set should be empty")
+	}
+
+	empty.Add(ones[0])
+	if !empty.Contains(ones[0]) {
+		t.Error("set should contain added UUID")
+	}
+
+	set = uuid.NewSet(ones...)
+	set.Add(nil)
+
+	if set.Len() != 10 {
+		t.Error("Expected 10 UUIDs in set but got ", set.Len())
+	}
+
+	//UUIDs are equal by value, not by pointer
+	set.Add(mustRead(t, ones[0].Hex()))
+
+	if set.Len() != 10 || !set.Contains(mustRead(t, ones[1].Hex())) {
+		t.Error("set should treat copies as the same UUID")
+	}
+
+	if set.Contains(twos[0]) || set.Contains(nil) {
+		t.Error("set shouldn't contain UUID")
+	}
+
+	set.Remove(ones[9])
+	set.Remove(nil)
+
+	if set.Len() != 9 || set.Contains(ones[9]) {
+		t.Error("UUID should have been removed")
+	}
+
+	other = uuid.NewSet(append([]*uuid.UUID{ones[0], ones[9]}, twos...)...)
+
+	if set.Union(other).Len() != 20 {
+		t.Error("Expected 20 UUIDs in union but got ", set.Union(other).Len())
+	}
+
+	result = set.Intersect(other).Slice()
+	if len(result) != 1 || !uuid.Equal(result[0], ones[0]) {
+		t.Error("Expected intersection to only contain the first UUID")
+	}
+
+	result = set.Union(other).FilterScope("two").Slice()
+	if len(result) != 10 {
+		t.Error("Expected 10 UUIDs of scope two but got ", len(result))
+	}
+
+	//slice is sorted and carries the scope and hex
+	if !slices.IsSortedFunc(result, uuid.Compare) {
+		t.Error("Slice should be sorted")
+	}
+
+	for _, myUUID := range result {
+		if myUUID.Scope() != "two" || mustRead(t, myUUID.Hex()).Bin() != myUUID.Bin() {
+			t.Error("UUID ", myUUID.Hex(), " has not been reconstructed correctly")
+		}
+	}
+}
+
+func BenchmarkSet(b *testing.B) {
+	var (
+		uuids []*uuid.UUID
+	)
+
+	setupScopes(b, "one")
+
+	uuids, _ = uuid.NewBatch("one", 100000)
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		set := uuid.NewSet()
+
+		for _, myUUID := range uuids {
+			set.Add(myUUID)
+		}
+	}
+}
+
+func BenchmarkStringMap(b *testing.B) {
+	var (
+		uuids []*uuid.UUID
+	)
+
+	setupScopes(b, "one")
+
+	uuids, _ = uuid.NewBatch("one", 100000)
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		set := make(map[string]struct{})
+
+		for _, myUUID := range uuids {
+			//copying the string as it would happen when reading IDs from a request or a DB
+			set[string([]byte(myUUID.Hex()))] = struct{}{}
+		}
+	}
+}
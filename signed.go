@@ -0,0 +1,87 @@
+package uuid
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
+// signatureSize is the number of bytes of the HMAC-SHA256 kept in signed UUIDs.
+const signatureSize int = 16
+
+var (
+	// ErrMissingSignature is returned by VerifyAndRead when the string doesn't carry a signature.
+	ErrMissingSignature = errors.New(ErrorMissingSignature)
+	// ErrBadSignature is returned by VerifyAndRead when the signature is not of the expected format,
+	// e.g. truncated.
+	ErrBadSignature = errors.New(ErrorBadSignature)
+	// ErrInvalidSignature is returned by VerifyAndRead when the signature doesn't match any key and by
+	// Deobfuscate when the check value doesn't match the key.
+	ErrInvalidSignature = errors.New(ErrorInvalidSignature)
+)
+
+// sign calculates the truncated HMAC-SHA256 of the canonical hex-string with the given key.
+func sign(hex string, key []byte) []byte {
+	var (
+		mac = hmac.New(sha256.New, key)
+	)
+
+	mac.Write([]byte(hex))
+
+	return mac.Sum(nil)[:signatureSize]
+}
+
+// Sign returns the canonical hex-string of the UUID followed by a dot and the base64url encoded first
+// 16 bytes of a HMAC-SHA256 over the hex-string, e.g. for IDs exposed in URLs that must not be tampered
+// with. See VerifyAndRead.
+//
+// If the UUID is not initialized, an empty string is returned.
+func (uuid *UUID) Sign(key []byte) string {
+	var (
+		hex string
+	)
+
+	if uuid == nil || uuid.scope == "" {
+		return ""
+	}
+
+	hex = formatHex(uuid.bin[:])
+
+	return hex + "." + base64.RawURLEncoding.EncodeToString(sign(hex, key))
+}
+
+// VerifyAndRead checks the signature of a string as returned by Sign and parses the UUID if the
+// signature matches any of the given keys. Passing more than one key allows rotating keys: sign with
+// the new key while still accepting the old one.
+//
+// Strings without a signature return ErrMissingSignature, malformatted signatures ErrBadSignature and
+// signatures not matching any key ErrInvalidSignature. The signature is checked in constant time before
+// the UUID gets parsed.
+func VerifyAndRead(input string, keys ...[]byte) (*UUID, error) {
+	var (
+		index     int
+		signature []byte
+		key       []byte
+		err       error
+	)
+
+	index = strings.LastIndexByte(input, '.')
+	if index < 0 {
+		return nil, ErrMissingSignature
+	}
+
+	signature, err = base64.RawURLEncoding.DecodeString(input[index+1:])
+	if err != nil || len(signature) != signatureSize {
+		return nil, ErrBadSignature
+	}
+
+	for _, key = range keys {
+		if hmac.Equal(signature, sign(input[:index], key)) {
+			return Read(input[:index])
+		}
+	}
+
+	return nil, ErrInvalidSignature
+}
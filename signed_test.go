@@ -0,0 +1,81 @@
+package uuid_test
+
+import (
+	"github.com/4xoc/uuid"
+	"testing"
+)
+
+func TestSign(t *testing.T) {
+	var (
+		myUUID *uuid.UUID
+		nilPtr *uuid.UUID
+		myCopy *uuid.UUID
+		signed string
+		oldKey = []byte("old secret")
+		newKey = []byte("new secret")
+		err    error
+	)
+
+	setupScopes(t, "one", "two")
+
+	if nilPtr.Sign(newKey) != "" {
+		t.Error("nil UUID should return an empty string")
+	}
+
+	myUUID, _ = uuid.New("two")
+	signed = myUUID.Sign(newKey)
+
+	if signed[:36] != myUUID.Hex() || signed[36] != '.' || len(signed) != 36+1+22 {
+		t.Fatal("unexpected signed string ", signed)
+	}
+
+	myCopy, err = uuid.VerifyAndRead(signed, newKey)
+	if err != nil || myCopy.Hex() != myUUID.Hex() || myCopy.Scope() != "two" {
+		t.Fatal("signed string should round-trip")
+	}
+
+	//key rotation
+	myCopy, err = uuid.VerifyAndRead(myUUID.Sign(oldKey), newKey, oldKey)
+	if err != nil || myCopy.Hex() != myUUID.Hex() {
+		t.Error("signature with old key should be accepted")
+	}
+
+	testCases := []struct {
+		input string
+		keys  [][]byte
+		err   error
+	}{
+		{myUUID.Hex(), [][]byte{newKey}, uuid.ErrMissingSignature},
+		{signed[:len(signed)-1], [][]byte{newKey}, uuid.ErrBadSignature},
+		{signed + "A", [][]byte{newKey}, uuid.ErrBadSignature},
+		{signed[:37] + "!" + signed[38:], [][]byte{newKey}, uuid.ErrBadSignature},
+		{signed, [][]byte{oldKey}, uuid.ErrInvalidSignature},
+		{signed, nil, uuid.ErrInvalidSignature},
+		//tampered UUID with the original signature
+		{mustNew(t, "two").Hex() + signed[36:], [][]byte{newKey}, uuid.ErrInvalidSignature},
+	}
+
+	for index := range testCases {
+		_, err = uuid.VerifyAndRead(testCases[index].input, testCases[index].keys...)
+		if err != testCases[index].err {
+			t.Error("test case ", index, ": expected error ", testCases[index].err, " but got ", err)
+		}
+	}
+}
+
+// mustNew generates a new UUID of the given scope or fails the test.
+func mustNew(t testing.TB, scope string) *uuid.UUID {
+	var (
+		myUUID *uuid.UUID
+		err    error
+	)
+
+	t.Helper()
+
+	myUUID, err = uuid.New(scope)
+	if err != nil {
+		t.Fatal("Expected UUID to be generated but failed with error ", err.Error())
+	}
+
+	return myUUID
+}
@@ -0,0 +1,167 @@
+package uuid
+
+import (
+	"bufio"
+	"errors"
+	"io"
+)
+
+// Encoder writes UUIDs as a stream of fixed 16 bytes records.
+type Encoder struct {
+	w io.Writer
+}
+
+// NewEncoder returns an Encoder writing to w. Every UUID results in a single write, so wrapping w in a
+// bufio.Writer is recommended for large streams.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w}
+}
+
+// Encode writes the binary representation of the UUID to the stream.
+func (encoder *Encoder) Encode(uuid *UUID) error {
+	var (
+		err error
+	)
+
+	if uuid == nil || uuid.scope == "" {
+		return errors.New(ErrorUninitializedUUID)
+	}
+
+	_, err = encoder.w.Write(uuid.bin[:])
+	return err
+}
+
+// EncodeAll writes all UUIDs to the stream, stopping at the first error.
+func (encoder *Encoder) EncodeAll(uuids []*UUID) error {
+	var (
+		index int
+		err   error
+	)
+
+	for index = range uuids {
+		err = encoder.Encode(uuids[index])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WriteTo writes the 16 bytes of the binary representation to w and returns the number of bytes written.
+// A short write without an error from w returns io.ErrShortWrite. If the UUID is nil or not
+// initialized, ErrorUninitializedUUID is returned and nothing is written.
+func (uuid *UUID) WriteTo(w io.Writer) (int64, error) {
+	var (
+		n   int
+		err error
+	)
+
+	if uuid == nil || uuid.scope == "" {
+		return 0, errors.New(ErrorUninitializedUUID)
+	}
+
+	n, err = w.Write(uuid.bin[:])
+	if err == nil && n != len(uuid.bin) {
+		err = io.ErrShortWrite
+	}
+
+	return int64(n), err
+}
+
+// ReadFrom reads exactly 16 bytes from r, as written by WriteTo, and sets the UUID to them, resolving its
+// scope. It returns the number of bytes read. Unlike most implementations of io.ReaderFrom, reaching the
+// end of r is an error: if r holds fewer than 16 bytes, io.ErrUnexpectedEOF is returned. An unknown scope
+// returns ErrBadScope. On failure the UUID is left unchanged.
+func (uuid *UUID) ReadFrom(r io.Reader) (int64, error) {
+	var (
+		tmpUUID UUID
+		n       int
+		err     error
+	)
+
+	if uuid == nil {
+		return 0, errors.New(ErrorUninitializedUUID)
+	}
+
+	n, err = io.ReadFull(r, tmpUUID.bin[:])
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+
+	if err != nil {
+		return int64(n), err
+	}
+
+	err = tmpUUID.resolveScope()
+	if err != nil {
+		return int64(n), ErrBadScope
+	}
+
+	*uuid = tmpUUID
+
+	return int64(n), nil
+}
+
+// Decoder reads UUIDs from a stream of fixed 16 bytes records as written by Encoder.
+type Decoder struct {
+	r *bufio.Reader
+	// record is the number of the next record to read.
+	record int
+}
+
+// NewDecoder returns a Decoder reading from r. The Decoder buffers its input and may read more data
+// from r than needed.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r: bufio.NewReader(r)}
+}
+
+// Decode reads the next UUID from the stream and resolves its scope. At the end of the stream io.EOF is
+// returned. A truncated last record or a record with an unknown scope returns a *ParseError holding the
+// record number, wrapping io.ErrUnexpectedEOF or an error with message ErrorBadScope.
+func (decoder *Decoder) Decode() (*UUID, error) {
+	var (
+		uuid UUID
+		err  error
+	)
+
+	_, err = io.ReadFull(decoder.r, uuid.bin[:])
+
+	switch {
+	case err == io.EOF:
+		return nil, io.EOF
+	case err != nil:
+		return nil, &ParseError{Index: decoder.record, Err: err}
+	}
+
+	decoder.record++
+
+	err = uuid.resolveScope()
+	if err != nil {
+		return nil, &ParseError{Index: decoder.record - 1, Input: formatHex(uuid.bin[:]), Err: ErrBadScope}
+	}
+
+	return &uuid, nil
+}
+
+// DecodeAll reads all remaining UUIDs from the stream. Reaching the end of the stream is not an error.
+func (decoder *Decoder) DecodeAll() ([]*UUID, error) {
+	var (
+		uuids []*UUID
+		uuid  *UUID
+		err   error
+	)
+
+	for {
+		uuid, err = decoder.Decode()
+		if err == io.EOF {
+			return uuids, nil
+		}
+
+		if err != nil {
+			return uuids, err
+		}
+
+		uuids = append(uuids, uuid)
+	}
+}
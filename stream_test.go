@@ -0,0 +1,211 @@
+package uuid_test
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"github.com/4xoc/uuid"
+	"io"
+	"testing"
+	"testing/iotest"
+)
+
+func TestStream(t *testing.T) {
+	var (
+		uuids      []*uuid.UUID
+		decoded    []*uuid.UUID
+		buf        bytes.Buffer
+		decoder    *uuid.Decoder
+		parseError *uuid.ParseError
+		data       []byte
+		index      int
+		err        error
+	)
+
+	setupScopes(t, "one", "two")
+
+	uuids, _ = uuid.NewBatch("two", 100)
+
+	if err = uuid.NewEncoder(&buf).EncodeAll(uuids); err != nil {
+		t.Fatal("Expected UUIDs to be encoded but failed with error ", err.Error())
+	}
+
+	if buf.Len() != 1600 {
+		t.Fatal("Expected 1600 bytes but got ", buf.Len())
+	}
+
+	if err = uuid.NewEncoder(&buf).Encode(nil); err == nil || err.Error() != uuid.ErrorUninitializedUUID {
+		t.Error("Expected error for nil UUID")
+	}
+
+	data = buf.Bytes()
+
+	decoded, err = uuid.NewDecoder(bytes.NewReader(data)).DecodeAll()
+	if err != nil || len(decoded) != 100 {
+		t.Fatal("Expected 100 UUIDs but got ", len(decoded), err)
+	}
+
+	for index = range decoded {
+		if !uuid.Equal(decoded[index], uuids[index]) || decoded[index].Scope() != "two" {
+			t.Error("UUID ", index, " doesn't match")
+		}
+	}
+
+	//clean end of stream
+	decoder = uuid.NewDecoder(bytes.NewReader(data[:16]))
+	if _, err = decoder.Decode(); err != nil {
+		t.Error("unexpected error ", err)
+	}
+
+	if _, err = decoder.Decode(); err != io.EOF {
+		t.Error("Expected io.EOF but got ", err)
+	}
+
+	//truncated last record
+	decoded, err = uuid.NewDecoder(bytes.NewReader(data[:16*3+5])).DecodeAll()
+	if !errors.As(err, &parseError) || parseError.Index != 3 || !errors.Is(err, io.ErrUnexpectedEOF) || len(decoded) != 3 {
+		t.Error("unexpected error ", err)
+	}
+
+	//unknown scope in record 2
+	data = append([]byte{}, data[:16*4]...)
+	data[32+scopeIndex()] = 0xfc
+
+	_, err = uuid.NewDecoder(bytes.NewReader(data)).DecodeAll()
+	if !errors.As(err, &parseError) || parseError.Index != 2 || parseError.Err.Error() != uuid.ErrorBadScope {
+		t.Error("unexpected error ", err)
+	}
+}
+
+// shortWriter accepts at most n bytes per write without returning an error.
+type shortWriter struct {
+	n int
+}
+
+func (w shortWriter) Write(p []byte) (int, error) {
+	return min(len(p), w.n), nil
+}
+
+func TestWriteToReadFrom(t *testing.T) {
+	var (
+		myUUID *uuid.UUID
+		myCopy uuid.UUID
+		buf    bytes.Buffer
+		bin    [16]byte
+		n      int64
+		err    error
+	)
+
+	setupScopes(t, "one", "two")
+
+	myUUID = mustNew(t, "two")
+	bin = myUUID.Bin()
+
+	if n, err = myUUID.WriteTo(&buf); err != nil || n != 16 || buf.Len() != 16 {
+		t.Fatal("unexpected result of WriteTo ", n, err)
+	}
+
+	if n, err = myCopy.ReadFrom(&buf); err != nil || n != 16 || !uuid.Equal(&myCopy, myUUID) || myCopy.Scope() != "two" {
+		t.Fatal("unexpected result of ReadFrom ", n, err)
+	}
+
+	//chunked reads
+	for _, r := range []io.Reader{
+		iotest.OneByteReader(bytes.NewReader(bin[:])),
+		iotest.HalfReader(bytes.NewReader(bin[:])),
+		iotest.DataErrReader(bytes.NewReader(bin[:])),
+	} {
+		myCopy = uuid.UUID{}
+
+		if n, err = myCopy.ReadFrom(r); err != nil || n != 16 || myCopy.Hex() != myUUID.Hex() {
+			t.Error("unexpected result of chunked ReadFrom ", n, err)
+		}
+	}
+
+	//consecutive UUIDs in a single stream
+	buf.Reset()
+	mustNew(t, "one").WriteTo(&buf)
+	myUUID.WriteTo(&buf)
+
+	myCopy.ReadFrom(&buf)
+	if n, err = myCopy.ReadFrom(&buf); err != nil || n != 16 || myCopy.Hex() != myUUID.Hex() {
+		t.Error("unexpected result of second ReadFrom ", n, err)
+	}
+
+	//short input
+	for _, data := range [][]byte{nil, bin[:5]} {
+		n, err = myCopy.ReadFrom(bytes.NewReader(data))
+		if err != io.ErrUnexpectedEOF || n != int64(len(data)) || myCopy.Hex() != myUUID.Hex() {
+			t.Error("expected io.ErrUnexpectedEOF but got ", n, err)
+		}
+	}
+
+	//unknown scope
+	n, err = myCopy.ReadFrom(bytes.NewReader(bytes.Repeat([]byte{0xfc}, 16)))
+	if err != uuid.ErrBadScope || n != 16 || myCopy.Hex() != myUUID.Hex() {
+		t.Error("expected ErrBadScope but got ", n, err)
+	}
+
+	if _, err = new(uuid.UUID).WriteTo(&buf); err == nil || err.Error() != uuid.ErrorUninitializedUUID {
+		t.Error("expected error for uninitialized UUID but got ", err)
+	}
+
+	myUUID = nil
+	if _, err = myUUID.WriteTo(&buf); err == nil || err.Error() != uuid.ErrorUninitializedUUID {
+		t.Error("expected error for nil UUID but got ", err)
+	}
+
+	if _, err = myUUID.ReadFrom(&buf); err == nil || err.Error() != uuid.ErrorUninitializedUUID {
+		t.Error("expected error for nil UUID but got ", err)
+	}
+
+	if n, err = myCopy.WriteTo(shortWriter{n: 10}); err != io.ErrShortWrite || n != 10 {
+		t.Error("expected io.ErrShortWrite but got ", n, err)
+	}
+}
+
+func BenchmarkDecoder(b *testing.B) {
+	var (
+		uuids []*uuid.UUID
+		buf   bytes.Buffer
+	)
+
+	setupScopes(b, "one")
+
+	uuids, _ = uuid.NewBatch("one", 10000)
+	uuid.NewEncoder(&buf).EncodeAll(uuids)
+
+	b.ReportAllocs()
+	b.SetBytes(int64(buf.Len()))
+
+	for i := 0; i < b.N; i++ {
+		if _, err := uuid.NewDecoder(bytes.NewReader(buf.Bytes())).DecodeAll(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkHexLines(b *testing.B) {
+	var (
+		uuids []*uuid.UUID
+		data  []byte
+	)
+
+	setupScopes(b, "one")
+
+	uuids, _ = uuid.NewBatch("one", 10000)
+	data = []byte(uuid.JoinHex(uuids, "\n"))
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(data)))
+
+	for i := 0; i < b.N; i++ {
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+
+		for scanner.Scan() {
+			if _, err := uuid.Read(scanner.Text()); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package uuid
+
+import (
+	"errors"
+	"sync"
+)
+
+var (
+	// subScopes holds the names of the sub-scopes of each scope that has sub-scopes configured. The
+	// index of a name is its value in the low two bits of the first byte.
+	subScopes struct {
+		mu    sync.RWMutex
+		names map[string]*[4]string
+	}
+)
+
+// SetSubScopes configures up to four sub-scopes for a known scope. The index of each name is stored in
+// the low two bits of the first byte of UUIDs generated with NewSub. Empty names leave the index
+// unused. Sub-scopes of a scope can only be set once.
+//
+// Sub-scopes don't change the scope of a UUID, so ScopeMatches keeps matching the main scope only.
+func SetSubScopes(scope string, names [4]string) error {
+	var (
+		i, j int
+		ok   bool
+	)
+
+	scope, _, ok = loadScopes().resolve(scope)
+	if !ok {
+		return ErrMissingScope
+	}
+
+	if !hasLowBits() {
+		return errors.New(ErrorLayoutUnsupported)
+	}
+
+	for i = range names {
+		for j = i + 1; j < len(names); j++ {
+			if names[i] != "" && names[i] == names[j] {
+				return errors.New(ErrorBadSubScopes)
+			}
+		}
+	}
+
+	subScopes.mu.Lock()
+	defer subScopes.mu.Unlock()
+
+	if subScopes.names[scope] != nil {
+		return errors.New(ErrorSubScopesSet)
+	}
+
+	if subScopes.names == nil {
+		subScopes.names = make(map[string]*[4]string)
+	}
+
+	subScopes.names[scope] = &names
+
+	return nil
+}
+
+// NewSub generates a new UUID of the given scope with the low two bits of the first byte set to the
+// index of the sub-scope (see SetSubScopes).
+func NewSub(scope, sub string) (*UUID, error) {
+	var (
+		names *[4]string
+		index int
+	)
+
+	if name, _, ok := loadScopes().resolve(scope); ok {
+		scope = name
+	}
+
+	subScopes.mu.RLock()
+	names = subScopes.names[scope]
+	subScopes.mu.RUnlock()
+
+	if names == nil {
+		return nil, errors.New(ErrorMissingSubScope)
+	}
+
+	for index = range names {
+		if sub != "" && names[index] == sub {
+			return NewWithVariant(scope, byte(index))
+		}
+	}
+
+	return nil, errors.New(ErrorMissingSubScope)
+}
+
+// SubScope returns the name of the sub-scope stored in the low two bits of the first byte. If the
+// scope of the UUID has no sub-scopes configured, an empty string is returned.
+func (uuid *UUID) SubScope() string {
+	var (
+		names *[4]string
+	)
+
+	if uuid == nil || uuid.scope == "" {
+		return ""
+	}
+
+	subScopes.mu.RLock()
+	names = subScopes.names[uuid.scope]
+	subScopes.mu.RUnlock()
+
+	if names == nil {
+		return ""
+	}
+
+	return names[uuid.lowBits()]
+}
@@ -0,0 +1,74 @@
+package uuid_test
+
+import (
+	"github.com/4xoc/uuid"
+	"testing"
+)
+
+func TestSubScopes(t *testing.T) {
+	var (
+		myUUID  *uuid.UUID
+		scanned uuid.UUID
+		nilPtr  *uuid.UUID
+		names   = [4]string{"draft", "published", "archived", "deleted"}
+		index   int
+		err     error
+	)
+
+	setupScopes(t, "user", "document")
+
+	if err = uuid.SetSubScopes("ten", names); err == nil || err.Error() != uuid.ErrorMissingScope {
+		t.Error("Expected error for unknown scope")
+	}
+
+	if err = uuid.SetSubScopes("document", [4]string{"a", "b", "a"}); err == nil || err.Error() != uuid.ErrorBadSubScopes {
+		t.Error("Expected error for duplicate sub-scopes")
+	}
+
+	if _, err = uuid.NewSub("document", "draft"); err == nil || err.Error() != uuid.ErrorMissingSubScope {
+		t.Error("Expected error when no sub-scopes are set")
+	}
+
+	if err = uuid.SetSubScopes("document", names); err != nil {
+		t.Fatal("Expected sub-scopes to be set but failed with error ", err.Error())
+	}
+
+	if err = uuid.SetSubScopes("document", names); err == nil || err.Error() != uuid.ErrorSubScopesSet {
+		t.Error("Expected error when setting sub-scopes twice")
+	}
+
+	for _, sub := range []string{"", "unknown"} {
+		if _, err = uuid.NewSub("document", sub); err == nil || err.Error() != uuid.ErrorMissingSubScope {
+			t.Error("Expected error for unknown sub-scope ", sub)
+		}
+	}
+
+	for index = range names {
+		myUUID, err = uuid.NewSub("document", names[index])
+		if err != nil {
+			t.Fatal("Expected UUID to be generated but failed with error ", err.Error())
+		}
+
+		if myUUID.SubScope() != names[index] || myUUID.Variant() != byte(index) {
+			t.Error("unexpected sub-scope ", myUUID.SubScope())
+		}
+
+		//the main scope is unchanged
+		if myUUID.Scope() != "document" || !myUUID.ScopeMatches([]string{"document"}) || myUUID.ScopeMatches(names[:]) {
+			t.Error("sub-scope must not change the scope")
+		}
+
+		if mustRead(t, myUUID.Hex()).SubScope() != names[index] {
+			t.Error("read UUID should have sub-scope ", names[index])
+		}
+
+		if err = scanned.Scan(myUUID.ToProtoBytes()); err != nil || scanned.SubScope() != names[index] {
+			t.Error("scanned UUID should have sub-scope ", names[index])
+		}
+	}
+
+	//scopes without sub-scopes
+	if mustNew(t, "user").SubScope() != "" || nilPtr.SubScope() != "" {
+		t.Error("UUID without sub-scopes should return an empty string")
+	}
+}
@@ -0,0 +1,83 @@
+package uuid
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// ErrTableVersion is returned when parsing a UUID of another scope table version while strict table
+// versions are enabled. Its message is ErrorTableVersion.
+var ErrTableVersion = errors.New(ErrorTableVersion)
+
+var (
+	// tableVersion holds the scope table version written into new UUIDs.
+	tableVersion atomic.Pointer[uint8]
+
+	// strictTableVersion defines if parsing rejects UUIDs of other scope table versions.
+	strictTableVersion atomic.Bool
+)
+
+// SetScopeTableVersion enables writing the given version (0-15) of the scope table into the high 4 bits of
+// byte 1 of UUIDs generated by New, NewBatch and ScopeFactory, so that UUIDs of different scope tables can
+// be told apart after the table has been changed. This costs 4 of the random bits, leaving 118. Ordered
+// UUIDs and UUIDs embedding a node ID use byte 1 otherwise and don't carry the version.
+//
+// The version can only be set once and should be set right after SetScopes.
+func SetScopeTableVersion(v uint8) error {
+	if v > 15 {
+		return errors.New(ErrorBadTableVersion)
+	}
+
+	if !tableVersion.CompareAndSwap(nil, &v) {
+		return errors.New(ErrorTableVersionSet)
+	}
+
+	return nil
+}
+
+// SetStrictTableVersion defines if parsing UUIDs rejects UUIDs whose table version differs from the one set
+// with SetScopeTableVersion, returning ErrTableVersion. It has no effect as long as no version is set.
+//
+// UUIDs generated before the table version has been set carry random data instead of a version, so strict
+// table versions must only be enabled once all UUIDs in use carry a version.
+func SetStrictTableVersion(strict bool) {
+	strictTableVersion.Store(strict)
+}
+
+// TableVersion returns the scope table version stored in the UUID. The second return value is false if no
+// table version is set with SetScopeTableVersion, in which case the bits are not interpreted. Note that
+// UUIDs generated before the table version has been set hold random data in place of the version.
+func (uuid *UUID) TableVersion() (uint8, bool) {
+	if uuid == nil || uuid.scope == "" || tableVersion.Load() == nil {
+		return 0, false
+	}
+
+	return uuid.bin[1] >> 4, true
+}
+
+// stampTableVersion writes the table version into bin if one is set.
+func stampTableVersion(bin []byte) {
+	var (
+		v *uint8
+	)
+
+	v = tableVersion.Load()
+	if v != nil {
+		bin[1] = *v<<4 | bin[1]&0x0f
+	}
+}
+
+// checkTableVersion returns ErrTableVersion if strict table versions are enabled and bin carries another
+// version.
+func checkTableVersion(bin []byte) error {
+	var (
+		v *uint8
+	)
+
+	v = tableVersion.Load()
+	if v != nil && strictTableVersion.Load() && bin[1]>>4 != *v {
+		return ErrTableVersion
+	}
+
+	return nil
+}
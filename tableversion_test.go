@@ -0,0 +1,90 @@
+package uuid_test
+
+import (
+	"errors"
+	"github.com/4xoc/uuid"
+	"testing"
+)
+
+func TestTableVersion(t *testing.T) {
+	var (
+		myUUID  *uuid.UUID
+		old     *uuid.UUID
+		scanned uuid.UUID
+		batch   []*uuid.UUID
+		bin     [16]byte
+		version uint8
+		ok      bool
+		err     error
+	)
+
+	setupScopes(t, "one", "two")
+
+	old = mustRead(t, "04a00000-0000-0000-0000-000000000000")
+
+	if _, ok = mustNew(t, "two").TableVersion(); ok {
+		t.Error("table version should not be interpreted before it is set")
+	}
+
+	if err = uuid.SetScopeTableVersion(16); err == nil || err.Error() != uuid.ErrorBadTableVersion {
+		t.Error("Expected error for bad table version but got ", err)
+	}
+
+	if err = uuid.SetScopeTableVersion(5); err != nil {
+		t.Fatal("Expected table version to be set but failed with error ", err.Error())
+	}
+
+	if err = uuid.SetScopeTableVersion(6); err == nil || err.Error() != uuid.ErrorTableVersionSet {
+		t.Error("Expected error when setting the table version twice but got ", err)
+	}
+
+	batch, _ = uuid.NewBatch("two", 10)
+
+	for index, generated := range append(batch, mustNew(t, "two")) {
+		version, ok = generated.TableVersion()
+		if !ok || version != 5 || generated.Hex()[2] != '5' || generated.Scope() != "two" {
+			t.Error("test case ", index, ": unexpected UUID ", generated.Hex())
+		}
+	}
+
+	//UUIDs of other versions are parsed unless strict table versions are enabled
+	if version, _ = old.TableVersion(); version != 10 {
+		t.Error("unexpected table version ", version)
+	}
+
+	mustRead(t, old.Hex())
+
+	uuid.SetStrictTableVersion(true)
+
+	myUUID = mustNew(t, "two")
+	mustRead(t, myUUID.Hex())
+
+	if _, err = uuid.Read(old.Hex()); err != uuid.ErrTableVersion {
+		t.Error("Expected ErrTableVersion but got ", err)
+	}
+
+	if _, err = uuid.ReadCompact(old.CompactHex()); err != uuid.ErrTableVersion {
+		t.Error("Expected ErrTableVersion but got ", err)
+	}
+
+	bin = old.Bin()
+	if err = scanned.Scan(bin[:]); err != uuid.ErrTableVersion {
+		t.Error("Expected ErrTableVersion but got ", err)
+	}
+
+	if _, err = uuid.FromBin(bin); err != uuid.ErrTableVersion {
+		t.Error("Expected ErrTableVersion from FromBin but got ", err)
+	}
+
+	if _, err = uuid.FromBytes(bin[:]); !errors.Is(err, uuid.ErrTableVersion) {
+		t.Error("Expected ErrTableVersion from FromBytes but got ", err)
+	}
+
+	if _, err = uuid.FromULIDString(old.ToULIDString()); err != uuid.ErrTableVersion {
+		t.Error("Expected ErrTableVersion from FromULIDString but got ", err)
+	}
+
+	uuid.SetStrictTableVersion(false)
+
+	mustRead(t, old.Hex())
+}
@@ -0,0 +1,113 @@
+package uuid
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+)
+
+// TextScanner reads UUIDs from text holding one canonical hex-string per line, e.g. exports of IDs. It is
+// modeled on bufio.Scanner: Scan advances to the next UUID which is then returned by UUID, and Err reports
+// the reason Scan stopped. Blank lines and lines starting with '#' are skipped, whitespace around UUIDs
+// (including the '\r' of CRLF line endings) is trimmed.
+//
+// Input is read line by line, so arbitrarily large inputs are processed with constant memory as long as
+// each line fits into the buffer of bufio.Scanner (64 KiB).
+type TextScanner struct {
+	scanner *bufio.Scanner
+	uuid    *UUID
+	// line is the number of the last line read.
+	line int
+	// skipInvalid defines if lines that are not a UUID are collected in errs instead of stopping.
+	skipInvalid bool
+	errs        []error
+	err         error
+}
+
+// NewTextScanner returns a TextScanner reading from r.
+func NewTextScanner(r io.Reader) *TextScanner {
+	return &TextScanner{scanner: bufio.NewScanner(r)}
+}
+
+// SkipInvalid defines if Scan continues after lines that are not a UUID of a known scope. By default
+// Scan stops at the first such line. With skip set, those lines are skipped and their errors are
+// collected until the end of the input and returned by Err; every skipped line keeps its error in
+// memory. SkipInvalid must be called before the first call of Scan.
+func (scanner *TextScanner) SkipInvalid(skip bool) {
+	scanner.skipInvalid = skip
+}
+
+// Scan advances to the next UUID of the input. It returns false at the end of the input, on errors
+// reading it and, unless SkipInvalid is set, at the first line that is not a UUID of a known scope.
+func (scanner *TextScanner) Scan() bool {
+	var (
+		line string
+		uuid *UUID
+		err  error
+	)
+
+	scanner.uuid = nil
+
+	if scanner.err != nil {
+		return false
+	}
+
+	for scanner.scanner.Scan() {
+		scanner.line++
+
+		line = strings.TrimSpace(scanner.scanner.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+
+		uuid, err = Read(line)
+		if err == nil {
+			scanner.uuid = uuid
+			return true
+		}
+
+		err = &ParseError{Index: scanner.line, Input: line, Err: err}
+
+		if !scanner.skipInvalid {
+			scanner.err = err
+			return false
+		}
+
+		scanner.errs = append(scanner.errs, err)
+	}
+
+	switch {
+	case len(scanner.errs) > 0:
+		scanner.err = errors.Join(append(scanner.errs, scanner.scanner.Err())...)
+	case scanner.scanner.Err() != nil:
+		scanner.err = scanner.scanner.Err()
+	default:
+		//marking the scanner as done even without errors
+		scanner.err = io.EOF
+	}
+
+	return false
+}
+
+// UUID returns the UUID read by the last call of Scan, or nil if Scan returned false.
+func (scanner *TextScanner) UUID() *UUID {
+	return scanner.uuid
+}
+
+// Line returns the number of the line the current UUID has been read from, starting at 1.
+func (scanner *TextScanner) Line() int {
+	return scanner.line
+}
+
+// Err returns the first error that stopped Scan, or nil if the whole input has been read. Lines that
+// are not a UUID are returned as *ParseError whose Index is the line number. With SkipInvalid set, the
+// errors of all skipped lines are returned joined (see errors.Join), followed by the error reading the
+// input if any.
+func (scanner *TextScanner) Err() error {
+	if scanner.err == io.EOF {
+		return nil
+	}
+
+	return scanner.err
+}
@@ -0,0 +1,158 @@
+package uuid_test
+
+import (
+	"errors"
+	"github.com/4xoc/uuid"
+	"io"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// lineReader generates n lines holding the same UUID without keeping them in memory.
+type lineReader struct {
+	line []byte
+	n    int
+	off  int
+}
+
+func (r *lineReader) Read(p []byte) (int, error) {
+	var (
+		written int
+	)
+
+	for written < len(p) {
+		if r.n == 0 {
+			if written == 0 {
+				return 0, io.EOF
+			}
+
+			break
+		}
+
+		copied := copy(p[written:], r.line[r.off:])
+		written += copied
+		r.off += copied
+
+		if r.off == len(r.line) {
+			r.off = 0
+			r.n--
+		}
+	}
+
+	return written, nil
+}
+
+func TestTextScanner(t *testing.T) {
+	var (
+		scanner  *uuid.TextScanner
+		a, b     *uuid.UUID
+		uuids    []string
+		lines    []int
+		parseErr *uuid.ParseError
+	)
+
+	setupScopes(t, "one", "two")
+
+	a = mustNew(t, "one")
+	b = mustNew(t, "two")
+
+	input := "# exported IDs\r\n" + a.Hex() + "\r\n\r\n   \n  " + b.Hex() + "  \n#" + a.Hex() + "\nnot a UUID\n" + a.Hex()
+
+	scanner = uuid.NewTextScanner(strings.NewReader(input))
+
+	for scanner.Scan() {
+		uuids = append(uuids, scanner.UUID().Hex())
+		lines = append(lines, scanner.Line())
+	}
+
+	if len(uuids) != 2 || uuids[0] != a.Hex() || uuids[1] != b.Hex() || lines[0] != 2 || lines[1] != 5 {
+		t.Error("unexpected UUIDs ", uuids, " in lines ", lines)
+	}
+
+	if !errors.As(scanner.Err(), &parseErr) || parseErr.Index != 7 || parseErr.Input != "not a UUID" ||
+		!errors.Is(scanner.Err(), uuid.ErrBadString) {
+		t.Error("Expected error in line 7 but got ", scanner.Err())
+	}
+
+	if scanner.Scan() || scanner.UUID() != nil {
+		t.Error("Scan should not continue after an error")
+	}
+
+	//collecting errors
+	scanner = uuid.NewTextScanner(strings.NewReader(input + "\nfc000000-0000-0000-0000-0000000000fc"))
+	scanner.SkipInvalid(true)
+	uuids = nil
+
+	for scanner.Scan() {
+		uuids = append(uuids, scanner.UUID().Hex())
+	}
+
+	if len(uuids) != 3 || uuids[2] != a.Hex() {
+		t.Error("unexpected UUIDs ", uuids)
+	}
+
+	if !errors.Is(scanner.Err(), uuid.ErrBadString) || !errors.Is(scanner.Err(), uuid.ErrBadScope) ||
+		!strings.Contains(scanner.Err().Error(), "item 9") {
+		t.Error("Expected errors of both invalid lines but got ", scanner.Err())
+	}
+
+	scanner = uuid.NewTextScanner(strings.NewReader(""))
+	if scanner.Scan() || scanner.Err() != nil {
+		t.Error("empty input should not return an error")
+	}
+
+	scanner = uuid.NewTextScanner(io.MultiReader(strings.NewReader(a.Hex()+"\n"), iotestErrReader{}))
+	if !scanner.Scan() || scanner.Scan() || scanner.Err() != errBrokenReader {
+		t.Error("Expected reader error but got ", scanner.Err())
+	}
+}
+
+// errBrokenReader is returned by iotestErrReader.
+var errBrokenReader = errors.New("broken reader")
+
+// iotestErrReader always fails with errBrokenReader.
+type iotestErrReader struct{}
+
+func (iotestErrReader) Read([]byte) (int, error) {
+	return 0, errBrokenReader
+}
+
+func TestTextScannerStreaming(t *testing.T) {
+	var (
+		scanner *uuid.TextScanner
+		lines   = 1000000
+		count   int
+		before  runtime.MemStats
+		after   runtime.MemStats
+	)
+
+	setupScopes(t, "one", "two")
+
+	if testing.Short() {
+		lines = 100000
+	}
+
+	scanner = uuid.NewTextScanner(&lineReader{line: []byte(mustNew(t, "two").Hex() + "\n"), n: lines})
+
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+
+	for scanner.Scan() {
+		count++
+
+		if count == lines/2 {
+			runtime.GC()
+			runtime.ReadMemStats(&after)
+		}
+	}
+
+	if scanner.Err() != nil || count != lines {
+		t.Fatal("expected ", lines, " UUIDs but got ", count, " ", scanner.Err())
+	}
+
+	//reading about 37 MB of input must not keep it in memory
+	if after.HeapInuse > before.HeapInuse+4<<20 {
+		t.Error("heap grew from ", before.HeapInuse, " to ", after.HeapInuse)
+	}
+}
@@ -0,0 +1,86 @@
+package uuid
+
+import (
+	"encoding/binary"
+	"strings"
+)
+
+// crockfordAlphabet holds the characters of Crockford's base32 as used by ULIDs.
+const crockfordAlphabet string = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ToULIDString returns the 16 bytes of the UUID in the 26 characters long Crockford base32 formatting of
+// ULIDs, e.g. to pass the UUID through systems keyed by ULID. The result can be read with FromULIDString.
+//
+// Only the formatting is shared with ULIDs: the first 48 bits of a ULID are its timestamp while a UUID
+// holds its scope in the first byte. Random UUIDs therefore result in meaningless ULID timestamps. UUIDs
+// generated by NewOrdered hold the scope and the upper 40 bits of their timestamp there, so their ULIDs
+// still sort by time of creation within a scope but the ULID timestamp isn't the time of creation either.
+//
+// If the UUID is not initialized, an empty string is returned.
+func (uuid *UUID) ToULIDString() string {
+	var (
+		buf   [26]byte
+		hi    uint64
+		lo    uint64
+		index int
+	)
+
+	if uuid == nil || uuid.scope == "" {
+		return ""
+	}
+
+	hi = binary.BigEndian.Uint64(uuid.bin[0:8])
+	lo = binary.BigEndian.Uint64(uuid.bin[8:16])
+
+	//encoding 5 bits at a time starting with the least significant ones; the 26 characters hold 130 bits
+	//of which the first 2 are always 0
+	for index = len(buf) - 1; index >= 0; index-- {
+		buf[index] = crockfordAlphabet[lo&0x1f]
+
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+
+	return string(buf[:])
+}
+
+// FromULIDString reads a UUID from a ULID string as returned by ToULIDString. The string is
+// case-insensitive; the characters I, L, O and U are not part of the alphabet and are rejected with
+// ErrorBadString, as are strings of another length and ULIDs starting with a character higher than 7
+// which don't fit into 128 bits. The scope is resolved like FromBin does, returning the same errors.
+func FromULIDString(s string) (*UUID, error) {
+	var (
+		uuid  UUID
+		hi    uint64
+		lo    uint64
+		value int
+		index int
+		err   error
+	)
+
+	if len(s) != 26 || s[0] > '7' {
+		return nil, ErrBadString
+	}
+
+	s = strings.ToUpper(s)
+
+	for index = 0; index < len(s); index++ {
+		value = strings.IndexByte(crockfordAlphabet, s[index])
+		if value < 0 {
+			return nil, ErrBadString
+		}
+
+		hi = hi<<5 | lo>>59
+		lo = lo<<5 | uint64(value)
+	}
+
+	binary.BigEndian.PutUint64(uuid.bin[0:8], hi)
+	binary.BigEndian.PutUint64(uuid.bin[8:16], lo)
+
+	err = uuid.resolveScope()
+	if err != nil {
+		return nil, err
+	}
+
+	return &uuid, nil
+}
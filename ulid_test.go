@@ -0,0 +1,71 @@
+package uuid_test
+
+import (
+	"github.com/4xoc/uuid"
+	"strings"
+	"testing"
+)
+
+func TestULID(t *testing.T) {
+	var (
+		myUUID *uuid.UUID
+		myCopy *uuid.UUID
+		ulid   string
+		err    error
+	)
+
+	//the test vector and the edge case below hold scopes 22 and 63 in the last byte
+	scopes := make([]string, 64)
+	scopes[0], scopes[1], scopes[22], scopes[63] = "one", "two", "vector", "max"
+
+	setupScopes(t, scopes...)
+
+	for i := 0; i < 100; i++ {
+		myUUID = mustNew(t, "two")
+		ulid = myUUID.ToULIDString()
+
+		if len(ulid) != 26 {
+			t.Fatal("unexpected ULID ", ulid)
+		}
+
+		for _, input := range []string{ulid, strings.ToLower(ulid)} {
+			myCopy, err = uuid.FromULIDString(input)
+			if err != nil || myCopy.Hex() != myUUID.Hex() || myCopy.Scope() != "two" {
+				t.Fatal("ULID ", input, " doesn't round-trip: ", err)
+			}
+		}
+	}
+
+	//test vector of github.com/oklog/ulid and its UUID representation
+	myUUID = mustRead(t, "01563e3a-b5d3-d676-4c61-efb99302bd5b")
+	if myUUID.ToULIDString() != "01ARZ3NDEKTSV4RRFFQ69G5FAV" {
+		t.Error("unexpected ULID ", myUUID.ToULIDString())
+	}
+
+	myCopy, err = uuid.FromULIDString("01arz3ndektsv4rrffq69g5fav")
+	if err != nil || myCopy.Hex() != "01563e3a-b5d3-d676-4c61-efb99302bd5b" {
+		t.Error("unexpected UUID ", myCopy.Hex(), " ", err)
+	}
+
+	if mustRead(t, "07ffffff-ffff-ffff-ffff-ffffffffffff").ToULIDString() != "07ZZZZZZZZZZZZZZZZZZZZZZZZ" ||
+		new(uuid.UUID).ToULIDString() != "" {
+		t.Error("unexpected ULID of edge cases")
+	}
+
+	for _, input := range []string{
+		"01ARZ3NDEKTSV4RRFFQ69G5FA",
+		"01ARZ3NDEKTSV4RRFFQ69G5FAVV",
+		"81ARZ3NDEKTSV4RRFFQ69G5FAV",
+		"01ARZ3NDEKTSV4RRFFQ69G5FAU",
+		"01ARZ3NDEKTSV4RRFFQ69G5FAL",
+	} {
+		if _, err = uuid.FromULIDString(input); err != uuid.ErrBadString {
+			t.Error("Expected ErrBadString for ", input, " but got ", err)
+		}
+	}
+
+	//first and last byte 0x08 hold scope 2 which isn't set
+	if _, err = uuid.FromULIDString("08000000000000000000000008"); err != uuid.ErrBadScope {
+		t.Error("Expected ErrBadScope but got ", err)
+	}
+}
@@ -4,22 +4,19 @@
 package uuid
 
 import (
-	crand "crypto/rand"
 	"database/sql/driver"
-	"encoding/hex"
 	"errors"
 	"fmt"
-	mrand "math/rand"
+	"iter"
 	"regexp"
-	"strings"
 )
 
-// Type UUID holds the ID's information like the Scope as well as a hex string and binary representation.
+// Type UUID holds the ID's information like the Scope as well as its binary representation. The
+// hex-string representation is derived from the binary one when needed.
 type UUID struct {
-	// scope describes the UUIDs scope as a string given on creation.
+	// scope describes the UUIDs scope as a string given on creation. It is empty for uninitialized
+	// UUIDs.
 	scope string
-	// Hex contains the canonical writing of the UUID
-	hex string
 	// bin contains the actual binary UUID.
 	bin [16]byte
 }
@@ -33,12 +30,68 @@ const (
 	ErrorMalformattedHex   string = "the Hex representation of the UUID is malformatted"
 	ErrorUninitializedUUID string = "the provided pointer refers to an uninitialized struct"
 	ErrorScopesAlreadySet  string = "scopes can only be set once"
+	ErrorBadBatchSize      string = "the batch size is out of range"
+	ErrorBadLength         string = "the provided data is not 16 bytes long"
+	ErrorBadInteger        string = "the provided integer is not in the range of a UUID"
+	ErrorShortBuffer       string = "the provided buffer is too small"
+	ErrorBadVariant        string = "the variant must be between 0 and 3"
+	ErrorChecksumMismatch  string = "the checksum of the UUID doesn't match"
+	ErrorMissingSignature  string = "the provided string is not signed"
+	ErrorBadSignature      string = "the signature of the UUID is malformatted"
+	ErrorInvalidSignature  string = "the signature of the UUID doesn't match"
+	ErrorScopeMismatch     string = "the scope of the UUID doesn't match its prefix"
+	ErrorMissingSubScope   string = "the provided sub-scope is not known"
+	ErrorBadSubScopes      string = "the provided sub-scopes are not unique"
+	ErrorSubScopesSet      string = "sub-scopes of a scope can only be set once"
+	ErrorBadShardCount     string = "the number of shards must be greater than zero"
+	ErrorScopeOverflow     string = "the resulting UUID would leave the range of its scope"
+	ErrorNoDefaultScope    string = "no default scope is set"
+	ErrorDefaultScopeSet   string = "the default scope can only be set once"
+	ErrorScopeNotAllowed   string = "the scope of the UUID is not allowed"
+	ErrorBadScopeName      string = "the scope name is not allowed"
+	ErrorEntropy           string = "Error generating new UUID"
+	ErrorBadSource         string = "the provided source type is not supported"
+	ErrorScanDepth         string = "the provided source nests too many values"
+	ErrorBadCBOR           string = "the provided data is not a CBOR encoded UUID"
+	ErrorNoNodeID          string = "no node ID is set"
+	ErrorNodeIDSet         string = "the node ID can only be set once"
+	ErrorBadPoolSize       string = "the pool size must be greater than zero"
+	ErrorCollision         string = "every generated UUID already exists"
+	ErrorBadAlias          string = "the alias is already used by another scope"
+	ErrorUnexpectedScope   string = "the UUID is not of the expected scope"
+	ErrorTableVersion      string = "the UUID is of another scope table version"
+	ErrorBadTableVersion   string = "the table version must be between 0 and 15"
+	ErrorTableVersionSet   string = "the table version can only be set once"
+	ErrorWellKnownExists   string = "the well-known name or UUID is already registered"
+	ErrorAmbiguousPrefix   string = "the prefix matches more than one UUID"
+	ErrorNotFound          string = "no UUID matches the prefix"
+	ErrorBadScopeLayout    string = "the scope layout is not supported"
+	ErrorScopeLayoutSet    string = "the scope layout must be set before the scopes"
+	ErrorLayoutUnsupported string = "the operation is not supported by the scope layout"
+	ErrorBadScopeWidth     string = "the scope width must be 6 or 8 bits"
+	ErrorScopeWidthSet     string = "the scope width must be set before the scopes"
+	ErrorBadScopeByte      string = "the binary representation of the scope is not allowed"
+	ErrorDuplicateScope    string = "two scopes share the same binary representation"
+	ErrorScopeDeprecated   string = "the scope is deprecated"
+	ErrorBadEnvelope       string = "the provided data is not a UUID envelope"
+	ErrorEnvelopeVersion   string = "the version of the UUID envelope is not supported"
+	ErrorEnvelopeScope     string = "the scope name of the envelope doesn't match the scope of the UUID"
 )
 
 var (
-	// setScopes holds the mapping between existing scopes (identified map index 'string')
-	// and a pointer to the byte set in `scopes`.
-	setScopes map[string]*byte
+	// ErrMissingScope is returned when the provided scope is not known. Its message is ErrorMissingScope.
+	ErrMissingScope = errors.New(ErrorMissingScope)
+	// ErrBadScope is returned when the scope of a UUID is not known. Its message is ErrorBadScope.
+	ErrBadScope = errors.New(ErrorBadScope)
+	// ErrBadString is returned when a string is not a UUID. Its message is ErrorBadString.
+	ErrBadString = errors.New(ErrorBadString)
+	// ErrScopeNotAllowed is matched by errors.Is for every *ScopeNotAllowedError.
+	ErrScopeNotAllowed = errors.New(ErrorScopeNotAllowed)
+)
+
+var (
+	// canonicalPattern matches the canonical hex-string representation of a UUID.
+	canonicalPattern = regexp.MustCompile("^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$")
 
 	// scopes holds a list of all available bytes that can be used to set the binary scope.
 	scopes = [64]byte{
@@ -60,13 +113,14 @@ var (
 		0xf0, 0xf4, 0xf8, 0xfc}
 )
 
-// Scope returns the scope of a UUID as a string.
+// Scope returns the scope of a UUID as a string. It is empty for uninitialized UUIDs and for UUIDs of
+// an unknown scope read with ReadAny.
 //
 // This function is needed to have a private 'scope' variable in the
 // struct. Errors where a scope has been manually changed should
 // be prevented by this.
 func (uuid *UUID) Scope() string {
-	if uuid == nil {
+	if uuid == nil || uuid.scope == unknownScope {
 		return ""
 	}
 
@@ -87,134 +141,414 @@ func (uuid *UUID) Bin() [16]byte {
 }
 
 // Hex returns the hex-string representation of a given UUID.
+//
+// If the UUID is not initialized, an empty string is returned.
 func (uuid *UUID) Hex() string {
-	if uuid == nil {
+	if uuid == nil || uuid.scope == "" {
 		return ""
 	}
 
-	return uuid.hex
+	return formatHex(uuid.bin[:])
 }
 
 // ScopeMatches checks a given slice of strings to check
 // a for a matching scope. If any of the given scopes matches,
 // the function returns true.
 //
-// If the UUID is not initialized, false is returned.
+// If the UUID is not initialized or of an unknown scope (see ReadAny), false is returned.
 func (uuid *UUID) ScopeMatches(scopes []string) bool {
 	var (
+		table *scopeTable
+		name  string
 		index int
 	)
 
+	if uuid.scope == unknownScope {
+		return false
+	}
+
+	table = loadScopes()
+
 	for index = range scopes {
 		if uuid.scope == scopes[index] {
 			return true
 		}
+
+		//aliases match the scope they resolve to
+		if name, _, _ = table.resolve(scopes[index]); uuid.scope != "" && name == uuid.scope {
+			return true
+		}
 	}
 
 	return false
 }
 
-// readScope is a function that checks the binary data of the uuid and
-// defines the scope as sting for that uuid.
-func (uuid *UUID) readScope() error {
+// hexSource is the type of the hex-strings decoded by decodeCanonical and decodeCompact. Decoding []byte
+// sources directly saves converting them to a string first.
+type hexSource interface {
+	string | []byte
+}
+
+// decodeCanonical sets bin from the canonical hex-string as matched by canonicalPattern without resolving
+// the scope. It returns ErrBadString for any other input, in which case bin may be partially written.
+func decodeCanonical[T hexSource](bin *[16]byte, input T) error {
 	var (
-		tmpBytes []byte
-		tmpByte  byte
-		scope    string
-		err      error
+		pos   int
+		index int
+		err   error
 	)
 
-	//first we set bin from hex
-	tmpBytes, err = hex.DecodeString(strings.Replace(uuid.hex, "-", "", -1))
-	if err != nil {
-		return errors.New(ErrorBadString)
+	if len(input) != 36 || input[8] != '-' || input[13] != '-' || input[18] != '-' || input[23] != '-' {
+		return ErrBadString
+	}
+
+	for index = range bin {
+		//skipping the dashes between the groups of 4-2-2-2-6 bytes
+		if index == 4 || index == 6 || index == 8 || index == 10 {
+			pos++
+		}
+
+		bin[index], err = decodeHexByte(input[pos], input[pos+1])
+		if err != nil {
+			return err
+		}
+
+		pos += 2
 	}
 
-	copy(uuid.bin[:], tmpBytes)
+	return nil
+}
 
-	//reading first byte and clearing last two bits
-	tmpByte = uuid.bin[0] &^ 0x03
+// decodeCompact works like decodeCanonical for the hex-string without dashes.
+func decodeCompact[T hexSource](bin *[16]byte, input T) error {
+	var (
+		index int
+		err   error
+	)
 
-	if setScopes == nil {
-		return errors.New(ErrorMissingScope)
+	if len(input) != 32 {
+		return ErrBadString
 	}
 
-	for scope = range setScopes {
-		if tmpByte == *setScopes[scope] {
-			uuid.scope = scope
-			break
+	for index = range bin {
+		bin[index], err = decodeHexByte(input[2*index], input[2*index+1])
+		if err != nil {
+			return err
 		}
 	}
 
+	return nil
+}
+
+// decodeHexByte returns the byte of two lowercase hex digits.
+func decodeHexByte(high byte, low byte) (byte, error) {
+	var (
+		err error
+	)
+
+	high, err = lowerNibble(high)
+	if err != nil {
+		return 0, err
+	}
+
+	low, err = lowerNibble(low)
+	if err != nil {
+		return 0, err
+	}
+
+	return high<<4 | low, nil
+}
+
+// lowerNibble returns the value of a single lowercase hex digit.
+func lowerNibble(digit byte) (byte, error) {
+	switch {
+	case digit >= '0' && digit <= '9':
+		return digit - '0', nil
+	case digit >= 'a' && digit <= 'f':
+		return digit - 'a' + 10, nil
+	}
+
+	return 0, ErrBadString
+}
+
+// resolveScope checks the binary data of the uuid and defines the scope as string for that uuid.
+func (uuid *UUID) resolveScope() error {
+	return uuid.resolveScopeIn(loadScopes())
+}
+
+// resolveScopeIn works like resolveScope using the given scope table.
+func (uuid *UUID) resolveScopeIn(table *scopeTable) error {
+	var (
+		tmpByte byte
+	)
+
+	//reading the scope byte and clearing its last two bits
+	tmpByte = uuid.scopeBits()
+
+	if table.byName == nil {
+		return ErrMissingScope
+	}
+
+	uuid.scope = table.name(tmpByte)
+
 	if uuid.scope == "" {
-		return errors.New(ErrorBadScope)
+		return ErrBadScope
 	}
 
-	return nil
+	return checkTableVersion(uuid.bin[:])
 }
 
 // Value provides a database/sql/driver interface to read the struct's value and pass it to a DB connection.
+// The canonical hex-string is derived from the binary representation. The zero value results in SQL NULL;
+// UUIDs holding binary data without a resolved scope return an error.
+//
+// Value has a value receiver, so it is available for UUID as well as *UUID. database/sql passes nil
+// *UUID arguments as NULL without calling Value. Together with Scan, this makes sql.Null[UUID] usable
+// for nullable columns.
 func (uuid UUID) Value() (driver.Value, error) {
-	if len(uuid.hex) != 36 {
+	if uuid.scope == "" {
+		if uuid.bin == [16]byte{} {
+			return nil, nil
+		}
+
 		return nil, errors.New(ErrorMalformattedHex)
 	}
 
-	return uuid.hex, nil
+	return formatHex(uuid.bin[:]), nil
 }
 
 // Scan provides a database/sql/driver interface to read the data coming from a DB connection into a struct.
+// The source can be the 16 bytes of a binary UUID (as []byte or [16]byte), a hex-string like accepted by
+// Read (also as []byte), a hex-string without dashes as []byte, a UUID or a pointer to one, or any
+// driver.Valuer or fmt.Stringer providing one of these. SQL NULL (nil), nil pointers and uninitialized
+// UUIDs result in the zero value; use sql.Null[UUID] to tell NULL apart. Other sources return
+// ErrorBadSource along with their type, []byte of any other length ErrorBadLength.
+//
+// On failure, the UUID is set to the zero value so that a UUID reused across rows never keeps the value
+// of a previous row.
 func (uuid *UUID) Scan(src interface{}) error {
 	var (
-		ok      bool
-		tmpByte []byte
+		err error
 	)
 
-	if tmpByte, ok = src.([]byte); !ok {
-		return errors.New("Type assertion .([]byte) failed.")
+	err = uuid.scan(src, 0)
+	if err != nil {
+		*uuid = UUID{}
+		reportParseError(src, err)
 	}
 
-	uuid.hex = fmt.Sprintf("%x-%x-%x-%x-%x",
-		tmpByte[0:4],
-		tmpByte[4:6],
-		tmpByte[6:8],
-		tmpByte[8:10],
-		tmpByte[10:16])
+	return err
+}
+
+// maxScanDepth limits the number of driver.Valuer sources Scan unwraps so that cycles can't recurse
+// forever.
+const maxScanDepth int = 8
+
+// scan implements Scan without reporting parse errors. The UUID is only modified if src holds a valid
+// UUID. depth is the number of driver.Valuer sources unwrapped so far.
+func (uuid *UUID) scan(src interface{}, depth int) error {
+	var (
+		tmpUUID UUID
+		value   driver.Value
+		err     error
+	)
 
-	//returns nil if uuid is good or error if the is a problem
-	return uuid.readScope()
+	switch tmp := src.(type) {
+	case nil:
+		//SQL NULL results in the zero value
+	case UUID:
+		return uuid.scan(&tmp, depth)
+	case *UUID:
+		//like SQL NULL, nil and uninitialized UUIDs result in the zero value
+		if tmp != nil {
+			tmpUUID = *tmp
+		}
+	case []byte:
+		switch len(tmp) {
+		case 16:
+			copy(tmpUUID.bin[:], tmp)
+
+			err = tmpUUID.resolveScope()
+		case 32:
+			//CHAR(32) columns holding the hex-string without dashes
+			err = decodeCompact(&tmpUUID.bin, tmp)
+			if err == nil {
+				err = tmpUUID.resolveParsed(loadScopes())
+			}
+		case 36:
+			//text columns are returned as []byte by many drivers
+			err = decodeCanonical(&tmpUUID.bin, tmp)
+			if err == nil {
+				err = tmpUUID.resolveScope()
+			}
+		default:
+			return errors.New(ErrorBadLength)
+		}
+	case [16]byte:
+		tmpUUID.bin = tmp
+
+		err = tmpUUID.resolveScope()
+	case string:
+		err = decodeCanonical(&tmpUUID.bin, tmp)
+		if err == nil {
+			err = tmpUUID.resolveScope()
+		}
+	case driver.Valuer:
+		if depth >= maxScanDepth {
+			return errors.New(ErrorScanDepth)
+		}
+
+		value, err = tmp.Value()
+		if err != nil {
+			return err
+		}
+
+		return uuid.scan(value, depth+1)
+	case fmt.Stringer:
+		return uuid.scan(tmp.String(), depth)
+	default:
+		return fmt.Errorf("%s: %T", ErrorBadSource, src)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	*uuid = tmpUUID
+
+	return nil
+}
+
+// hexDigits holds the characters used for the hex-string representation.
+const hexDigits string = "0123456789abcdef"
+
+// formatHex returns the canonical hex-string representation of the given 16 bytes.
+func formatHex(bin []byte) string {
+	var (
+		buf [36]byte
+	)
+
+	return string(appendHex(buf[:0], bin))
+}
+
+// appendHex appends the canonical hex-string representation of the given 16 bytes to dst.
+func appendHex(dst []byte, bin []byte) []byte {
+	var (
+		index int
+	)
+
+	for index = 0; index < 16; index++ {
+		//dashes go between the groups of 4-2-2-2-6 bytes
+		if index == 4 || index == 6 || index == 8 || index == 10 {
+			dst = append(dst, '-')
+		}
+
+		dst = append(dst, hexDigits[bin[index]>>4], hexDigits[bin[index]&0x0f])
+	}
+
+	return dst
 }
 
 // New generates a new UUID and sets its scope to the one provided as an argument.
 // If the scope doesn't exist yet, it will return an error (see SetScopes function).
+// An empty scope uses the default scope if one is set (see SetDefaultScope function).
+// Deprecated scopes return ErrScopeDeprecated (see DeprecateScope function).
 func New(scope string) (*UUID, error) {
 	var (
 		uuid UUID
 		err  error
 	)
 
-	if setScopes[scope] == nil {
-		return nil, errors.New(ErrorMissingScope)
+	uuid, err = NewValue(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	return &uuid, nil
+}
+
+// NewValue works like New but returns the UUID by value. Unlike the pointer returned by New, the value
+// doesn't need to be allocated on the heap, which saves an allocation per UUID where the result is
+// stored by value, e.g. in a struct field or a slice of UUIDs. All methods of UUID can be called on
+// addressable values like variables and struct fields.
+func NewValue(scope string) (UUID, error) {
+	var (
+		uuid      UUID
+		name      string
+		scopeByte byte
+		err       error
+	)
+
+	if scope == "" && defaultScope.Load() != nil {
+		scope = *defaultScope.Load()
 	}
 
-	_, err = crand.Read(uuid.bin[:])
+	name, scopeByte, err = loadScopes().resolveNew(scope)
+	if err != nil {
+		return UUID{}, err
+	}
+
+	err = uuid.generate(name, scopeByte)
+	if err != nil {
+		return UUID{}, err
+	}
+
+	return uuid, nil
+}
+
+// Regenerate replaces the random data of the UUID with new random data while keeping its scope. The
+// UUID is modified in place, so copies taken before (e.g. via Hex) keep the old value. Like New, the low
+// two bits of the first byte are random afterwards.
+//
+// Calling Regenerate while the same UUID is used concurrently is not safe. If the UUID is nil or not
+// initialized, ErrorUninitializedUUID is returned, for UUIDs of an unknown scope (see ReadAny)
+// ErrBadScope.
+func (uuid *UUID) Regenerate() error {
+	var (
+		tmpUUID UUID
+		err     error
+	)
+
+	if uuid == nil || uuid.scope == "" {
+		return errors.New(ErrorUninitializedUUID)
+	}
+
+	if uuid.scope == unknownScope {
+		return ErrBadScope
+	}
+
+	//generating into a copy so the UUID stays untouched on failure
+	err = tmpUUID.generate(uuid.scope, uuid.scopeBits())
+	if err != nil {
+		return err
+	}
+
+	*uuid = tmpUUID
+
+	return nil
+}
+
+// generate fills the UUID with random data and sets the given scope and its binary representation.
+func (uuid *UUID) generate(scope string, scopeByte byte) error {
+	var (
+		err error
+	)
+
+	err = readEntropyInto(&uuid.bin)
 
 	if err != nil {
-		return nil, errors.New("Error generating new UUID: " + err.Error())
+		return err
 	}
 
-	//set scope
-	uuid.bin[0] = *setScopes[scope] | byte(mrand.Intn(4))
+	//set scope, keeping the random low two bits
+	uuid.setScopeBits(scopeByte)
 	uuid.scope = scope
 
-	//formatting as canonical string
-	uuid.hex = fmt.Sprintf("%x-%x-%x-%x-%x",
-		uuid.bin[0:4],
-		uuid.bin[4:6],
-		uuid.bin[6:8],
-		uuid.bin[8:10],
-		uuid.bin[10:16])
+	stampTableVersion(uuid.bin[:])
 
-	return &uuid, nil
+	reportGenerate(scope, scopeByte, 1)
+
+	return nil
 }
 
 // Read uses a given string and parses it into a UUID struct.
@@ -224,31 +558,47 @@ func Read(input string) (*UUID, error) {
 		err  error
 	)
 
-	if !regexp.MustCompile("^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$").MatchString(input) {
-		return nil, errors.New(ErrorBadString)
+	uuid, err = ReadValue(input)
+	if err != nil {
+		return nil, err
 	}
 
-	uuid.hex = input
+	return &uuid, nil
+}
+
+// ReadValue works like Read but returns the UUID by value, saving the heap allocation of the pointer
+// returned by Read (see NewValue). On failure, the zero value is returned.
+func ReadValue(input string) (UUID, error) {
+	var (
+		uuid UUID
+		err  error
+	)
 
-	err = uuid.readScope()
+	err = uuid.readCanonical(input, loadScopes())
 	if err != nil {
-		return nil, errors.New(ErrorBadScope)
+		reportParseError(input, err)
+		return UUID{}, err
 	}
 
-	return &uuid, nil
+	return uuid, nil
 }
 
 // Scopes provides a list of all currently set scopes in a [64]string. The order is not the same as set with
-// SetScopes function.
+// SetScopes function. With more than 64 scopes (see SetWideScopes), only 64 of them are listed; use
+// ScopesSeq instead. Aliases are not listed, see ScopeAliases.
 func Scopes() [64]string {
 	var (
+		table  *scopeTable
 		scope  string
 		scopes [64]string
 		index  int
 	)
 
-	if setScopes != nil {
-		for scope = range setScopes {
+	table = loadScopes()
+
+	for scope = range table.byName {
+		//aliases are not listed
+		if !table.isAlias(scope) && index < len(scopes) {
 			scopes[index] = scope
 			index++
 		}
@@ -257,25 +607,105 @@ func Scopes() [64]string {
 	return scopes
 }
 
-// setScopes defines the scopes used within this package and its binary representation. This function can
-// only set scopes when there aren't any configured yet. A dynamic update is not supported for the sake
-// of preventing concurrency issues without compromising performance.
+// ScopesSeq returns an iterator over all currently set scopes and their binary representation, ordered
+// by the binary representation. The iterator works on a snapshot of the scopes taken when iterating
+// starts and yields nothing as long as no scopes are set.
+func ScopesSeq() iter.Seq2[string, byte] {
+	return func(yield func(string, byte) bool) {
+		var (
+			names [maxScopes]string
+			index int
+		)
+
+		names = loadScopes().names
+
+		for index = range names {
+			if names[index] == "" {
+				continue
+			}
+
+			if !yield(names[index], scopeByteAt(index)) {
+				return
+			}
+		}
+	}
+}
+
+// SetScopes defines the scopes used within this package and its binary representation. This function can
+// only set scopes when there aren't any configured yet; use ReplaceScopes to change them later on.
+//
+// Scope names must not be longer than 32 characters and only contain the characters a-z, 0-9, '_' and
+// '-'. Empty entries mark unused scopes. The first name violating a rule is returned as *ScopeNameError
+// and no scopes are set. SetScopesUnchecked skips these checks.
 func SetScopes(newScopes [64]string) error {
+	var (
+		index int
+		rule  string
+	)
+
+	if scopesSet() {
+		return errors.New(ErrorScopesAlreadySet)
+	}
+
+	for index = 0; index < 64; index++ {
+		rule = checkScopeName(newScopes[index])
+		if rule != "" {
+			return &ScopeNameError{Index: index, Name: newScopes[index], Rule: rule}
+		}
+	}
+
+	return SetScopesUnchecked(newScopes)
+}
+
+// SetScopesUnchecked works like SetScopes but accepts any scope name. It exists for setups with scope
+// names that were in use before the naming rules were introduced.
+func SetScopesUnchecked(newScopes [64]string) error {
+	return setScopeTable(newScopes[:])
+}
+
+// setScopeTable sets the scopes at the position of their name in newScopes, using the table of binary
+// representations matching the scope width. It fails if scopes are set already.
+func setScopeTable(newScopes []string) error {
 	var (
 		index  int
+		scope  string
+		table  *scopeTable
 		tmpMap map[string]*byte
 	)
 
-	if setScopes != nil {
+	scopesMu.Lock()
+	defer scopesMu.Unlock()
+
+	if scopesSet() {
 		return errors.New(ErrorScopesAlreadySet)
 	}
 
+	table = &scopeTable{}
 	tmpMap = make(map[string]*byte)
 
-	for index = 0; index < 64; index++ {
-		tmpMap[newScopes[index]] = &scopes[index]
+	for index = range newScopes {
+		//empty entries mark unused scopes
+		if newScopes[index] == "" {
+			continue
+		}
+
+		switch {
+		case scopeWidth == 8:
+			tmpMap[newScopes[index]] = &wideScopes[index]
+		case index < len(scopes):
+			tmpMap[newScopes[index]] = &scopes[index]
+		default:
+			return errors.New(ErrorOutOfScopes)
+		}
 	}
 
-	setScopes = tmpMap
+	//building the reverse lookup from the map so that only the last of duplicate names is used
+	for scope = range tmpMap {
+		table.names[scopeIndex(*tmpMap[scope])] = scope
+	}
+
+	table.byName = tmpMap
+	currentScopes.Store(table)
+
 	return nil
 }
@@ -2,9 +2,33 @@ package uuid_test
 
 import (
 	"github.com/4xoc/uuid"
+	"strings"
 	"testing"
+	"unsafe"
 )
 
+// setupScopes replaces the configured scopes with the given names for the duration of a test.
+func setupScopes(t testing.TB, names ...string) {
+	var (
+		newScopes [64]string
+	)
+
+	t.Helper()
+
+	copy(newScopes[:], names)
+
+	uuid.ResetScopes()
+	if err := uuid.SetScopeLayout(testLayout); err != nil {
+		t.Fatal("failed to set scope layout: ", err.Error())
+	}
+
+	if err := uuid.SetScopes(newScopes); err != nil {
+		t.Fatal("failed to set scopes: ", err.Error())
+	}
+
+	t.Cleanup(uuid.ResetScopes)
+}
+
 func TestMain(t *testing.T) {
 	var (
 		myScopes    [64]string
@@ -105,3 +129,331 @@ func TestMain(t *testing.T) {
 		t.Error("UUID shouldn't have been generated")
 	}
 }
+
+func TestNewLowBits(t *testing.T) {
+	var (
+		myUUID *uuid.UUID
+		myCopy *uuid.UUID
+		counts [4]int
+		index  int
+		err    error
+	)
+
+	setupScopes(t, "one", "two")
+
+	for index = 0; index < 10000; index++ {
+		myUUID, err = uuid.New("two")
+		if err != nil {
+			t.Fatal("Expected UUID to be generated but failed with error ", err.Error())
+		}
+
+		counts[myUUID.Bin()[0]&0x03]++
+
+		//the low bits must not affect parsing
+		myCopy, err = uuid.Read(myUUID.Hex())
+		if err != nil || myCopy.Scope() != "two" {
+			t.Fatal("Expected UUID ", myUUID.Hex(), " to be read with scope two")
+		}
+	}
+
+	//each value is expected about 2500 times; anything below 2000 is practically impossible
+	for index = range counts {
+		if counts[index] < 2000 {
+			t.Error("low bits value ", index, " occurred only ", counts[index], " times")
+		}
+	}
+}
+
+func TestSize(t *testing.T) {
+	var (
+		expected = unsafe.Sizeof("") + unsafe.Sizeof([16]byte{})
+	)
+
+	//scope string header and 16 binary bytes without padding on any platform; the hex-string is not
+	//stored anymore
+	if unsafe.Sizeof(uuid.UUID{}) != expected {
+		t.Error("Expected UUID to be ", expected, " bytes but got ", unsafe.Sizeof(uuid.UUID{}))
+	}
+}
+
+func BenchmarkNew(b *testing.B) {
+	setupScopes(b, "one")
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := uuid.New("one"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkValue(b *testing.B) {
+	var (
+		myUUID *uuid.UUID
+	)
+
+	setupScopes(b, "one")
+
+	myUUID, _ = uuid.New("one")
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := myUUID.Value(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestNewValue(t *testing.T) {
+	var (
+		myUUID  uuid.UUID
+		read    uuid.UUID
+		scanned uuid.UUID
+		old     string
+		err     error
+	)
+
+	setupScopes(t, "one", "two")
+
+	myUUID, err = uuid.NewValue("two")
+	if err != nil || myUUID.Scope() != "two" || !myUUID.IsValid() {
+		t.Fatal("unexpected UUID ", myUUID.DebugString(), err)
+	}
+
+	//methods with pointer receivers work on addressable values
+	old = myUUID.Hex()
+	if err = myUUID.Regenerate(); err != nil || myUUID.Hex() == old || myUUID.Scope() != "two" {
+		t.Error("failed to regenerate UUID ", myUUID.DebugString(), err)
+	}
+
+	if err = scanned.Scan(myUUID); err != nil || scanned != myUUID {
+		t.Error("failed to scan UUID ", scanned.DebugString(), err)
+	}
+
+	read, err = uuid.ReadValue(myUUID.Hex())
+	if err != nil || read != myUUID {
+		t.Error("unexpected UUID ", read.DebugString(), err)
+	}
+
+	//errors match New and Read and result in the zero value
+	if myUUID, err = uuid.NewValue("unknown"); err != uuid.ErrMissingScope || myUUID != (uuid.UUID{}) {
+		t.Error("expected ErrMissingScope but got ", myUUID.DebugString(), err)
+	}
+
+	uuid.DeprecateScope("one")
+
+	if myUUID, err = uuid.NewValue("one"); err != uuid.ErrScopeDeprecated || myUUID != (uuid.UUID{}) {
+		t.Error("expected ErrScopeDeprecated but got ", myUUID.DebugString(), err)
+	}
+
+	if read, err = uuid.ReadValue("10000000-0000-0000-0000-000000000010"); err != uuid.ErrBadScope || read != (uuid.UUID{}) {
+		t.Error("expected ErrBadScope but got ", read.DebugString(), err)
+	}
+
+	if read, err = uuid.ReadValue("not a uuid"); err != uuid.ErrBadString || read != (uuid.UUID{}) {
+		t.Error("expected ErrBadString but got ", read.DebugString(), err)
+	}
+}
+
+func TestReadScopeLookup(t *testing.T) {
+	var (
+		myUUID *uuid.UUID
+		err    error
+	)
+
+	//using the last slot and a duplicate name
+	setupScopes(t, "one", "two", "one")
+	uuid.ResetScopes()
+	uuid.SetScopes([64]string{"one", "two", "one", 63: "last"})
+
+	myUUID = mustRead(t, "ff000000-0000-0000-0000-000000000000")
+	if myUUID.Scope() != "last" {
+		t.Error("Expected scope last but got ", myUUID.Scope())
+	}
+
+	myUUID = mustRead(t, "0b000000-0000-0000-0000-000000000000")
+	if myUUID.Scope() != "one" {
+		t.Error("Expected scope one but got ", myUUID.Scope())
+	}
+
+	//the first slot of a duplicate name is not used
+	_, err = uuid.Read("00000000-0000-0000-0000-000000000000")
+	if err == nil || err.Error() != uuid.ErrorBadScope {
+		t.Error("Expected error for unused scope byte")
+	}
+
+	_, err = uuid.Read("10000000-0000-0000-0000-000000000000")
+	if err == nil || err.Error() != uuid.ErrorBadScope {
+		t.Error("Expected error for unknown scope byte")
+	}
+}
+
+func BenchmarkRead(b *testing.B) {
+	var (
+		myUUID *uuid.UUID
+		hex    string
+	)
+
+	setupScopes(b, "one", "two", "three", "four", "five", "six", "seven", "eight")
+
+	myUUID, _ = uuid.New("eight")
+	hex = myUUID.Hex()
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := uuid.Read(hex); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkNewValue stores the generated UUIDs by value, which doesn't allocate in the buffered entropy
+// mode.
+func BenchmarkNewValue(b *testing.B) {
+	var (
+		uuids [64]uuid.UUID
+		err   error
+	)
+
+	setupScopes(b, "one")
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		if uuids[i%len(uuids)], err = uuid.NewValue("one"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkReadValue(b *testing.B) {
+	var (
+		myUUID uuid.UUID
+		hex    string
+		err    error
+	)
+
+	setupScopes(b, "one", "two", "three", "four", "five", "six", "seven", "eight")
+
+	myUUID, _ = uuid.NewValue("eight")
+	hex = myUUID.Hex()
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		if myUUID, err = uuid.ReadValue(hex); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestRegenerate(t *testing.T) {
+	var (
+		myUUID *uuid.UUID
+		nilPtr *uuid.UUID
+		oldHex string
+		err    error
+	)
+
+	setupScopes(t, "one", "two")
+
+	err = nilPtr.Regenerate()
+	if err == nil || err.Error() != uuid.ErrorUninitializedUUID {
+		t.Error("Expected error for nil UUID")
+	}
+
+	err = (&uuid.UUID{}).Regenerate()
+	if err == nil || err.Error() != uuid.ErrorUninitializedUUID {
+		t.Error("Expected error for uninitialized UUID")
+	}
+
+	myUUID, _ = uuid.New("two")
+	oldHex = myUUID.Hex()
+
+	if err = myUUID.Regenerate(); err != nil {
+		t.Fatal("Expected UUID to be regenerated but failed with error ", err.Error())
+	}
+
+	if myUUID.Hex() == oldHex {
+		t.Error("UUID has not changed")
+	}
+
+	if myUUID.Scope() != "two" || mustRead(t, myUUID.Hex()).Scope() != "two" {
+		t.Error("UUID should keep its scope")
+	}
+}
+
+func TestScopesSeq(t *testing.T) {
+	var (
+		names []string
+		bytes []byte
+	)
+
+	uuid.ResetScopes()
+	t.Cleanup(uuid.ResetScopes)
+
+	for range uuid.ScopesSeq() {
+		t.Fatal("no scopes should be yielded before scopes are set")
+	}
+
+	uuid.SetScopes([64]string{"one", "two", 3: "four", 63: "last"})
+
+	for name, scopeByte := range uuid.ScopesSeq() {
+		names = append(names, name)
+		bytes = append(bytes, scopeByte)
+	}
+
+	if strings.Join(names, ",") != "one,two,four,last" || string(bytes) != "\x00\x04\x0c\xfc" {
+		t.Error("unexpected scopes ", names, bytes)
+	}
+
+	//stopping early
+	names = nil
+	for name := range uuid.ScopesSeq() {
+		names = append(names, name)
+		if len(names) == 2 {
+			break
+		}
+	}
+
+	if strings.Join(names, ",") != "one,two" {
+		t.Error("unexpected scopes after break ", names)
+	}
+}
+
+func TestEmptyScope(t *testing.T) {
+	var (
+		err error
+	)
+
+	setupScopes(t, "one", "two")
+
+	//unused entries must not be registered as scope; Counters holds an entry for every registered scope
+	if len(uuid.Counters()) != 2 {
+		t.Error("unexpected scopes ", uuid.Counters())
+	}
+
+	if _, err = uuid.New(""); err != uuid.ErrMissingScope {
+		t.Error("expected ErrMissingScope for empty scope but got ", err)
+	}
+
+	if _, err = uuid.NewBatch("", 2); err != uuid.ErrMissingScope {
+		t.Error("expected ErrMissingScope for empty scope batch but got ", err)
+	}
+
+	//the byte of an unused entry in either layout
+	if _, err = uuid.Read("08000000-0000-0000-0000-000000000008"); err != uuid.ErrBadScope {
+		t.Error("expected ErrBadScope for unused scope byte but got ", err)
+	}
+
+	if err = new(uuid.UUID).Scan(make([]byte, 16)); err != nil {
+		t.Error("expected scope one for first scope byte but got ", err)
+	}
+
+	if err = new(uuid.UUID).Scan([]byte{0x08, 15: 0x08}); err != uuid.ErrBadScope {
+		t.Error("expected ErrBadScope when scanning unused scope byte but got ", err)
+	}
+}
@@ -0,0 +1,94 @@
+// Package uuidtest provides helpers for testing code that uses scoped UUIDs: a scope table that is only
+// installed for the duration of a test and deterministic UUIDs for fixtures and golden files.
+//
+// Since the scope table is global, tests using Scopes must not run in parallel with other tests using
+// the uuid package.
+package uuidtest
+
+import (
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/4xoc/uuid"
+)
+
+// Scopes installs a scope table holding the given names in that order, replacing any scopes that have
+// been set before. All global configuration of the uuid package is reset once the test and its subtests
+// are done.
+func Scopes(t testing.TB, names ...string) {
+	var (
+		table [64]string
+		err   error
+	)
+
+	t.Helper()
+
+	if len(names) > len(table) {
+		t.Fatalf("uuidtest: %d scopes exceed the limit of %d", len(names), len(table))
+	}
+
+	copy(table[:], names)
+
+	uuid.ResetForTesting()
+	t.Cleanup(uuid.ResetForTesting)
+
+	err = uuid.SetScopes(table)
+	if err != nil {
+		t.Fatalf("uuidtest: setting scopes: %s", err)
+	}
+}
+
+// Static returns a UUID of the given scope derived from seed. The same scope and seed always result in
+// the same UUID, across runs and machines, as long as the scope keeps its position in the scope table.
+// Different seeds result in different UUIDs.
+func Static(t testing.TB, scope string, seed string) *uuid.UUID {
+	var (
+		myUUID *uuid.UUID
+		err    error
+	)
+
+	t.Helper()
+
+	myUUID, err = uuid.NewFromReader(scope, strings.NewReader(seed))
+	if err != nil {
+		t.Fatalf("uuidtest: static UUID of scope %q: %s", scope, err)
+	}
+
+	return myUUID
+}
+
+// Sequence returns a generator of UUIDs of the given scope holding an incrementing number, starting at 1,
+// in their last 8 bytes, e.g. "04000000-0000-0000-0000-000000000001" for the second scope. It is safe
+// for concurrent use, and every call of Sequence starts a new sequence.
+//
+// Sequence panics if the scope isn't set.
+func Sequence(scope string) func() *uuid.UUID {
+	var (
+		min     *uuid.UUID
+		hi      uint64
+		counter atomic.Uint64
+		err     error
+	)
+
+	min, _, err = uuid.ScopeBounds(scope)
+	if err != nil {
+		panic("uuidtest: sequence of scope " + scope + ": " + err.Error())
+	}
+
+	hi, _ = min.Uint64Pair()
+
+	return func() *uuid.UUID {
+		var (
+			myUUID *uuid.UUID
+			err    error
+		)
+
+		myUUID, err = uuid.FromUint64Pair(hi, counter.Add(1))
+		if err != nil {
+			panic("uuidtest: sequence of scope " + scope + ": " + err.Error())
+		}
+
+		return myUUID
+	}
+}
@@ -0,0 +1,141 @@
+package uuidtest_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/4xoc/uuid"
+	"github.com/4xoc/uuid/uuidtest"
+)
+
+func TestScopes(t *testing.T) {
+	t.Run("installed", func(t *testing.T) {
+		uuidtest.Scopes(t, "one", "two")
+
+		if myUUID, err := uuid.New("two"); err != nil || myUUID.Bin()[0]&0xfc != 0x04 {
+			t.Error("unexpected UUID ", myUUID.Hex(), " ", err)
+		}
+	})
+
+	if _, err := uuid.New("one"); err != uuid.ErrMissingScope {
+		t.Error("scopes should be reset after the test: ", err)
+	}
+
+	t.Run("again", func(t *testing.T) {
+		uuidtest.Scopes(t, "three")
+
+		if _, err := uuid.New("three"); err != nil {
+			t.Error("scopes should be installable again: ", err)
+		}
+	})
+}
+
+func TestStatic(t *testing.T) {
+	var (
+		myUUID *uuid.UUID
+		myCopy *uuid.UUID
+		err    error
+	)
+
+	uuidtest.Scopes(t, "user", "blob")
+
+	myUUID = uuidtest.Static(t, "blob", "alice")
+
+	//the value must not change between releases, golden files depend on it
+	if myUUID.Hex() != "07d806c9-7f0e-00af-1a1f-c3328fa763a9" {
+		t.Error("unexpected UUID ", myUUID.Hex())
+	}
+
+	if !uuid.Equal(myUUID, uuidtest.Static(t, "blob", "alice")) ||
+		uuid.Equal(myUUID, uuidtest.Static(t, "blob", "bob")) {
+		t.Error("static UUIDs should only depend on scope and seed")
+	}
+
+	myCopy, err = uuid.Read(myUUID.Hex())
+	if err != nil || myCopy.Scope() != "blob" {
+		t.Error("static UUIDs should be accepted by Read: ", err)
+	}
+}
+
+func TestSequence(t *testing.T) {
+	var (
+		next  func() *uuid.UUID
+		other func() *uuid.UUID
+		seen  sync.Map
+		wg    sync.WaitGroup
+	)
+
+	uuidtest.Scopes(t, "user", "blob")
+
+	next = uuidtest.Sequence("blob")
+	other = uuidtest.Sequence("user")
+
+	for _, expected := range []string{
+		"04000000-0000-0000-0000-000000000001",
+		"04000000-0000-0000-0000-000000000002",
+	} {
+		if myUUID, err := uuid.Read(next().Hex()); err != nil || myUUID.Hex() != expected {
+			t.Error("unexpected UUID ", myUUID.Hex(), " ", err)
+		}
+	}
+
+	if other().Hex() != "00000000-0000-0000-0000-000000000001" {
+		t.Error("sequences should be independent")
+	}
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < 100; j++ {
+				if _, loaded := seen.LoadOrStore(next().Bin(), true); loaded {
+					t.Error("sequence returned a UUID twice")
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for unknown scope")
+		}
+	}()
+
+	uuidtest.Sequence("missing")
+}
+
+func TestVectors(t *testing.T) {
+	var (
+		sets []uuidtest.VectorSet
+	)
+
+	sets = uuidtest.Vectors()
+	if len(sets) != 3 {
+		t.Fatal("unexpected number of vector sets ", len(sets))
+	}
+
+	for _, set := range sets {
+		t.Run(set.Name, func(t *testing.T) {
+			set.Install(t)
+
+			for index, vector := range set.Vectors {
+				myUUID, err := uuid.Read(vector.Hex)
+
+				if vector.Scope == "" && err != uuid.ErrBadScope || vector.Scope != "" && (err != nil || myUUID.Scope() != vector.Scope) {
+					t.Error("vector ", index, ": unexpected result ", myUUID.DebugString(), err)
+				}
+			}
+		})
+	}
+
+	//vectors are copies
+	sets[0].Vectors[0].Hex = ""
+
+	if uuidtest.Vectors()[0].Vectors[0].Hex == "" {
+		t.Error("vectors should not be shared")
+	}
+}
@@ -0,0 +1,108 @@
+package uuidtest
+
+import (
+	_ "embed"
+	"encoding/json"
+	"testing"
+
+	"github.com/4xoc/uuid"
+)
+
+// vectorsJSON holds the test vectors. Changing them changes the mapping between the binary and the text
+// representation, which breaks UUIDs stored by other implementations.
+//
+//go:embed vectors.json
+var vectorsJSON []byte
+
+// VectorSet is a scope configuration along with test vectors pinning how UUIDs of that configuration
+// are represented. Other implementations of scoped UUIDs can use the sets to validate that they are
+// compatible with this package.
+type VectorSet struct {
+	// Name describes the configuration.
+	Name string `json:"name"`
+	// Layout is "leading" or "trailing" (see uuid.SetScopeLayout).
+	Layout string `json:"layout"`
+	// Width is the number of bits of the scope (see uuid.SetScopeWidth).
+	Width int `json:"width"`
+	// Scopes maps the position of each scope in the scope table to its name.
+	Scopes map[int]string `json:"scopes"`
+	// Vectors holds the test vectors of the configuration.
+	Vectors []Vector `json:"vectors"`
+}
+
+// Vector is a single UUID of a VectorSet.
+type Vector struct {
+	// Random holds the 16 random bytes, hex encoded, that uuid.New turns into the UUID. It is empty for
+	// vectors with an unknown scope.
+	Random string `json:"random,omitempty"`
+	// Bin holds the 16 bytes of the binary representation, hex encoded.
+	Bin string `json:"bin"`
+	// Hex is the canonical hex-string.
+	Hex string `json:"hex"`
+	// Scope is the scope of the UUID. It is empty if the UUID is of an unknown scope and must be rejected.
+	Scope string `json:"scope"`
+}
+
+// Vectors returns the test vectors pinning the binary and the text representation of UUIDs. The vectors
+// are never changed without a deliberate decision, since any change breaks existing UUIDs.
+func Vectors() []VectorSet {
+	var (
+		vectors struct {
+			Sets []VectorSet `json:"sets"`
+		}
+		err error
+	)
+
+	err = json.Unmarshal(vectorsJSON, &vectors)
+	if err != nil {
+		panic("uuidtest: decoding test vectors: " + err.Error())
+	}
+
+	return vectors.Sets
+}
+
+// Install installs the scope configuration of the set like Scopes. All global configuration of the uuid
+// package is reset once the test and its subtests are done.
+func (set VectorSet) Install(t testing.TB) {
+	var (
+		layout uuid.ScopeLayout
+		table  [256]string
+		err    error
+	)
+
+	t.Helper()
+
+	switch set.Layout {
+	case "leading":
+		layout = uuid.ScopeLeading
+	case "trailing":
+		layout = uuid.ScopeTrailing
+	default:
+		t.Fatalf("uuidtest: unknown layout %q of vector set %s", set.Layout, set.Name)
+	}
+
+	for index, name := range set.Scopes {
+		if index < 0 || index >= len(table) {
+			t.Fatalf("uuidtest: scope %q of vector set %s is out of range", name, set.Name)
+		}
+
+		table[index] = name
+	}
+
+	uuid.ResetForTesting()
+	t.Cleanup(uuid.ResetForTesting)
+
+	err = uuid.SetScopeLayout(layout)
+	if err == nil {
+		err = uuid.SetScopeWidth(set.Width)
+	}
+
+	//SetWideScopes rejects scopes beyond the first 64 with 6 bit scopes
+	if err == nil {
+		err = uuid.SetWideScopes(table)
+	}
+
+	if err != nil {
+		t.Fatalf("uuidtest: installing vector set %s: %s", set.Name, err)
+	}
+}
@@ -0,0 +1,12 @@
+module github.com/4xoc/uuid/uuidzap
+
+go 1.24
+
+require (
+	github.com/4xoc/uuid v0.0.0
+	go.uber.org/zap v1.27.0
+)
+
+require go.uber.org/multierr v1.10.0 // indirect
+
+replace github.com/4xoc/uuid => ../
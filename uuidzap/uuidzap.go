@@ -0,0 +1,46 @@
+// Package uuidzap logs uuid.UUID values with zap without reflection.
+//
+// Field logs a UUID as an object holding its canonical hex-string and its scope:
+//
+//	logger.Info("user created", uuidzap.Field("user", myUUID))
+//	// {"msg":"user created","user":{"hex":"0529a1d0-84f3-4d8d-b6cc-682d1ca34dae","scope":"user"}}
+//
+// The field names are part of the API and won't change, so that log parsers can rely on them.
+//
+// The package lives in its own module so that the uuid package doesn't depend on zap.
+package uuidzap
+
+import (
+	"github.com/4xoc/uuid"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// HexKey is the name of the field holding the canonical hex-string.
+	HexKey string = "hex"
+	// ScopeKey is the name of the field holding the scope.
+	ScopeKey string = "scope"
+)
+
+// UUID is a uuid.UUID implementing zapcore.ObjectMarshaler. Uninitialized UUIDs are logged with an
+// empty hex-string and scope.
+type UUID uuid.UUID
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, adding the canonical hex-string as HexKey and the
+// scope as ScopeKey.
+func (u *UUID) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString(HexKey, (*uuid.UUID)(u).Hex())
+	enc.AddString(ScopeKey, (*uuid.UUID)(u).Scope())
+
+	return nil
+}
+
+// Field returns a zap.Field logging the UUID as an object (see UUID). A nil UUID is logged as null.
+func Field(key string, u *uuid.UUID) zap.Field {
+	if u == nil {
+		return zap.Field{Key: key, Type: zapcore.ReflectType}
+	}
+
+	return zap.Object(key, (*UUID)(u))
+}
@@ -0,0 +1,100 @@
+package uuidzap_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/4xoc/uuid"
+	"github.com/4xoc/uuid/uuidzap"
+	"go.uber.org/zap"
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestMain(m *testing.M) {
+	if err := uuid.SetScopes([64]string{"one", "two"}); err != nil {
+		panic(err)
+	}
+
+	os.Exit(m.Run())
+}
+
+// encode returns the JSON encoding of the fields without any entry metadata.
+func encode(t testing.TB, fields ...zap.Field) string {
+	var (
+		enc zapcore.Encoder
+		buf *buffer.Buffer
+		out string
+		err error
+	)
+
+	t.Helper()
+
+	enc = zapcore.NewJSONEncoder(zapcore.EncoderConfig{})
+
+	buf, err = enc.EncodeEntry(zapcore.Entry{}, fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out = buf.String()
+	buf.Free()
+
+	return out
+}
+
+func TestField(t *testing.T) {
+	var (
+		myUUID *uuid.UUID
+		out    string
+		err    error
+	)
+
+	myUUID, err = uuid.New("two")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out = encode(t, uuidzap.Field("user", myUUID))
+	if out != `{"user":{"hex":"`+myUUID.Hex()+`","scope":"two"}}`+"\n" {
+		t.Error("unexpected output ", out)
+	}
+
+	out = encode(t, uuidzap.Field("user", nil))
+	if out != `{"user":null}`+"\n" {
+		t.Error("unexpected output for nil UUID ", out)
+	}
+
+	out = encode(t, uuidzap.Field("user", &uuid.UUID{}))
+	if out != `{"user":{"hex":"","scope":""}}`+"\n" {
+		t.Error("unexpected output for uninitialized UUID ", out)
+	}
+}
+
+func BenchmarkField(b *testing.B) {
+	var (
+		myUUID *uuid.UUID
+	)
+
+	myUUID, _ = uuid.New("one")
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		encode(b, uuidzap.Field("user", myUUID))
+	}
+}
+
+func BenchmarkString(b *testing.B) {
+	var (
+		myUUID *uuid.UUID
+	)
+
+	myUUID, _ = uuid.New("one")
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		encode(b, zap.String("user", myUUID.Hex()))
+	}
+}
@@ -0,0 +1,81 @@
+package uuid
+
+import (
+	"errors"
+	"strings"
+)
+
+// ScopeNotAllowedError is returned by ValidateScoped when a UUID is valid but its scope is not one of
+// the allowed scopes. errors.Is reports it as ErrScopeNotAllowed.
+type ScopeNotAllowedError struct {
+	// Scope is the scope of the UUID.
+	Scope string
+	// Allowed holds the allowed scopes.
+	Allowed []string
+}
+
+// Error returns the error message including the actual and the allowed scopes.
+func (err *ScopeNotAllowedError) Error() string {
+	return ErrorScopeNotAllowed + ": " + err.Scope + " (allowed: " + strings.Join(err.Allowed, ", ") + ")"
+}
+
+// Is reports whether target is ErrScopeNotAllowed.
+func (err *ScopeNotAllowedError) Is(target error) bool {
+	return target == ErrScopeNotAllowed
+}
+
+// ValidateScoped parses the string like Read and checks that the scope of the UUID is one of the
+// allowed scopes. Without any allowed scopes, every known scope is accepted.
+//
+// Malformatted strings return ErrBadString and unknown scopes ErrBadScope, both meaning the input is
+// not a valid UUID. A valid UUID of a scope that is not allowed returns a *ScopeNotAllowedError.
+func ValidateScoped(input string, allowed ...string) (*UUID, error) {
+	var (
+		uuid *UUID
+		err  error
+	)
+
+	uuid, err = Read(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(allowed) > 0 && !uuid.ScopeMatches(allowed) {
+		return nil, &ScopeNotAllowedError{Scope: uuid.scope, Allowed: allowed}
+	}
+
+	return uuid, nil
+}
+
+// Validate checks the internal consistency of the UUID. The scope must be set and currently known, and
+// the scope byte of the binary representation must belong to that scope. The hex-string representation
+// is always derived from the binary one and therefore can't be inconsistent.
+//
+// Nil and uninitialized UUIDs return ErrorUninitializedUUID, unknown scopes ErrMissingScope and a scope
+// byte not matching the scope ErrBadScope.
+func (uuid *UUID) Validate() error {
+	var (
+		table *scopeTable
+	)
+
+	if uuid == nil || uuid.scope == "" {
+		return errors.New(ErrorUninitializedUUID)
+	}
+
+	table = loadScopes()
+
+	if table.byName[uuid.scope] == nil {
+		return ErrMissingScope
+	}
+
+	if uuid.scopeBits() != *table.byName[uuid.scope] {
+		return ErrBadScope
+	}
+
+	return nil
+}
+
+// IsValid reports whether the UUID passes Validate.
+func (uuid *UUID) IsValid() bool {
+	return uuid.Validate() == nil
+}
@@ -0,0 +1,96 @@
+package uuid_test
+
+import (
+	"errors"
+	"github.com/4xoc/uuid"
+	"testing"
+)
+
+func TestValidateScoped(t *testing.T) {
+	var (
+		myUUID   *uuid.UUID
+		notAllow *uuid.ScopeNotAllowedError
+		err      error
+	)
+
+	setupScopes(t, "user", "post", "comment")
+
+	testCases := []struct {
+		input   string
+		allowed []string
+		scope   string
+		err     error
+	}{
+		{fixture("04000000-0000-0000-0000-000000000000"), []string{"post"}, "post", nil},
+		{fixture("04000000-0000-0000-0000-000000000000"), []string{"user", "post"}, "post", nil},
+		{fixture("04000000-0000-0000-0000-000000000000"), nil, "post", nil},
+		{"", nil, "", uuid.ErrBadString},
+		{"04000000-0000-0000-0000-00000000000", []string{"post"}, "", uuid.ErrBadString},
+		{fixture("fc000000-0000-0000-0000-000000000000"), nil, "", uuid.ErrBadScope},
+		{fixture("fc000000-0000-0000-0000-000000000000"), []string{"post"}, "", uuid.ErrBadScope},
+		{fixture("04000000-0000-0000-0000-000000000000"), []string{"user"}, "", uuid.ErrScopeNotAllowed},
+	}
+
+	for index := range testCases {
+		myUUID, err = uuid.ValidateScoped(testCases[index].input, testCases[index].allowed...)
+
+		if !errors.Is(err, testCases[index].err) || (err == nil) != (testCases[index].err == nil) {
+			t.Error("test case ", index, ": expected error ", testCases[index].err, " but got ", err)
+			continue
+		}
+
+		if err != nil && myUUID != nil {
+			t.Error("test case ", index, ": UUID should be nil on error")
+		}
+
+		if err == nil && myUUID.Scope() != testCases[index].scope {
+			t.Error("test case ", index, ": unexpected scope ", myUUID.Scope())
+		}
+	}
+
+	_, err = uuid.ValidateScoped(fixture("08000000-0000-0000-0000-000000000000"), "user", "post")
+	if !errors.As(err, &notAllow) || notAllow.Scope != "comment" || len(notAllow.Allowed) != 2 {
+		t.Fatal("unexpected error ", err)
+	}
+
+	if err.Error() != uuid.ErrorScopeNotAllowed+": comment (allowed: user, post)" {
+		t.Error("unexpected error message ", err.Error())
+	}
+}
+
+func TestValidate(t *testing.T) {
+	var (
+		myUUID *uuid.UUID
+		nilPtr *uuid.UUID
+	)
+
+	setupScopes(t, "user", "post")
+
+	myUUID = mustNew(t, "post")
+
+	if err := myUUID.Validate(); err != nil || !myUUID.IsValid() {
+		t.Fatal("new UUID should be valid but got ", err)
+	}
+
+	if nilPtr.IsValid() || nilPtr.Validate().Error() != uuid.ErrorUninitializedUUID {
+		t.Error("nil UUID should be invalid")
+	}
+
+	if new(uuid.UUID).IsValid() || new(uuid.UUID).Validate().Error() != uuid.ErrorUninitializedUUID {
+		t.Error("zero value UUID should be invalid")
+	}
+
+	//same scope name on a different byte
+	setupScopes(t, "post", "user")
+
+	if err := myUUID.Validate(); err != uuid.ErrBadScope {
+		t.Error("expected ErrBadScope but got ", err)
+	}
+
+	//scope no longer known
+	setupScopes(t, "user")
+
+	if err := myUUID.Validate(); err != uuid.ErrMissingScope {
+		t.Error("expected ErrMissingScope but got ", err)
+	}
+}
@@ -0,0 +1,572 @@
+package uuid_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"github.com/4xoc/uuid"
+	"io"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+// valuer provides a fixed value via driver.Valuer.
+type valuer struct {
+	value driver.Value
+}
+
+func (v valuer) Value() (driver.Value, error) {
+	return v.value, nil
+}
+
+// loopValuer provides itself as its value.
+type loopValuer struct{}
+
+func (v loopValuer) Value() (driver.Value, error) {
+	return v, nil
+}
+
+// recorded holds the arguments of the last statement executed via recordingConnector.
+var recorded []driver.Value
+
+// errNotNull is returned by recordingConnector for NULL arguments of "INSERT NOT NULL" statements.
+var errNotNull = errors.New("NOT NULL constraint failed")
+
+// errConnection is returned by recordingConnector for "SELECT ROWS BROKEN" statements after the last row.
+var errConnection = errors.New("connection reset")
+
+// recordingConnector opens database/sql connections recording the arguments of executed statements.
+type recordingConnector struct{}
+
+func (c *recordingConnector) Connect(context.Context) (driver.Conn, error) {
+	return recordingConn{}, nil
+}
+
+func (c *recordingConnector) Driver() driver.Driver {
+	return nil
+}
+
+// recordingConn is a connection of recordingConnector.
+type recordingConn struct{}
+
+func (recordingConn) Prepare(query string) (driver.Stmt, error) {
+	return recordingStmt(query), nil
+}
+
+func (recordingConn) Close() error {
+	return nil
+}
+
+func (recordingConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions are not supported")
+}
+
+// recordingStmt is a statement of recordingConn.
+type recordingStmt string
+
+func (recordingStmt) Close() error {
+	return nil
+}
+
+func (recordingStmt) NumInput() int {
+	return -1
+}
+
+func (stmt recordingStmt) Exec(args []driver.Value) (driver.Result, error) {
+	recorded = args
+
+	for index := range args {
+		if args[index] == nil && stmt == "INSERT NOT NULL" {
+			return nil, errNotNull
+		}
+	}
+
+	return driver.RowsAffected(1), nil
+}
+
+// Query returns a single row holding the arguments of the last executed statement. "SELECT ROWS" returns
+// a single column with one row per argument instead. "SELECT ROWS BROKEN" does the same but fails with
+// errConnection after the last row.
+func (stmt recordingStmt) Query(args []driver.Value) (driver.Rows, error) {
+	var (
+		rows [][]driver.Value
+	)
+
+	if stmt != "SELECT ROWS" && stmt != "SELECT ROWS BROKEN" {
+		return &recordedRows{columns: len(recorded), rows: [][]driver.Value{recorded}}, nil
+	}
+
+	for index := range recorded {
+		rows = append(rows, recorded[index:index+1])
+	}
+
+	if stmt == "SELECT ROWS BROKEN" {
+		return &recordedRows{columns: 1, rows: rows, err: errConnection}, nil
+	}
+
+	return &recordedRows{columns: 1, rows: rows}, nil
+}
+
+// recordedRows holds the rows returned by recordingStmt.
+type recordedRows struct {
+	columns int
+	rows    [][]driver.Value
+	// err is returned after the last row instead of io.EOF, if set.
+	err error
+}
+
+func (rows *recordedRows) Columns() []string {
+	return make([]string, rows.columns)
+}
+
+func (rows *recordedRows) Close() error {
+	return nil
+}
+
+func (rows *recordedRows) Next(dest []driver.Value) error {
+	if len(rows.rows) == 0 && rows.err != nil {
+		return rows.err
+	}
+
+	if len(rows.rows) == 0 {
+		return io.EOF
+	}
+
+	copy(dest, rows.rows[0])
+	rows.rows = rows.rows[1:]
+
+	return nil
+}
+
+// stringer provides a fixed string via fmt.Stringer.
+type stringer string
+
+func (s stringer) String() string {
+	return string(s)
+}
+
+func TestValue(t *testing.T) {
+	var (
+		uuids   map[string]*uuid.UUID
+		myUUID  *uuid.UUID
+		scanned uuid.UUID
+		factory *uuid.ScopeFactory
+		batch   []*uuid.UUID
+		hi, lo  uint64
+		value   interface{}
+		name    string
+		err     error
+	)
+
+	setupScopes(t, "one", "two")
+
+	myUUID, _ = uuid.New("two")
+	batch, _ = uuid.NewBatch("two", 1)
+	factory, _ = uuid.ForScope("two")
+	hi, lo = myUUID.Uint64Pair()
+
+	uuids = map[string]*uuid.UUID{"New": myUUID, "NewBatch": batch[0]}
+	uuids["NewCompat"], _ = uuid.NewCompat("two")
+	uuids["NewOrdered"], _ = uuid.NewOrdered("two")
+	uuids["ScopeFactory"], _ = factory.New()
+	uuids["Read"], _ = uuid.Read(myUUID.Hex())
+	uuids["FromRFC"], _ = uuid.FromRFC(myUUID.Bin())
+	uuids["ImportForeign"], _ = uuid.ImportForeign(myUUID.Hex(), "two")
+	uuids["FromProtoBytes"], _ = uuid.FromProtoBytes(myUUID.ToProtoBytes())
+	uuids["FromUint64Pair"], _ = uuid.FromUint64Pair(hi, lo)
+	uuids["FromBigInt"], _ = uuid.FromBigInt(new(big.Int).Set(myUUID.BigInt()))
+	uuids["Set"] = uuid.NewSet(myUUID).Slice()[0]
+
+	if err = scanned.Scan(myUUID.ToProtoBytes()); err != nil {
+		t.Fatal("Expected UUID to be scanned but failed with error ", err.Error())
+	}
+	uuids["Scan"] = &scanned
+
+	for name, myUUID = range uuids {
+		if myUUID == nil {
+			t.Error(name, ": UUID has not been created")
+			continue
+		}
+
+		value, err = myUUID.Value()
+		if err != nil {
+			t.Error(name, ": Expected value but failed with error ", err.Error())
+			continue
+		}
+
+		if value.(string) != myUUID.Hex() || len(value.(string)) != 36 {
+			t.Error(name, ": unexpected value ", value)
+		}
+
+		if mustRead(t, value.(string)).Bin() != myUUID.Bin() {
+			t.Error(name, ": value doesn't match binary representation")
+		}
+	}
+
+	//zero value
+	value, err = uuid.UUID{}.Value()
+	if err != nil || value != nil {
+		t.Error("Expected NULL for zero value but got ", value, err)
+	}
+
+	//binary data without a scope
+	_, err = uuid.Unscoped([16]byte{0x04}).Value()
+	if err == nil || err.Error() != uuid.ErrorMalformattedHex {
+		t.Error("Expected error for UUID without scope")
+	}
+}
+
+func TestValueArgs(t *testing.T) {
+	var (
+		db     *sql.DB
+		myUUID *uuid.UUID
+		err    error
+	)
+
+	setupScopes(t, "one", "two")
+
+	myUUID = mustNew(t, "two")
+
+	db = sql.OpenDB(&recordingConnector{})
+	defer db.Close()
+
+	testCases := []struct {
+		arg   interface{}
+		value driver.Value
+	}{
+		{myUUID, myUUID.Hex()},
+		{*myUUID, myUUID.Hex()},
+		{(*uuid.UUID)(nil), nil},
+		{&uuid.UUID{}, nil},
+		{uuid.UUID{}, nil},
+	}
+
+	for index := range testCases {
+		recorded = nil
+
+		if _, err = db.Exec("INSERT", testCases[index].arg); err != nil {
+			t.Error("test case ", index, ": unexpected error ", err)
+			continue
+		}
+
+		if len(recorded) != 1 || recorded[0] != testCases[index].value {
+			t.Error("test case ", index, ": unexpected argument ", recorded)
+		}
+	}
+
+	//NOT NULL columns reject unset UUIDs at the database, not in Value
+	_, err = db.Exec("INSERT NOT NULL", uuid.UUID{})
+	if err != errNotNull {
+		t.Error("Expected NOT NULL violation but got ", err)
+	}
+
+	_, err = db.Exec("INSERT", uuid.Unscoped([16]byte{0x04}))
+	if err == nil || !strings.Contains(err.Error(), uuid.ErrorMalformattedHex) {
+		t.Error("Expected error for UUID without scope but got ", err)
+	}
+}
+
+func TestNull(t *testing.T) {
+	var (
+		db      *sql.DB
+		myUUID  *uuid.UUID
+		scanned sql.Null[uuid.UUID]
+		err     error
+	)
+
+	setupScopes(t, "one", "two")
+
+	myUUID = mustNew(t, "two")
+
+	db = sql.OpenDB(&recordingConnector{})
+	defer db.Close()
+
+	testCases := []struct {
+		arg   sql.Null[uuid.UUID]
+		value driver.Value
+	}{
+		{sql.Null[uuid.UUID]{V: *myUUID, Valid: true}, myUUID.Hex()},
+		{sql.Null[uuid.UUID]{}, nil},
+		//an unset UUID is NULL even if marked as valid
+		{sql.Null[uuid.UUID]{Valid: true}, nil},
+	}
+
+	for index := range testCases {
+		recorded = nil
+
+		if _, err = db.Exec("INSERT", testCases[index].arg); err != nil {
+			t.Error("test case ", index, ": unexpected error ", err)
+			continue
+		}
+
+		if len(recorded) != 1 || recorded[0] != testCases[index].value {
+			t.Error("test case ", index, ": unexpected argument ", recorded)
+		}
+
+		//selecting the inserted value again
+		scanned = sql.Null[uuid.UUID]{V: *mustNew(t, "one"), Valid: true}
+
+		if err = db.QueryRow("SELECT").Scan(&scanned); err != nil {
+			t.Error("test case ", index, ": unexpected error ", err)
+			continue
+		}
+
+		if testCases[index].value == nil && (scanned.Valid || scanned.V != uuid.UUID{}) {
+			t.Error("test case ", index, ": NULL should result in an invalid zero UUID ", scanned)
+		}
+
+		if testCases[index].value != nil && (!scanned.Valid || scanned.V.Hex() != myUUID.Hex() || scanned.V.Scope() != "two") {
+			t.Error("test case ", index, ": unexpected UUID ", scanned.V.Hex())
+		}
+	}
+
+	recorded = []driver.Value{"not a UUID"}
+
+	if err = db.QueryRow("SELECT").Scan(&scanned); err == nil || !strings.Contains(err.Error(), uuid.ErrorBadString) {
+		t.Error("Expected error for malformatted value but got ", err)
+	}
+}
+
+// TestScanReuse scans the rows of a query into the same UUID. A row that fails to scan must not leave
+// the UUID of the previous row behind.
+func TestScanReuse(t *testing.T) {
+	var (
+		db      *sql.DB
+		rows    *sql.Rows
+		first   *uuid.UUID
+		last    *uuid.UUID
+		scanned uuid.UUID
+		results []string
+		err     error
+	)
+
+	setupScopes(t, "one", "two")
+
+	first = mustNew(t, "one")
+	last = mustNew(t, "two")
+
+	db = sql.OpenDB(&recordingConnector{})
+	defer db.Close()
+
+	recorded = []driver.Value{first.Hex(), "05a1b2c3-d4e5", nil, []byte{0xfc, 15: 0xfc}, last.Hex()}
+
+	rows, err = db.Query("SELECT ROWS")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		if err = rows.Scan(&scanned); err != nil && scanned != (uuid.UUID{}) {
+			t.Error("failed scan kept ", scanned.DebugString())
+		}
+
+		results = append(results, scanned.Hex())
+	}
+
+	if rows.Err() != nil || len(results) != 5 || results[0] != first.Hex() || results[1] != "" || results[2] != "" ||
+		results[3] != "" || results[4] != last.Hex() {
+		t.Error("unexpected results ", results, rows.Err())
+	}
+
+	//the same holds for the decoders
+	scanned = *first
+	if err = scanned.UnmarshalText([]byte("05a1b2c3-d4e5")); err == nil || scanned != (uuid.UUID{}) {
+		t.Error("failed UnmarshalText kept ", scanned.DebugString())
+	}
+
+	scanned = *first
+	if err = json.Unmarshal([]byte(`"fc000000-0000-0000-0000-0000000000fc"`), &scanned); err == nil || scanned != (uuid.UUID{}) {
+		t.Error("failed json.Unmarshal kept ", scanned.DebugString())
+	}
+
+	scanned = *first
+	if err = scanned.UnmarshalCBOR([]byte{0x50, 0xfc}); err == nil || scanned != (uuid.UUID{}) {
+		t.Error("failed UnmarshalCBOR kept ", scanned.DebugString())
+	}
+}
+
+func TestScanSources(t *testing.T) {
+	var (
+		myUUID  *uuid.UUID
+		scanned uuid.UUID
+		bin     [16]byte
+		err     error
+	)
+
+	setupScopes(t, "one", "two")
+
+	myUUID = mustNew(t, "two")
+	bin = myUUID.Bin()
+
+	testCases := []struct {
+		src interface{}
+		err string
+	}{
+		{bin[:], ""},
+		{myUUID.Hex(), ""},
+		{[]byte(myUUID.Hex()), ""},
+		{[]byte(myUUID.CompactHex()), ""},
+		{*myUUID, ""},
+		{myUUID, ""},
+		{bin, ""},
+		{valuer{bin}, ""},
+		{valuer{myUUID.Hex()}, ""},
+		{valuer{bin[:]}, ""},
+		{valuer{valuer{myUUID}}, ""},
+		{stringer(myUUID.Hex()), ""},
+		{"foo", uuid.ErrorBadString},
+		{[]byte(strings.ToUpper(myUUID.Hex())), uuid.ErrorBadString},
+		{[]byte("z" + myUUID.CompactHex()[1:]), uuid.ErrorBadString},
+		{[]byte("fc" + myUUID.CompactHex()[2:30] + "fc"), uuid.ErrorBadScope},
+		{"fc000000-0000-0000-0000-0000000000fc", uuid.ErrorBadScope},
+		{stringer("foo"), uuid.ErrorBadString},
+		{loopValuer{}, uuid.ErrorScanDepth},
+		{42, uuid.ErrorBadSource + ": int"},
+		{&bin, uuid.ErrorBadSource + ": *[16]uint8"},
+		{[]byte{}, uuid.ErrorBadLength},
+		{bin[:15], uuid.ErrorBadLength},
+		{valuer{int64(42)}, uuid.ErrorBadSource + ": int64"},
+	}
+
+	for index := range testCases {
+		scanned = uuid.UUID{}
+		err = scanned.Scan(testCases[index].src)
+
+		if testCases[index].err == "" {
+			if err != nil || scanned.Bin() != bin || scanned.Scope() != "two" {
+				t.Error("test case ", index, ": expected scanned UUID but got ", err)
+			}
+			continue
+		}
+
+		if err == nil || err.Error() != testCases[index].err {
+			t.Error("test case ", index, ": expected error ", testCases[index].err, " but got ", err)
+		}
+
+		if scanned != (uuid.UUID{}) {
+			t.Error("test case ", index, ": failed scan modified the UUID")
+		}
+	}
+}
+
+func TestScanUUID(t *testing.T) {
+	var (
+		source  *uuid.UUID
+		scanned uuid.UUID
+		bin     [16]byte
+	)
+
+	setupScopes(t, "one", "two")
+
+	source = mustNew(t, "two")
+	bin = source.Bin()
+
+	for index, src := range []interface{}{source, *source} {
+		scanned = uuid.UUID{}
+
+		if err := scanned.Scan(src); err != nil || scanned.Bin() != bin || scanned.Scope() != "two" ||
+			scanned.Hex() != source.Hex() {
+			t.Error("test case ", index, ": expected copy of source but got ", err)
+		}
+	}
+
+	//the copy doesn't alias the source
+	if err := source.Regenerate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if scanned.Bin() != bin {
+		t.Error("modifying the source changed the scanned UUID")
+	}
+
+	//nil and uninitialized sources behave like NULL
+	for index, src := range []interface{}{nil, valuer{nil}, (*uuid.UUID)(nil), &uuid.UUID{}, uuid.UUID{}} {
+		scanned = *source
+
+		if err := scanned.Scan(src); err != nil || scanned != (uuid.UUID{}) {
+			t.Error("test case ", index, ": expected zero value but got ", err)
+		}
+	}
+}
+
+func TestScanCompact(t *testing.T) {
+	var (
+		scanned uuid.UUID
+		err     error
+	)
+
+	setupScopes(t, "one", "two")
+
+	//the first 16 bytes were once copied as binary data, resulting in 30356131-6232-6333-6434-653566366137
+	err = scanned.Scan([]byte("05a1b2c3d4e5f6a7b8c9d0e1f2a3b405"))
+	if err != nil || scanned.Hex() == "30356131-6232-6333-6434-653566366137" || scanned.Hex() != "05a1b2c3-d4e5-f6a7-b8c9-d0e1f2a3b405" || scanned.Scope() != "two" {
+		t.Error("unexpected result of scanning compact hex ", scanned.Hex(), err)
+	}
+
+	//non-hex characters must not fall back to binary data
+	scanned = uuid.UUID{}
+
+	err = scanned.Scan([]byte("05a1b2c3d4e5f6a7b8c9d0e1f2a3b40x"))
+	if err != uuid.ErrBadString || scanned != (uuid.UUID{}) {
+		t.Error("expected ErrBadString but got ", err)
+	}
+}
+
+func BenchmarkValueInsertLoop(b *testing.B) {
+	var (
+		uuids []*uuid.UUID
+	)
+
+	setupScopes(b, "one")
+
+	uuids, _ = uuid.NewBatch("one", 1000)
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		for _, myUUID := range uuids {
+			if _, err := myUUID.Value(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+// BenchmarkScan measures Scan for the sources returned by drivers for binary and text columns.
+func BenchmarkScan(b *testing.B) {
+	var (
+		myUUID *uuid.UUID
+		bin    [16]byte
+	)
+
+	setupScopes(b, "one", "two", "three", "four", "five", "six", "seven", "eight")
+
+	myUUID, _ = uuid.New("eight")
+	bin = myUUID.Bin()
+
+	for name, src := range map[string]interface{}{
+		"Binary":      bin[:],
+		"String":      myUUID.Hex(),
+		"TextBytes":   []byte(myUUID.Hex()),
+		"CompactText": []byte(myUUID.CompactHex()),
+	} {
+		b.Run(name, func(b *testing.B) {
+			var (
+				scanned uuid.UUID
+			)
+
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				if err := scanned.Scan(src); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package uuid
+
+import (
+	"errors"
+)
+
+// Variant returns the low two bits of the first byte. They are not part of the scope and are random
+// for UUIDs generated by New unless set explicitly with NewWithVariant.
+//
+// If the UUID is not initialized, 0 is returned.
+func (uuid *UUID) Variant() byte {
+	if uuid == nil {
+		return 0
+	}
+
+	return uuid.lowBits()
+}
+
+// NewWithVariant generates a new UUID like New but sets the low two bits of the first byte to the given
+// variant instead of random data. The variant must be between 0 and 3 and doesn't affect the scope.
+func NewWithVariant(scope string, variant byte) (*UUID, error) {
+	var (
+		uuid *UUID
+		err  error
+	)
+
+	if variant > 3 {
+		return nil, errors.New(ErrorBadVariant)
+	}
+
+	if !hasLowBits() {
+		return nil, errors.New(ErrorLayoutUnsupported)
+	}
+
+	uuid, err = New(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	uuid.setLowBits(variant)
+
+	return uuid, nil
+}
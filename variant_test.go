@@ -0,0 +1,53 @@
+package uuid_test
+
+import (
+	"github.com/4xoc/uuid"
+	"testing"
+)
+
+func TestVariant(t *testing.T) {
+	var (
+		myUUID  *uuid.UUID
+		myCopy  *uuid.UUID
+		nilPtr  *uuid.UUID
+		scanned uuid.UUID
+		variant byte
+		err     error
+	)
+
+	setupScopes(t, "one", "two")
+
+	if nilPtr.Variant() != 0 {
+		t.Error("nil UUID should have variant 0")
+	}
+
+	_, err = uuid.NewWithVariant("two", 4)
+	if err == nil || err.Error() != uuid.ErrorBadVariant {
+		t.Error("Expected error for variant 4")
+	}
+
+	_, err = uuid.NewWithVariant("ten", 1)
+	if err == nil || err.Error() != uuid.ErrorMissingScope {
+		t.Error("Expected error for unknown scope")
+	}
+
+	for variant = 0; variant < 4; variant++ {
+		myUUID, err = uuid.NewWithVariant("two", variant)
+		if err != nil {
+			t.Fatal("Expected UUID to be generated but failed with error ", err.Error())
+		}
+
+		if myUUID.Variant() != variant || myUUID.Scope() != "two" || !myUUID.ScopeMatches([]string{"two"}) {
+			t.Error("unexpected variant or scope of UUID ", myUUID.Hex())
+		}
+
+		myCopy = mustRead(t, myUUID.Hex())
+		if myCopy.Variant() != variant || myCopy.Scope() != "two" || !myCopy.ScopeMatches([]string{"two"}) {
+			t.Error("unexpected variant or scope of read UUID ", myCopy.Hex())
+		}
+
+		if err = scanned.Scan(myUUID.ToProtoBytes()); err != nil || scanned.Variant() != variant || scanned.Scope() != "two" {
+			t.Error("unexpected variant or scope of scanned UUID ", scanned.Hex())
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package uuid_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/4xoc/uuid"
+	"github.com/4xoc/uuid/uuidtest"
+	"testing"
+)
+
+// TestVectors checks every way of creating, parsing and encoding UUIDs against the test vectors of
+// uuidtest. If this test fails, the mapping between the binary and the text representation has changed,
+// which breaks stored UUIDs and other implementations.
+func TestVectors(t *testing.T) {
+	for _, set := range uuidtest.Vectors() {
+		t.Run(set.Name, func(t *testing.T) {
+			set.Install(t)
+
+			for index, vector := range set.Vectors {
+				var (
+					bin [16]byte
+				)
+
+				if _, err := hex.Decode(bin[:], []byte(vector.Bin)); err != nil {
+					t.Fatal("vector ", index, ": ", err)
+				}
+
+				if vector.Scope == "" {
+					checkInvalidVector(t, index, vector, bin)
+				} else {
+					checkVector(t, index, vector, bin)
+				}
+			}
+		})
+	}
+}
+
+// checkVector checks a vector of a known scope.
+func checkVector(t *testing.T, index int, vector uuidtest.Vector, bin [16]byte) {
+	var (
+		random  []byte
+		myUUID  *uuid.UUID
+		decoded uuid.UUID
+		data    []byte
+		value   interface{}
+		err     error
+	)
+
+	t.Helper()
+
+	random, _ = hex.DecodeString(vector.Random)
+
+	uuid.SetDirectEntropy(true)
+	restore := uuid.SetEntropySource(bytes.NewReader(random))
+	myUUID, err = uuid.New(vector.Scope)
+	restore()
+	uuid.SetDirectEntropy(false)
+
+	if err != nil || myUUID.Bin() != bin || myUUID.Hex() != vector.Hex {
+		t.Error("vector ", index, ": New results in ", myUUID.DebugString(), err)
+	}
+
+	for name, parse := range map[string]func() (*uuid.UUID, error){
+		"Read":    func() (*uuid.UUID, error) { return uuid.Read(vector.Hex) },
+		"FromRFC": func() (*uuid.UUID, error) { return uuid.FromRFC(bin) },
+		"ScanString": func() (*uuid.UUID, error) {
+			var myUUID uuid.UUID
+			return &myUUID, myUUID.Scan(vector.Hex)
+		},
+		"ScanBytes": func() (*uuid.UUID, error) {
+			var myUUID uuid.UUID
+			return &myUUID, myUUID.Scan(bin[:])
+		},
+		"UnmarshalText": func() (*uuid.UUID, error) {
+			var myUUID uuid.UUID
+			return &myUUID, myUUID.UnmarshalText([]byte(vector.Hex))
+		},
+		"UnmarshalJSON": func() (*uuid.UUID, error) {
+			var myUUID uuid.UUID
+			return &myUUID, json.Unmarshal([]byte(`"`+vector.Hex+`"`), &myUUID)
+		},
+		"UnmarshalCBOR": func() (*uuid.UUID, error) {
+			var myUUID uuid.UUID
+			return &myUUID, myUUID.UnmarshalCBOR(append([]byte{0xd8, 0x25, 0x50}, bin[:]...))
+		},
+	} {
+		myUUID, err = parse()
+		if err != nil || myUUID.Bin() != bin || myUUID.Scope() != vector.Scope {
+			t.Error("vector ", index, ": ", name, " results in ", myUUID.DebugString(), err)
+		}
+	}
+
+	decoded = *myUUID
+
+	if data, err = decoded.MarshalText(); err != nil || string(data) != vector.Hex {
+		t.Error("vector ", index, ": MarshalText results in ", string(data), err)
+	}
+
+	if data, err = json.Marshal(decoded); err != nil || string(data) != `"`+vector.Hex+`"` {
+		t.Error("vector ", index, ": json.Marshal results in ", string(data), err)
+	}
+
+	if value, err = decoded.MarshalYAML(); err != nil || value != vector.Hex {
+		t.Error("vector ", index, ": MarshalYAML results in ", value, err)
+	}
+
+	if value, err = decoded.Value(); err != nil || value != vector.Hex {
+		t.Error("vector ", index, ": Value results in ", value, err)
+	}
+
+	if data, err = decoded.MarshalCBOR(); err != nil || !bytes.Equal(data, append([]byte{0xd8, 0x25, 0x50}, bin[:]...)) {
+		t.Error("vector ", index, ": MarshalCBOR results in ", data, err)
+	}
+
+	if data, err = decoded.AppendBinary(nil); err != nil || !bytes.Equal(data, bin[:]) {
+		t.Error("vector ", index, ": AppendBinary results in ", data, err)
+	}
+}
+
+// checkInvalidVector checks that a vector of an unknown scope is rejected.
+func checkInvalidVector(t *testing.T, index int, vector uuidtest.Vector, bin [16]byte) {
+	var (
+		myUUID uuid.UUID
+		err    error
+	)
+
+	t.Helper()
+
+	if _, err = uuid.Read(vector.Hex); err != uuid.ErrBadScope {
+		t.Error("vector ", index, ": expected Read to fail with ErrBadScope but got ", err)
+	}
+
+	if _, err = uuid.FromRFC(bin); err != uuid.ErrBadScope {
+		t.Error("vector ", index, ": expected FromRFC to fail with ErrBadScope but got ", err)
+	}
+
+	if err = myUUID.Scan(bin[:]); err == nil {
+		t.Error("vector ", index, ": expected Scan to fail")
+	}
+
+	if err = myUUID.UnmarshalText([]byte(vector.Hex)); err == nil {
+		t.Error("vector ", index, ": expected UnmarshalText to fail")
+	}
+}
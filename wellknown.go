@@ -0,0 +1,111 @@
+package uuid
+
+import (
+	"errors"
+	"sync"
+)
+
+var (
+	// wellKnown holds the registered well-known UUIDs.
+	wellKnown struct {
+		mu sync.RWMutex
+		// byName maps the names of well-known UUIDs to their UUID.
+		byName map[string]UUID
+		// byBin maps the binary representation of well-known UUIDs to their name.
+		byBin map[[16]byte]string
+	}
+)
+
+// RegisterWellKnown registers a UUID with a fixed meaning (e.g. "the system user") under the given name.
+// The UUID must be valid (see Validate); names and UUIDs can only be registered once each.
+func RegisterWellKnown(name string, uuid *UUID) error {
+	var (
+		err error
+	)
+
+	if name == "" {
+		return errors.New(ErrorWellKnownExists)
+	}
+
+	err = uuid.Validate()
+	if err != nil {
+		return err
+	}
+
+	wellKnown.mu.Lock()
+	defer wellKnown.mu.Unlock()
+
+	if _, ok := wellKnown.byName[name]; ok {
+		return errors.New(ErrorWellKnownExists)
+	}
+
+	if _, ok := wellKnown.byBin[uuid.bin]; ok {
+		return errors.New(ErrorWellKnownExists)
+	}
+
+	if wellKnown.byName == nil {
+		wellKnown.byName = make(map[string]UUID)
+		wellKnown.byBin = make(map[[16]byte]string)
+	}
+
+	wellKnown.byName[name] = *uuid
+	wellKnown.byBin[uuid.bin] = name
+
+	return nil
+}
+
+// WellKnown returns a copy of the UUID registered under the given name.
+func WellKnown(name string) (*UUID, bool) {
+	var (
+		uuid UUID
+		ok   bool
+	)
+
+	wellKnown.mu.RLock()
+	uuid, ok = wellKnown.byName[name]
+	wellKnown.mu.RUnlock()
+
+	if !ok {
+		return nil, false
+	}
+
+	return &uuid, true
+}
+
+// WellKnownName returns the name the UUID is registered under, e.g. to annotate logs.
+func WellKnownName(uuid *UUID) (string, bool) {
+	var (
+		name string
+		ok   bool
+	)
+
+	if uuid == nil || uuid.scope == "" {
+		return "", false
+	}
+
+	wellKnown.mu.RLock()
+	name, ok = wellKnown.byBin[uuid.bin]
+	wellKnown.mu.RUnlock()
+
+	return name, ok
+}
+
+// wellKnownHex returns the canonical hex-strings of all well-known UUIDs by name.
+func wellKnownHex() map[string]string {
+	var (
+		result map[string]string
+		name   string
+		uuid   UUID
+	)
+
+	wellKnown.mu.RLock()
+	defer wellKnown.mu.RUnlock()
+
+	result = make(map[string]string, len(wellKnown.byName))
+
+	for name, uuid = range wellKnown.byName {
+		result[name] = formatHex(uuid.bin[:])
+	}
+
+	return result
+}
@@ -0,0 +1,96 @@
+package uuid_test
+
+import (
+	"github.com/4xoc/uuid"
+	"sync"
+	"testing"
+)
+
+func TestWellKnown(t *testing.T) {
+	var (
+		system *uuid.UUID
+		tenant *uuid.UUID
+		found  *uuid.UUID
+		name   string
+		ok     bool
+		wg     sync.WaitGroup
+		err    error
+	)
+
+	setupScopes(t, "user", "tenant")
+
+	system = mustRead(t, fixture("00000000-0000-0000-0000-000000000001"))
+	tenant = mustRead(t, fixture("04000000-0000-0000-0000-000000000001"))
+
+	if err = uuid.RegisterWellKnown("system", system); err != nil {
+		t.Fatal("Expected UUID to be registered but failed with error ", err.Error())
+	}
+
+	testCases := []struct {
+		name string
+		uuid *uuid.UUID
+		err  string
+	}{
+		{"system", tenant, uuid.ErrorWellKnownExists},
+		{"root", system, uuid.ErrorWellKnownExists},
+		{"system", system, uuid.ErrorWellKnownExists},
+		{"", tenant, uuid.ErrorWellKnownExists},
+		{"nobody", nil, uuid.ErrorUninitializedUUID},
+		{"nobody", &uuid.UUID{}, uuid.ErrorUninitializedUUID},
+	}
+
+	for index := range testCases {
+		err = uuid.RegisterWellKnown(testCases[index].name, testCases[index].uuid)
+		if err == nil || err.Error() != testCases[index].err {
+			t.Error("test case ", index, ": expected error ", testCases[index].err, " but got ", err)
+		}
+	}
+
+	//registering and looking up concurrently
+	for index := 0; index < 8; index++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := 0; i < 100; i++ {
+				uuid.WellKnown("system")
+				uuid.WellKnownName(tenant)
+			}
+		}()
+	}
+
+	if err = uuid.RegisterWellKnown("default tenant", tenant); err != nil {
+		t.Fatal("Expected UUID to be registered but failed with error ", err.Error())
+	}
+
+	wg.Wait()
+
+	found, ok = uuid.WellKnown("default tenant")
+	if !ok || !uuid.Equal(found, tenant) || found.Scope() != "tenant" {
+		t.Error("unexpected well-known UUID ", found)
+	}
+
+	//the returned UUID is a copy
+	found.Regenerate()
+
+	if found, _ = uuid.WellKnown("default tenant"); !uuid.Equal(found, tenant) {
+		t.Error("modifying the returned UUID changed the registry")
+	}
+
+	if name, ok = uuid.WellKnownName(mustRead(t, system.Hex())); !ok || name != "system" {
+		t.Error("unexpected name ", name)
+	}
+
+	if _, ok = uuid.WellKnown("nobody"); ok {
+		t.Error("unknown name should not be found")
+	}
+
+	if _, ok = uuid.WellKnownName(mustNew(t, "user")); ok {
+		t.Error("unknown UUID should not be found")
+	}
+
+	if _, ok = uuid.WellKnownName(nil); ok {
+		t.Error("nil UUID should not be found")
+	}
+}
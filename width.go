@@ -0,0 +1,106 @@
+package uuid
+
+import (
+	"errors"
+)
+
+// maxScopes is the number of scopes available with 8 bit scopes.
+const maxScopes int = 256
+
+var (
+	// scopeWidth holds the number of bits of the scope set with SetScopeWidth.
+	scopeWidth = 6
+
+	// wideScopes holds the binary representation of each scope with 8 bit scopes, see `scopes`.
+	wideScopes = func() [maxScopes]byte {
+		var (
+			table [maxScopes]byte
+			index int
+		)
+
+		for index = range table {
+			table[index] = byte(index)
+		}
+
+		return table
+	}()
+)
+
+// SetScopeWidth sets the number of bits used for the scope, either 6 (the default, 64 scopes) or 8 (256
+// scopes). It must be called before SetScopes. With 8 bit scopes the whole byte holding the scope is
+// used for it, so UUIDs of scopes beyond the first 64 can be set with SetWideScopes, but there are no
+// low two bits left: Variant always returns 0 and NewWithVariant, NewSub, SetSubScopes and NewWithNode
+// return ErrorLayoutUnsupported.
+//
+// Like the scope layout, the width can't be derived from a UUID. Every scope has another binary
+// representation with each width, so UUIDs generated with one width can't be read with the other and
+// the width must never change once UUIDs have been stored.
+func SetScopeWidth(bits int) error {
+	if bits != 6 && bits != 8 {
+		return errors.New(ErrorBadScopeWidth)
+	}
+
+	if scopesSet() {
+		return errors.New(ErrorScopeWidthSet)
+	}
+
+	scopeWidth = bits
+
+	return nil
+}
+
+// SetWideScopes works like SetScopes but accepts up to 256 scopes for use with 8 bit scopes (see
+// SetScopeWidth). With 6 bit scopes, only the first 64 entries can be used and ErrorOutOfScopes is
+// returned if any other entry is set.
+func SetWideScopes(newScopes [maxScopes]string) error {
+	var (
+		index int
+		rule  string
+	)
+
+	if scopesSet() {
+		return errors.New(ErrorScopesAlreadySet)
+	}
+
+	for index = range newScopes {
+		rule = checkScopeName(newScopes[index])
+		if rule != "" {
+			return &ScopeNameError{Index: index, Name: newScopes[index], Rule: rule}
+		}
+	}
+
+	return setScopeTable(newScopes[:])
+}
+
+// scopeMask returns the bits of the byte holding the scope that are used for the scope.
+func scopeMask() byte {
+	if scopeWidth == 8 {
+		return 0xff
+	}
+
+	return 0xfc
+}
+
+// scopeIndex returns the index of a scope, as used in scopeTable and `counters`, from its binary
+// representation.
+func scopeIndex(scopeByte byte) int {
+	if scopeWidth == 8 {
+		return int(scopeByte)
+	}
+
+	return int(scopeByte >> 2)
+}
+
+// scopeByteAt returns the binary representation of the scope with the given index.
+func scopeByteAt(index int) byte {
+	if scopeWidth == 8 {
+		return wideScopes[index]
+	}
+
+	return scopes[index]
+}
+
+// hasLowBits returns false if the scope width leaves no low bits for variants.
+func hasLowBits() bool {
+	return scopeWidth != 8
+}
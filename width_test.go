@@ -0,0 +1,140 @@
+package uuid_test
+
+import (
+	"errors"
+	"github.com/4xoc/uuid"
+	"strconv"
+	"testing"
+)
+
+// wideScopeNames returns n scope names "s0", "s1", ...
+func wideScopeNames(n int) [256]string {
+	var (
+		names [256]string
+	)
+
+	for index := 0; index < n; index++ {
+		names[index] = "s" + strconv.Itoa(index)
+	}
+
+	return names
+}
+
+func TestScopeWidth(t *testing.T) {
+	var (
+		myUUID   *uuid.UUID
+		myCopy   *uuid.UUID
+		min, max *uuid.UUID
+		scopes   int
+		err      error
+	)
+
+	uuid.ResetScopes()
+	t.Cleanup(uuid.ResetScopes)
+
+	if err = uuid.SetScopeWidth(7); err == nil || err.Error() != uuid.ErrorBadScopeWidth {
+		t.Error("Expected ErrorBadScopeWidth but got ", err)
+	}
+
+	if err = uuid.SetScopeWidth(8); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = uuid.SetWideScopes(wideScopeNames(200)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = uuid.SetScopeWidth(6); err == nil || err.Error() != uuid.ErrorScopeWidthSet {
+		t.Error("Expected ErrorScopeWidthSet but got ", err)
+	}
+
+	for i := 0; i < 100; i++ {
+		myUUID = mustNew(t, "s199")
+
+		if myUUID.Bin()[0] != 199 || myUUID.Variant() != 0 {
+			t.Fatal("the whole first byte should hold the scope ", myUUID.Hex())
+		}
+	}
+
+	myCopy = mustRead(t, myUUID.Hex())
+	if myCopy.Scope() != "s199" || myCopy.Validate() != nil {
+		t.Error("unexpected scope ", myCopy.Scope())
+	}
+
+	//scope 1 with 6 bit scopes is scope 4 with 8 bit scopes
+	if mustRead(t, "04000000-0000-0000-0000-000000000000").Scope() != "s4" {
+		t.Error("unexpected scope of a UUID generated with 6 bit scopes")
+	}
+
+	if _, err = uuid.Read("c8000000-0000-0000-0000-000000000000"); err != uuid.ErrBadScope {
+		t.Error("Expected ErrBadScope for unused scope but got ", err)
+	}
+
+	min, max, err = uuid.ScopeBounds("s199")
+	if err != nil || min.Hex() != "c7000000-0000-0000-0000-000000000000" ||
+		max.Hex() != "c7ffffff-ffff-ffff-ffff-ffffffffffff" {
+		t.Error("unexpected bounds ", min.Hex(), " ", max.Hex(), " ", err)
+	}
+
+	if _, err = max.Next(); err == nil || err.Error() != uuid.ErrorScopeOverflow {
+		t.Error("Expected ErrorScopeOverflow but got ", err)
+	}
+
+	for scope, scopeByte := range uuid.ScopesSeq() {
+		if scope != "s"+strconv.Itoa(int(scopeByte)) {
+			t.Error("unexpected binary representation ", scopeByte, " of ", scope)
+		}
+
+		scopes++
+	}
+
+	if scopes != 200 {
+		t.Error("expected 200 scopes but got ", scopes)
+	}
+
+	uuid.EnableCounters()
+	mustNew(t, "s150")
+
+	if uuid.Counters()["s150"] != 1 || uuid.Counters()["s199"] != 0 {
+		t.Error("unexpected counters ", uuid.Counters()["s150"])
+	}
+
+	if _, err = uuid.NewWithVariant("s1", 1); err == nil || err.Error() != uuid.ErrorLayoutUnsupported {
+		t.Error("Expected ErrorLayoutUnsupported but got ", err)
+	}
+
+	if err = uuid.SetSubScopes("s1", [4]string{"a"}); err == nil || err.Error() != uuid.ErrorLayoutUnsupported {
+		t.Error("Expected ErrorLayoutUnsupported but got ", err)
+	}
+}
+
+func TestSetWideScopes(t *testing.T) {
+	var (
+		names   [256]string
+		nameErr *uuid.ScopeNameError
+		err     error
+	)
+
+	uuid.ResetScopes()
+	t.Cleanup(uuid.ResetScopes)
+
+	//only 64 scopes fit into 6 bits
+	if err = uuid.SetWideScopes(wideScopeNames(65)); err == nil || err.Error() != uuid.ErrorOutOfScopes {
+		t.Error("Expected ErrorOutOfScopes but got ", err)
+	}
+
+	names = wideScopeNames(3)
+	names[2] = "Bad"
+
+	if err = uuid.SetWideScopes(names); !errors.As(err, &nameErr) || nameErr.Index != 2 {
+		t.Error("Expected ScopeNameError but got ", err)
+	}
+
+	if err = uuid.SetWideScopes(wideScopeNames(64)); err != nil {
+		t.Fatal(err)
+	}
+
+	if mustNew(t, "s63").Bin()[0]&0xfc != 0xfc {
+		t.Error("six bit scopes should keep their binary representation")
+	}
+}
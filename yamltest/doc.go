@@ -0,0 +1,5 @@
+// Package yamltest tests the YAML support of uuid.UUID against the decoder and encoder of
+// gopkg.in/yaml.v3. It doesn't export anything.
+//
+// The package lives in its own module so that the uuid package doesn't depend on yaml.
+package yamltest
@@ -0,0 +1,10 @@
+module github.com/4xoc/uuid/yamltest
+
+go 1.24
+
+require (
+	github.com/4xoc/uuid v0.0.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+replace github.com/4xoc/uuid => ../
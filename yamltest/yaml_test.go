@@ -0,0 +1,183 @@
+package yamltest_test
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/4xoc/uuid"
+	"gopkg.in/yaml.v3"
+)
+
+type config struct {
+	Owner uuid.UUID   `yaml:"owner"`
+	Users []uuid.UUID `yaml:"users,omitempty"`
+}
+
+type optional struct {
+	Owner *uuid.UUID   `yaml:"owner"`
+	Users []*uuid.UUID `yaml:"users,omitempty"`
+}
+
+func TestMain(m *testing.M) {
+	if err := uuid.SetScopes([64]string{"one", "two"}); err != nil {
+		panic(err)
+	}
+
+	os.Exit(m.Run())
+}
+
+func TestDecode(t *testing.T) {
+	var (
+		myUUID  *uuid.UUID
+		decoded config
+		err     error
+	)
+
+	myUUID, err = uuid.Read("0529a1d0-84f3-4d8d-b6cc-682d1ca34dae")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, input := range []string{
+		"owner: 0529a1d0-84f3-4d8d-b6cc-682d1ca34dae",
+		"owner: \"0529a1d0-84f3-4d8d-b6cc-682d1ca34dae\"",
+		"owner: '0529a1d0-84f3-4d8d-b6cc-682d1ca34dae'",
+		"owner: !!str 0529a1d0-84f3-4d8d-b6cc-682d1ca34dae",
+	} {
+		decoded = config{}
+
+		if err = yaml.Unmarshal([]byte(input), &decoded); err != nil {
+			t.Error("failed to decode ", input, ": ", err)
+			continue
+		}
+
+		if decoded.Owner.Bin() != myUUID.Bin() || decoded.Owner.Scope() != "two" {
+			t.Error("unexpected UUID ", decoded.Owner.Hex(), " decoded from ", input)
+		}
+	}
+
+	//empty strings reset the UUID
+	for _, input := range []string{"owner: \"\"", "owner: ''"} {
+		decoded = config{Owner: *myUUID}
+
+		if err = yaml.Unmarshal([]byte(input), &decoded); err != nil || decoded.Owner != (uuid.UUID{}) {
+			t.Error("expected zero value for ", input, " but got ", decoded.Owner.Hex(), " ", err)
+		}
+	}
+
+	if err = yaml.Unmarshal([]byte("users:\n- 0529a1d0-84f3-4d8d-b6cc-682d1ca34dae\n- \"\"\n"), &decoded); err != nil ||
+		len(decoded.Users) != 2 || !uuid.Equal(&decoded.Users[0], myUUID) || decoded.Users[1] != (uuid.UUID{}) {
+		t.Error("unexpected sequence ", decoded.Users, " ", err)
+	}
+}
+
+// TestDecodeNull documents that yaml.v3 doesn't call UnmarshalYAML for null.
+func TestDecodeNull(t *testing.T) {
+	var (
+		myUUID  *uuid.UUID
+		decoded config
+		ptr     optional
+		err     error
+	)
+
+	myUUID, err = uuid.Read("0529a1d0-84f3-4d8d-b6cc-682d1ca34dae")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//a missing value is null as well
+	for _, input := range []string{"owner: null", "owner: ~", "owner: Null", "owner: NULL", "owner:"} {
+		//a UUID is left unchanged
+		decoded = config{Owner: *myUUID}
+
+		if err = yaml.Unmarshal([]byte(input), &decoded); err != nil || !uuid.Equal(&decoded.Owner, myUUID) {
+			t.Error("expected unchanged UUID for ", input, " but got ", decoded.Owner.Hex(), " ", err)
+		}
+
+		//a pointer is set to nil
+		ptr = optional{Owner: myUUID}
+
+		if err = yaml.Unmarshal([]byte(input), &ptr); err != nil || ptr.Owner != nil {
+			t.Error("expected nil for ", input, " but got ", ptr.Owner, " ", err)
+		}
+	}
+
+	//null entries of sequences are dropped unless they are pointers
+	if err = yaml.Unmarshal([]byte("users:\n- 0529a1d0-84f3-4d8d-b6cc-682d1ca34dae\n- ~\n"), &decoded); err != nil ||
+		len(decoded.Users) != 1 || !uuid.Equal(&decoded.Users[0], myUUID) {
+		t.Error("unexpected sequence ", decoded.Users, " ", err)
+	}
+
+	if err = yaml.Unmarshal([]byte("users:\n- 0529a1d0-84f3-4d8d-b6cc-682d1ca34dae\n- null\n"), &ptr); err != nil ||
+		len(ptr.Users) != 2 || !uuid.Equal(ptr.Users[0], myUUID) || ptr.Users[1] != nil {
+		t.Error("unexpected sequence ", ptr.Users, " ", err)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	var (
+		decoded config
+		err     error
+	)
+
+	testCases := []struct {
+		input string
+		err   error
+	}{
+		{"owner: foo", uuid.ErrBadString},
+		{"owner: 42", uuid.ErrBadString},
+		{"owner: fc000000-0000-0000-0000-000000000000", uuid.ErrBadScope},
+		{"owner: \"fc000000-0000-0000-0000-000000000000\"", uuid.ErrBadScope},
+		{"owner: [0529a1d0-84f3-4d8d-b6cc-682d1ca34dae]", nil},
+		{"owner: {hex: 0529a1d0-84f3-4d8d-b6cc-682d1ca34dae}", nil},
+	}
+
+	for index := range testCases {
+		decoded = config{}
+
+		err = yaml.Unmarshal([]byte(testCases[index].input), &decoded)
+		if err == nil || testCases[index].err != nil && !errors.Is(err, testCases[index].err) {
+			t.Error("test case ", index, ": expected error ", testCases[index].err, " but got ", err)
+		}
+
+		if decoded.Owner != (uuid.UUID{}) {
+			t.Error("test case ", index, ": failed decode didn't reset the UUID")
+		}
+	}
+}
+
+func TestEncode(t *testing.T) {
+	var (
+		myUUID  *uuid.UUID
+		decoded config
+		out     []byte
+		err     error
+	)
+
+	myUUID, err = uuid.New("two")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, err = yaml.Marshal(config{Owner: *myUUID, Users: []uuid.UUID{*myUUID, {}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(out), "owner: "+myUUID.Hex()+"\n") || !strings.Contains(string(out), "- null\n") {
+		t.Error("unexpected YAML ", string(out))
+	}
+
+	if err = yaml.Unmarshal(out, &decoded); err != nil || !uuid.Equal(&decoded.Owner, myUUID) ||
+		len(decoded.Users) != 1 || !uuid.Equal(&decoded.Users[0], myUUID) {
+		t.Error("YAML doesn't round-trip: ", string(out), " ", err)
+	}
+
+	//uninitialized UUIDs are encoded as null
+	out, err = yaml.Marshal(config{})
+	if err != nil || string(out) != "owner: null\n" {
+		t.Error("unexpected YAML of uninitialized UUID ", string(out), " ", err)
+	}
+}